@@ -0,0 +1,258 @@
+package kanka
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Ability contains information about a specific ability.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/abilities
+type Ability struct {
+	SimpleAbility
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
+
+	Attributes   Attributes   `json:"attributes"`
+	EntityEvents EntityEvents `json:"entity_events"`
+	EntityFiles  EntityFiles  `json:"entity_files"`
+	EntityNotes  EntityNotes  `json:"entity_notes"`
+	Relations    Relations    `json:"relations"`
+	Inventory    Inventory    `json:"inventory"`
+}
+
+// SimpleAbility contains only the simple information about an ability.
+// SimpleAbility is primarily used to create new abilities for posting to Kanka.
+type SimpleAbility struct {
+	Name            string   `json:"name"`
+	Entry           string   `json:"entry,omitempty"`
+	Type            string   `json:"type,omitempty"`
+	Charges         string   `json:"charges,omitempty"`
+	ParentAbilityID int      `json:"ability_id,omitempty"`
+	Tags            []int    `json:"tags,omitempty"`
+	IsPrivate       bool     `json:"is_private,omitempty"`
+	IsTemplate      *bool    `json:"is_template,omitempty"`
+	Image           string   `json:"image,omitempty"`
+	ImageURL        string   `json:"image_url,omitempty"`
+	ImageUUID       string   `json:"image_uuid,omitempty"`
+	FocusX          *float64 `json:"focus_x,omitempty"`
+	FocusY          *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL  string   `json:"header_image_url,omitempty"`
+	HeaderUUID      string   `json:"entity_header_uuid,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
+}
+
+// MarshalJSON marshals the SimpleAbility into its JSON-encoded form if it
+// has the required populated fields.
+func (sa SimpleAbility) MarshalJSON() ([]byte, error) {
+	if err := requireField("SimpleAbility", "Name", sa.Name); err != nil {
+		return nil, err
+	}
+
+	type alias SimpleAbility
+	return json.Marshal(alias(sa))
+}
+
+// Simple returns a copy of the SimpleAbility data of the Ability, ready to be
+// modified and passed to Update. The path of the current image is left out and
+// the slices of the copy do not share memory with the Ability.
+func (a *Ability) Simple() SimpleAbility {
+	s := a.SimpleAbility
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+
+	return s
+}
+
+// AbilityService handles communication with the Ability endpoint.
+type AbilityService service
+
+// base returns the baseService implementing the common operations of the
+// AbilityService.
+func (as *AbilityService) base() baseService[Ability, SimpleAbility] {
+	return baseService[Ability, SimpleAbility]{
+		service: (*service)(as),
+		kind:    "Ability",
+		entity:  "ability",
+		label:   func(abl SimpleAbility) string { return abl.Name },
+	}
+}
+
+// Index returns the list of all Abilities in the Campaign associated with campID.
+// If a non-nil time is provided, Index will only return Abilities that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Abilities that were decoded.
+func (as *AbilityService) Index(campID int, sync *time.Time) ([]*Ability, error) {
+	return as.base().Index(campID, sync)
+}
+
+// IndexAll returns the list of all Abilities in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Abilities that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Abilities that were decoded.
+func (as *AbilityService) IndexAll(campID int, sync *time.Time) ([]*Ability, error) {
+	return as.base().IndexAll(campID, sync)
+}
+
+// GetMany returns the Abilities associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Abilities, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Abilities that were
+// found.
+func (as *AbilityService) GetMany(campID int, ids []int) (map[int]*Ability, error) {
+	return as.base().GetMany(campID, ids)
+}
+
+// Count returns the number of Abilities in the Campaign associated with campID
+// without retrieving them.
+func (as *AbilityService) Count(campID int) (int, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(as.end)
+
+	n, err := as.client.count(end)
+	if err != nil {
+		return 0, fmt.Errorf("cannot count Abilities in Campaign (ID: %d): %w", campID, err)
+	}
+
+	return n, nil
+}
+
+// Get returns the Ability associated with ablID from the Campaign
+// associated with campID.
+func (as *AbilityService) Get(campID int, ablID int) (*Ability, error) {
+	return as.base().Get(campID, ablID)
+}
+
+// GetByName returns the Ability with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Ability has the name and ErrAmbiguous if more than one does.
+func (as *AbilityService) GetByName(campID int, name string) (*Ability, error) {
+	return as.base().GetByName(campID, name)
+}
+
+// Create creates a new Ability in the Campaign associated with campID using
+// the provided SimpleAbility data.
+// Create returns the newly created Ability.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (as *AbilityService) Create(campID int, abl SimpleAbility, opts ...CreateOption) (*Ability, error) {
+	return as.base().Create(campID, abl, opts...)
+}
+
+// CreateWithResult creates a new Ability in the Campaign associated with campID
+// using the provided SimpleAbility data.
+// CreateWithResult returns the newly created Ability along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (as *AbilityService) CreateWithResult(campID int, abl SimpleAbility, opts ...CreateOption) (*Ability, *WriteResult, error) {
+	return as.base().CreateWithResult(campID, abl, opts...)
+}
+
+// Update updates an existing Ability associated with ablID from the
+// Campaign associated with campID using the provided SimpleAbility data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Ability returned by Kanka, provide its SimpleAbility.
+// Update returns the newly updated Ability.
+func (as *AbilityService) Update(campID int, ablID int, abl SimpleAbility) (*Ability, error) {
+	return as.base().Update(campID, ablID, abl)
+}
+
+// UpdateWithResult updates an existing Ability associated with ablID from the
+// Campaign associated with campID using the provided SimpleAbility data, as
+// Update does.
+// UpdateWithResult returns the newly updated Ability along with the metadata of
+// Kanka's response, such as its status code.
+func (as *AbilityService) UpdateWithResult(campID int, ablID int, abl SimpleAbility) (*Ability, *WriteResult, error) {
+	return as.base().UpdateWithResult(campID, ablID, abl)
+}
+
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Ability associated with ablID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Ability is left unchanged.
+// Clear returns the newly updated Ability.
+func (as *AbilityService) Clear(campID int, ablID int, fields ...string) (*Ability, error) {
+	return as.base().Clear(campID, ablID, fields...)
+}
+
+// SetHeader sets the header image of an existing Ability associated with ablID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Ability on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Ability.
+func (as *AbilityService) SetHeader(campID int, ablID int, uuid string) (*Ability, error) {
+	return as.base().SetHeader(campID, ablID, uuid)
+}
+
+// SetHeaderURL sets the header image of an existing Ability associated with
+// ablID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Ability.
+func (as *AbilityService) SetHeaderURL(campID int, ablID int, url string) (*Ability, error) {
+	return as.base().SetHeaderURL(campID, ablID, url)
+}
+
+// Delete deletes an existing Ability associated with ablID from the
+// Campaign associated with campID.
+func (as *AbilityService) Delete(campID int, ablID int) error {
+	return as.base().Delete(campID, ablID)
+}
+
+// Parents returns the chain of parent Abilities of the provided Ability from
+// the Campaign associated with campID, starting with its direct parent and
+// ending with the root of its ability tree. Returns an empty list if the
+// Ability has no parent.
+func (as *AbilityService) Parents(campID int, abl *Ability) ([]*Ability, error) {
+	return as.parents(abl, func(id int) (*Ability, error) {
+		return as.Get(campID, id)
+	})
+}
+
+// parents returns the chain of parent Abilities of the provided Ability using
+// the provided function to fetch each parent. Returns an error if the chain
+// contains a cycle.
+func (as *AbilityService) parents(abl *Ability, get func(int) (*Ability, error)) ([]*Ability, error) {
+	chain := []*Ability{}
+	seen := map[int]bool{abl.ID: true}
+
+	for id := abl.ParentAbilityID; id != 0; {
+		if seen[id] {
+			return nil, fmt.Errorf("cannot resolve parents of Ability (ID: %d): cycle at Ability (ID: %d)", abl.ID, id)
+		}
+		seen[id] = true
+
+		parent, err := get(id)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve parents of Ability (ID: %d): %w", abl.ID, err)
+		}
+
+		chain = append(chain, parent)
+		id = parent.ParentAbilityID
+	}
+
+	return chain, nil
+}
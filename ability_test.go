@@ -0,0 +1,651 @@
+package kanka
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testAbilityIndex  string = "test_data/ability_index.json"
+	testAbilityGet    string = "test_data/ability_get.json"
+	testAbilityCreate string = "test_data/ability_create.json"
+	testAbilityUpdate string = "test_data/ability_update.json"
+)
+
+func TestAbilityService_Index(t *testing.T) {
+	abls := []*Ability{
+		{
+			SimpleAbility: SimpleAbility{
+				Name: "Fireball",
+				Type: "Spell",
+			},
+		},
+		{
+			SimpleAbility: SimpleAbility{
+				Name: "Evocation",
+				Type: "School",
+			},
+		},
+		{
+			SimpleAbility: SimpleAbility{
+				Name: "Second Wind",
+				Type: "Feat",
+			},
+		},
+	}
+	n := time.Now()
+	now := &n
+
+	type args struct {
+		campID int
+		sync   *time.Time
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*Ability
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testAbilityIndex,
+			args:    args{campID: 5272, sync: now},
+			want:    abls,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testAbilityIndex,
+			args:    args{campID: -123, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Abilities.Index(test.args.campID, test.args.sync)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAbilityService_Get(t *testing.T) {
+	abl := &Ability{
+		SimpleAbility: SimpleAbility{
+			Name:      "Fireball",
+			Entry:     "\n<p>A very tasty plant whose seeds taste of pepper, Fireball possesses a hidden danger. Its famous seeds, used often in Dwarven and Gnomish cooking, are incredibly flammable and will explode if left too long near a fire.</p>\n",
+			Image:     "abilities/2XAeetUHrO0TDB0lU6tAsa46wvdIEmZ1jmsSUC20.jpeg",
+			IsPrivate: false,
+			Tags:      []int{3742},
+			Type:      "Spell",
+		},
+		ID:             2142,
+		ImageFull:      "https://kanka-user-assets.s3.eu-central-1.amazonaws.com/abilities/2XAeetUHrO0TDB0lU6tAsa46wvdIEmZ1jmsSUC20.jpeg",
+		ImageThumb:     "https://kanka-user-assets.s3.eu-central-1.amazonaws.com/abilities/2XAeetUHrO0TDB0lU6tAsa46wvdIEmZ1jmsSUC20_thumb.jpeg",
+		HasCustomImage: true,
+		EntityID:       86314,
+		CreatedBy:      5600,
+		UpdatedBy:      5600,
+	}
+
+	type args struct {
+		campID int
+		ablID  int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *Ability
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testAbilityGet,
+			args:    args{campID: 5272, ablID: 2142},
+			want:    abl,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testAbilityGet,
+			args:    args{campID: -123, ablID: 2142},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid ablID",
+			status:  http.StatusOK,
+			file:    testAbilityGet,
+			args:    args{campID: 5272, ablID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testAbilityGet,
+			args:    args{campID: -123, ablID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, ablID: 2142},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, ablID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, ablID: 2142},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, ablID: 2142},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, ablID: 2142},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Abilities.Get(test.args.campID, test.args.ablID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAbilityService_Create(t *testing.T) {
+	abl := SimpleAbility{
+		Name: "Magic Missile",
+		Type: "Spell",
+	}
+	type args struct {
+		campID int
+		abl    SimpleAbility
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *Ability
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testAbilityCreate,
+			args:    args{campID: 5272, abl: abl},
+			want:    &Ability{SimpleAbility: abl},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testAbilityCreate,
+			args:    args{campID: -123, abl: abl},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid ability",
+			status:  http.StatusOK,
+			file:    testAbilityCreate,
+			args:    args{campID: 5272, abl: SimpleAbility{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testAbilityCreate,
+			args:    args{campID: -123, abl: SimpleAbility{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, abl: abl},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, abl: SimpleAbility{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, abl: abl},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, abl: abl},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, abl: abl},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Abilities.Create(test.args.campID, test.args.abl)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAbilityService_Update(t *testing.T) {
+	abl := SimpleAbility{
+		Name: "Lightning Bolt",
+		Type: "Spell",
+	}
+	type args struct {
+		campID int
+		ablID  int
+		abl    SimpleAbility
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *Ability
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testAbilityUpdate,
+			args:    args{campID: 5272, ablID: 111, abl: abl},
+			want:    &Ability{SimpleAbility: abl, ID: 111},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testAbilityUpdate,
+			args:    args{campID: -123, ablID: 111, abl: abl},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid ablID",
+			status:  http.StatusOK,
+			file:    testAbilityUpdate,
+			args:    args{campID: 5272, ablID: -123, abl: abl},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid ability",
+			status:  http.StatusOK,
+			file:    testAbilityUpdate,
+			args:    args{campID: 5272, ablID: 111, abl: SimpleAbility{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testAbilityUpdate,
+			args:    args{campID: -123, ablID: -123, abl: SimpleAbility{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, ablID: 111, abl: abl},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, ablID: -123, abl: SimpleAbility{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, ablID: 111, abl: abl},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, ablID: 111, abl: abl},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, ablID: 111, abl: abl},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Abilities.Update(test.args.campID, test.args.ablID, test.args.abl)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAbilityService_Delete(t *testing.T) {
+	type args struct {
+		campID int
+		ablID  int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid args",
+			status:  http.StatusOK,
+			args:    args{campID: 5272, ablID: 111},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, invalid campID",
+			status:  http.StatusOK,
+			args:    args{campID: -123, ablID: 111},
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, invalid ablID",
+			status:  http.StatusOK,
+			args:    args{campID: 5272, ablID: -123},
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, invalid args",
+			status:  http.StatusOK,
+			args:    args{campID: -123, ablID: -123},
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			args:    args{campID: 5272, ablID: 111},
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			args:    args{campID: 5272, ablID: 111},
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			args:    args{campID: 5272, ablID: 111},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(testFileEmpty)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			err = c.Abilities.Delete(test.args.campID, test.args.ablID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+		})
+	}
+}
+
+// testAbilityTreeClient returns a Client connected to a test server that
+// serves a small ability tree for the Campaign with ID 5272. Ability 1 is a
+// child of ability 2, which is a child of the root ability 3. Abilities 4 and
+// 5 are each other's parent. The returned map counts the requests per path.
+func testAbilityTreeClient(t *testing.T) (*Client, *httptest.Server, map[string]int) {
+	bodies := map[string]string{
+		"/campaigns/5272/abilities/1":                      `{"data":{"id":1,"name":"Fireball","ability_id":2}}`,
+		"/campaigns/5272/abilities/2":                      `{"data":{"id":2,"name":"Evocation","ability_id":3}}`,
+		"/campaigns/5272/abilities/3":                      `{"data":{"id":3,"name":"Arcane"}}`,
+		"/campaigns/5272/abilities/4":                      `{"data":{"id":4,"name":"Ouroboros","ability_id":5}}`,
+		"/campaigns/5272/abilities/5":                      `{"data":{"id":5,"name":"Serpent","ability_id":4}}`,
+		"/campaigns/5272/entities/430214/entity_abilities": `{"data":[{"id":10,"entity_id":430214,"ability_id":1},{"id":11,"entity_id":430214,"ability_id":2}]}`,
+		"/campaigns/5272/entities/430215/entity_abilities": `{"data":[{"id":12,"entity_id":430215,"ability_id":4}]}`,
+		"/campaigns/5272/entities/430216/entity_abilities": `{"data":[{"id":13,"entity_id":430216,"ability_id":6}]}`,
+		"/campaigns/5272/entities/430217/entity_abilities": `{"data":[]}`,
+	}
+
+	counts := make(map[string]int)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counts[r.URL.Path]++
+
+		body, ok := bodies[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	return c, ts, counts
+}
+
+func TestAbilityService_Parents(t *testing.T) {
+	tests := []struct {
+		name    string
+		abl     *Ability
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "Leaf ability",
+			abl:     &Ability{SimpleAbility: SimpleAbility{Name: "Fireball", ParentAbilityID: 2}, ID: 1},
+			want:    []string{"Evocation", "Arcane"},
+			wantErr: false,
+		},
+		{
+			name:    "Root ability",
+			abl:     &Ability{SimpleAbility: SimpleAbility{Name: "Arcane"}, ID: 3},
+			want:    []string{},
+			wantErr: false,
+		},
+		{
+			name:    "Cyclic ability tree",
+			abl:     &Ability{SimpleAbility: SimpleAbility{Name: "Ouroboros", ParentAbilityID: 5}, ID: 4},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Missing parent",
+			abl:     &Ability{SimpleAbility: SimpleAbility{Name: "Orphan", ParentAbilityID: 6}, ID: 7},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, _ := testAbilityTreeClient(t)
+			defer ts.Close()
+
+			got, err := c.Abilities.Parents(5272, test.abl)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+
+			names := []string{}
+			for _, p := range got {
+				names = append(names, p.Name)
+			}
+			if diff := cmp.Diff(names, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -4,9 +4,8 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // Attribute represents a distinct detail relating to the parent entity.
@@ -30,19 +29,45 @@ type SimpleAttribute struct {
 	Type         string `json:"type,omitempty"`
 	IsPrivate    bool   `json:"is_private,omitempty"`
 	APIKey       string `json:"api_key,omitempty"`
+
+	// IsPinned shows the attribute in the header of its entity if true and
+	// removes it from the header if false.
+	IsPinned *bool `json:"is_pinned,omitempty"`
+	// Visibility is the group of campaign members allowed to view the
+	// attribute, such as VisibilityAdmin for a stat only the game master may
+	// see. IsPrivate hides the attribute from everyone but the admins
+	// regardless of its Visibility.
+	Visibility Visibility `json:"visibility,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleAttribute into its JSON-encoded form if it
 // has the required populated fields.
 func (sa SimpleAttribute) MarshalJSON() ([]byte, error) {
-	if blank.Is(sa.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleAttribute into JSON with a missing Name")
+	if err := requireField("SimpleAttribute", "Name", sa.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleAttribute
 	return json.Marshal(alias(sa))
 }
 
+// matches returns true if the SimpleAttribute already has the provided
+// desired data. A nil IsPinned or an empty Visibility in the desired data
+// matches any state.
+func (sa SimpleAttribute) matches(want SimpleAttribute) bool {
+	if want.IsPinned != nil && (sa.IsPinned == nil || *sa.IsPinned != *want.IsPinned) {
+		return false
+	}
+
+	if want.Visibility != "" && sa.Visibility != want.Visibility {
+		return false
+	}
+
+	sa.IsPinned, want.IsPinned = nil, nil
+	sa.Visibility, want.Visibility = "", ""
+	return sa == want
+}
+
 // Attributes wraps a list of attributes.
 // Attributes exists to satisfy the API's JSON structure.
 type Attributes struct {
@@ -50,6 +75,67 @@ type Attributes struct {
 	Sync time.Time    `json:"sync"`
 }
 
+// UnmarshalJSON unmarshals the JSON-encoded data into the Attributes. A null value
+// or a null list is unmarshaled into an empty list rather than a nil one.
+func (as *Attributes) UnmarshalJSON(b []byte) error {
+	type alias Attributes
+	var al alias
+
+	if !isNull(b) {
+		if err := json.Unmarshal(b, &al); err != nil {
+			return err
+		}
+	}
+
+	if al.Data == nil {
+		al.Data = []*Attribute{}
+	}
+
+	*as = Attributes(al)
+	return nil
+}
+
+// AttributeTypeSection is the Type of an attribute that starts a new section.
+const AttributeTypeSection string = "section"
+
+// AttributeSection is a named group of attributes as displayed on an entity's
+// attribute sheet.
+type AttributeSection struct {
+	// Name is the name of the section attribute that starts the section. The
+	// attributes listed before the first section have an empty Name.
+	Name       string
+	Attributes []*Attribute
+}
+
+// Sections returns the wrapped attributes grouped into sections in the order
+// they are displayed. Attributes are ordered by their DefaultOrder and each
+// attribute with the section Type starts a new section. Attributes with the
+// same DefaultOrder keep their original order.
+func (as Attributes) Sections() []AttributeSection {
+	atrs := make([]*Attribute, len(as.Data))
+	copy(atrs, as.Data)
+
+	sort.SliceStable(atrs, func(i, j int) bool {
+		return atrs[i].DefaultOrder < atrs[j].DefaultOrder
+	})
+
+	var secs []AttributeSection
+	for _, atr := range atrs {
+		if atr.Type == AttributeTypeSection {
+			secs = append(secs, AttributeSection{Name: atr.Name})
+			continue
+		}
+
+		if len(secs) == 0 {
+			secs = append(secs, AttributeSection{})
+		}
+		last := &secs[len(secs)-1]
+		last.Attributes = append(last.Attributes, atr)
+	}
+
+	return secs
+}
+
 // AttributeService handles communication with the Attribute endpoint.
 type AttributeService service
 
@@ -57,6 +143,8 @@ type AttributeService service
 // entID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Attributes that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Attributes that were decoded.
 func (as *AttributeService) Index(campID int, entID int, sync *time.Time) ([]*Attribute, error) {
 	var err error
 	end := EndpointCampaign
@@ -75,15 +163,29 @@ func (as *AttributeService) Index(campID int, entID int, sync *time.Time) ([]*At
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*Attribute `json:"data"`
-	}
+	var wrap response[[]json.RawMessage]
 
 	if err = as.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get Attribute Index from Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	var list []*Attribute
+	if err = decodeList(wrap.Data, &list, as.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode Attribute Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// IndexAll returns the list of all Attributes for the entity associated with
+// entID in the Campaign associated with campID from every page of the list.
+// Each page is a separate request subject to the rate limit of the Client.
+// If a non-nil time is provided, IndexAll will only return Attributes that
+// have been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Attributes that were decoded.
+func (as *AttributeService) IndexAll(campID int, entID int, sync *time.Time) ([]*Attribute, error) {
+	return entityIndexAll[Attribute](as.client, campID, entID, as.end, "Attribute", sync)
 }
 
 // Get returns the Attribute associated with atrID for the entity associated
@@ -106,9 +208,7 @@ func (as *AttributeService) Get(campID int, entID int, atrID int) (*Attribute, e
 		return nil, fmt.Errorf("invalid Attribute ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *Attribute `json:"data"`
-	}
+	var wrap response[*Attribute]
 
 	if err = as.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get Attribute (ID: %d) from Campaign (ID: %d): %w", atrID, campID, err)
@@ -139,9 +239,7 @@ func (as *AttributeService) Create(campID int, entID int, atr SimpleAttribute) (
 		return nil, fmt.Errorf("cannot marshal SimpleAttribute (Name: %s): %w", atr.Name, err)
 	}
 
-	var wrap struct {
-		Data *Attribute `json:"data"`
-	}
+	var wrap response[*Attribute]
 
 	if err = as.client.post(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot create Attribute (Name: %s) for Campaign (ID: %d): %w", atr.Name, campID, err)
@@ -177,9 +275,7 @@ func (as *AttributeService) Update(campID int, entID int, atrID int, atr SimpleA
 		return nil, fmt.Errorf("cannot marshal SimpleAttribute (Name: %s): %w", atr.Name, err)
 	}
 
-	var wrap struct {
-		Data *Attribute `json:"data"`
-	}
+	var wrap response[*Attribute]
 
 	if err = as.client.put(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot update Attribute (Name: %s) for Campaign (ID: %d): '%w'", atr.Name, campID, err)
@@ -214,3 +310,87 @@ func (as *AttributeService) Delete(campID int, entID int, atrID int) error {
 
 	return nil
 }
+
+// CreateCalculated creates a new calculated Attribute for the entity
+// associated with entID in the Campaign associated with campID using the
+// provided SimpleAttribute data. The Value of the SimpleAttribute is the
+// formula Kanka calculates the attribute from and is validated against the
+// existing Attributes of the entity before it is sent.
+// Kanka has no separate type for calculated attributes: it evaluates the
+// references in the Value of a standard attribute, which is sent without a
+// type. Any Type of the SimpleAttribute is therefore cleared.
+// CreateCalculated returns the newly created Attribute.
+func (as *AttributeService) CreateCalculated(campID int, entID int, atr SimpleAttribute) (*Attribute, error) {
+	atr.Type = ""
+
+	old, err := as.Index(campID, entID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Attributes referenced by formula for Entity (ID: %d): %w", entID, err)
+	}
+
+	known := make([]string, len(old))
+	for i, a := range old {
+		known[i] = a.Name
+	}
+
+	if err = ValidateFormula(atr.Value, known); err != nil {
+		return nil, fmt.Errorf("invalid formula for Attribute '%s': %w", atr.Name, err)
+	}
+
+	return as.Create(campID, entID, atr)
+}
+
+// Replace replaces the full set of Attributes for the entity associated with
+// entID in the Campaign associated with campID with the provided
+// SimpleAttributes. Attributes are matched by Name, in order: matching
+// Attributes are updated if they differ, new Attributes are created, and
+// existing Attributes left without a match, including extra Attributes
+// sharing a Name, are deleted. Every page of the existing Attributes is read.
+// Replace returns the resulting set of Attributes.
+// Replace is not atomic; if any request fails, the entity may be left with a
+// partially replaced set of Attributes.
+func (as *AttributeService) Replace(campID int, entID int, atrs []SimpleAttribute) ([]*Attribute, error) {
+	old, err := as.IndexAll(campID, entID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot replace Attributes for Entity (ID: %d): %w", entID, err)
+	}
+
+	byName := make(map[string][]*Attribute, len(old))
+	for _, a := range old {
+		byName[a.Name] = append(byName[a.Name], a)
+	}
+	matched := make(map[int]bool, len(old))
+
+	for _, atr := range atrs {
+		same := byName[atr.Name]
+		if len(same) == 0 {
+			if _, err = as.Create(campID, entID, atr); err != nil {
+				return nil, fmt.Errorf("cannot replace Attributes for Entity (ID: %d): %w", entID, err)
+			}
+			continue
+		}
+		a := same[0]
+		byName[atr.Name] = same[1:]
+		matched[a.ID] = true
+
+		if a.SimpleAttribute.matches(atr) {
+			continue
+		}
+
+		if _, err = as.Update(campID, entID, a.ID, atr); err != nil {
+			return nil, fmt.Errorf("cannot replace Attributes for Entity (ID: %d): %w", entID, err)
+		}
+	}
+
+	for _, a := range old {
+		if matched[a.ID] {
+			continue
+		}
+
+		if err = as.Delete(campID, entID, a.ID); err != nil {
+			return nil, fmt.Errorf("cannot replace Attributes for Entity (ID: %d): %w", entID, err)
+		}
+	}
+
+	return as.IndexAll(campID, entID, nil)
+}
@@ -1,7 +1,11 @@
 package kanka
 
 import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
@@ -10,10 +14,11 @@ import (
 )
 
 const (
-	testAttributeIndex  string = "test_data/attribute_index.json"
-	testAttributeGet    string = "test_data/attribute_get.json"
-	testAttributeCreate string = "test_data/attribute_create.json"
-	testAttributeUpdate string = "test_data/attribute_update.json"
+	testAttributeIndex   string = "test_data/attribute_index.json"
+	testAttributeGet     string = "test_data/attribute_get.json"
+	testAttributeCreate  string = "test_data/attribute_create.json"
+	testAttributeUpdate  string = "test_data/attribute_update.json"
+	testAttributeReplace string = "test_data/attribute_replace.json"
 )
 
 func TestAttributeService_Index(t *testing.T) {
@@ -148,6 +153,7 @@ func TestAttributeService_Index(t *testing.T) {
 }
 
 func TestAttributeService_Get(t *testing.T) {
+	pinned := true
 	atr := &Attribute{
 		SimpleAttribute: SimpleAttribute{
 			Name:         "Item",
@@ -156,6 +162,7 @@ func TestAttributeService_Get(t *testing.T) {
 			Type:         "",
 			APIKey:       "",
 			DefaultOrder: 0,
+			IsPinned:     &pinned,
 		},
 		ID:        318053,
 		EntityID:  430214,
@@ -400,8 +407,9 @@ func TestAttributeService_Create(t *testing.T) {
 
 func TestAttributeService_Update(t *testing.T) {
 	atr := SimpleAttribute{
-		Name:  "Conquests",
-		Value: "2",
+		Name:       "Conquests",
+		Value:      "2",
+		Visibility: VisibilityAdmin,
 	}
 	type args struct {
 		campID int
@@ -605,3 +613,330 @@ func TestAttributeService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestAttributeService_Replace(t *testing.T) {
+	atrs := []SimpleAttribute{
+		{Name: "Troops", Value: "500"},
+		{Name: "Population", Value: "3000"},
+		{Name: "Race", Value: "elf"},
+	}
+	pin, unpin := true, false
+
+	type args struct {
+		campID int
+		entID  int
+		atrs   []SimpleAttribute
+	}
+	tests := []struct {
+		name       string
+		status     int
+		args       args
+		wantCounts map[string]int
+		wantErr    bool
+	}{
+		{
+			name:       "StatusOK, valid args",
+			status:     http.StatusOK,
+			args:       args{campID: 5272, entID: 430214, atrs: atrs},
+			wantCounts: map[string]int{"GET": 2, "POST": 1, "PUT": 1, "DELETE": 1},
+			wantErr:    false,
+		},
+		{
+			name:   "StatusOK, pinned attribute",
+			status: http.StatusOK,
+			args: args{campID: 5272, entID: 430214, atrs: []SimpleAttribute{
+				{Name: "Troops", Value: "500", IsPinned: &pin},
+				{Name: "Population", Value: "2000"},
+				{Name: "Title", Value: "King", IsPinned: &unpin},
+			}},
+			wantCounts: map[string]int{"GET": 2, "PUT": 2},
+			wantErr:    false,
+		},
+		{
+			name:       "StatusOK, no attributes",
+			status:     http.StatusOK,
+			args:       args{campID: 5272, entID: 430214, atrs: nil},
+			wantCounts: map[string]int{"GET": 2, "DELETE": 3},
+			wantErr:    false,
+		},
+		{
+			name:       "StatusOK, invalid attribute",
+			status:     http.StatusOK,
+			args:       args{campID: 5272, entID: 430214, atrs: []SimpleAttribute{{Value: "500"}}},
+			wantCounts: map[string]int{"GET": 1},
+			wantErr:    true,
+		},
+		{
+			name:       "StatusOK, invalid args",
+			status:     http.StatusOK,
+			args:       args{campID: -123, entID: 430214, atrs: atrs},
+			wantCounts: map[string]int{},
+			wantErr:    true,
+		},
+		{
+			name:       "StatusNotFound, valid args",
+			status:     http.StatusNotFound,
+			args:       args{campID: 5272, entID: 430214, atrs: atrs},
+			wantCounts: map[string]int{"GET": 1},
+			wantErr:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			files := map[string]string{
+				"GET":  testAttributeReplace,
+				"POST": testAttributeCreate,
+				"PUT":  testAttributeUpdate,
+			}
+			c, ts, counts := testMethodClient(t, test.status, files)
+			defer ts.Close()
+
+			_, err := c.Attributes.Replace(test.args.campID, test.args.entID, test.args.atrs)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(counts, test.wantCounts); diff != "" {
+				t.Errorf("request count mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAttributeService_Replace_Pages(t *testing.T) {
+	var calls []string
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.RequestURI())
+
+		switch {
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Query().Get("page") == "2":
+			w.Write([]byte(`{"data":[{"id":444,"name":"Troops","value":"100"},{"id":555,"name":"Title","value":"King"}],"links":{"next":null}}`))
+		default:
+			fmt.Fprintf(w, `{"data":[{"id":111,"name":"Troops","value":"500"},{"id":222,"name":"Population","value":"2000"}],"links":{"next":"%s/campaigns/5272/entities/430214/attributes?page=2"}}`, ts.URL)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	atrs := []SimpleAttribute{
+		{Name: "Troops", Value: "500"},
+		{Name: "Title", Value: "King"},
+	}
+	if _, err := c.Attributes.Replace(5272, 430214, atrs); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"GET /campaigns/5272/entities/430214/attributes?related=1",
+		"GET /campaigns/5272/entities/430214/attributes?page=2&related=1",
+		"DELETE /campaigns/5272/entities/430214/attributes/222",
+		"DELETE /campaigns/5272/entities/430214/attributes/444",
+		"GET /campaigns/5272/entities/430214/attributes?related=1",
+		"GET /campaigns/5272/entities/430214/attributes?page=2&related=1",
+	}
+	if diff := cmp.Diff(calls, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestAttributeService_CreateCalculated(t *testing.T) {
+	type args struct {
+		campID int
+		entID  int
+		atr    SimpleAttribute
+	}
+	tests := []struct {
+		name       string
+		status     int
+		args       args
+		wantCounts map[string]int
+		wantBody   string
+		wantErr    bool
+	}{
+		{
+			name:       "StatusOK, valid formula",
+			status:     http.StatusOK,
+			args:       args{campID: 5272, entID: 430214, atr: SimpleAttribute{Name: "Army", Value: "{Troops} * 2"}},
+			wantCounts: map[string]int{"GET": 1, "POST": 1},
+			wantBody:   `{"name":"Army","value":"{Troops} * 2"}`,
+			wantErr:    false,
+		},
+		{
+			name:       "StatusOK, valid formula with type",
+			status:     http.StatusOK,
+			args:       args{campID: 5272, entID: 430214, atr: SimpleAttribute{Name: "Army", Value: "{Troops} * 2", Type: "block"}},
+			wantCounts: map[string]int{"GET": 1, "POST": 1},
+			wantBody:   `{"name":"Army","value":"{Troops} * 2"}`,
+			wantErr:    false,
+		},
+		{
+			name:       "StatusOK, unknown reference",
+			status:     http.StatusOK,
+			args:       args{campID: 5272, entID: 430214, atr: SimpleAttribute{Name: "Army", Value: "{Soldiers} * 2"}},
+			wantCounts: map[string]int{"GET": 1},
+			wantErr:    true,
+		},
+		{
+			name:       "StatusOK, unbalanced braces",
+			status:     http.StatusOK,
+			args:       args{campID: 5272, entID: 430214, atr: SimpleAttribute{Name: "Army", Value: "{Troops * 2"}},
+			wantCounts: map[string]int{"GET": 1},
+			wantErr:    true,
+		},
+		{
+			name:       "StatusOK, invalid args",
+			status:     http.StatusOK,
+			args:       args{campID: -123, entID: 430214, atr: SimpleAttribute{Name: "Army", Value: "{Troops} * 2"}},
+			wantCounts: map[string]int{},
+			wantErr:    true,
+		},
+		{
+			name:       "StatusNotFound, valid args",
+			status:     http.StatusNotFound,
+			args:       args{campID: 5272, entID: 430214, atr: SimpleAttribute{Name: "Army", Value: "{Troops} * 2"}},
+			wantCounts: map[string]int{"GET": 1},
+			wantErr:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			counts := make(map[string]int)
+			var body string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				counts[r.Method]++
+				w.WriteHeader(test.status)
+
+				file := testAttributeIndex
+				if r.Method == http.MethodPost {
+					b, err := ioutil.ReadAll(r.Body)
+					if err != nil {
+						t.Error(err)
+					}
+					body = string(b)
+					file = testAttributeCreate
+				}
+
+				b, err := ioutil.ReadFile(file)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				w.Write(b)
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			_, err := c.Attributes.CreateCalculated(test.args.campID, test.args.entID, test.args.atr)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(counts, test.wantCounts); diff != "" {
+				t.Errorf("request count mismatch (-want +got):\n%s", diff)
+			}
+			if body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", body, test.wantBody)
+			}
+		})
+	}
+}
+
+func TestSimpleAttribute_MarshalJSON(t *testing.T) {
+	pinned := true
+
+	tests := []struct {
+		name    string
+		atr     SimpleAttribute
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Name only",
+			atr:     SimpleAttribute{Name: "Strength"},
+			want:    `{"name":"Strength"}`,
+			wantErr: false,
+		},
+		{
+			name:    "Pinned with visibility",
+			atr:     SimpleAttribute{Name: "Strength", Value: "18", IsPinned: &pinned, Visibility: VisibilityMembers},
+			want:    `{"name":"Strength","value":"18","is_pinned":true,"visibility":"members"}`,
+			wantErr: false,
+		},
+		{
+			name:    "Missing name",
+			atr:     SimpleAttribute{Value: "18", Visibility: VisibilityAdmin},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			b, err := json.Marshal(test.atr)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			if string(b) != test.want {
+				t.Errorf("got: <%s>, want: <%s>", b, test.want)
+			}
+		})
+	}
+}
+
+func TestAttributes_Sections(t *testing.T) {
+	level := &Attribute{SimpleAttribute: SimpleAttribute{Name: "Level", DefaultOrder: 0}}
+	stats := &Attribute{SimpleAttribute: SimpleAttribute{Name: "Stats", Type: AttributeTypeSection, DefaultOrder: 1}}
+	str := &Attribute{SimpleAttribute: SimpleAttribute{Name: "Strength", DefaultOrder: 2}}
+	dex := &Attribute{SimpleAttribute: SimpleAttribute{Name: "Dexterity", DefaultOrder: 2}}
+	gear := &Attribute{SimpleAttribute: SimpleAttribute{Name: "Gear", Type: AttributeTypeSection, DefaultOrder: 3}}
+	rope := &Attribute{SimpleAttribute: SimpleAttribute{Name: "Rope", Type: "checkbox", DefaultOrder: 4}}
+
+	tests := []struct {
+		name string
+		atrs Attributes
+		want []AttributeSection
+	}{
+		{
+			name: "Unordered attributes with sections",
+			atrs: Attributes{Data: []*Attribute{rope, str, gear, level, dex, stats}},
+			want: []AttributeSection{
+				{Name: "", Attributes: []*Attribute{level}},
+				{Name: "Stats", Attributes: []*Attribute{str, dex}},
+				{Name: "Gear", Attributes: []*Attribute{rope}},
+			},
+		},
+		{
+			name: "Leading section, empty section",
+			atrs: Attributes{Data: []*Attribute{stats, gear, rope}},
+			want: []AttributeSection{
+				{Name: "Stats", Attributes: nil},
+				{Name: "Gear", Attributes: []*Attribute{rope}},
+			},
+		},
+		{
+			name: "No sections",
+			atrs: Attributes{Data: []*Attribute{str, level}},
+			want: []AttributeSection{
+				{Name: "", Attributes: []*Attribute{level, str}},
+			},
+		},
+		{
+			name: "Empty attributes",
+			atrs: Attributes{},
+			want: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.atrs.Sections()
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
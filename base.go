@@ -0,0 +1,531 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Henry-Sarabia/blank"
+)
+
+// baseService implements the Index, Get, Create, Update, and Delete operations
+// shared by the services of the objects that belong directly to a campaign,
+// such as characters and locations. T is the type of the object returned by
+// Kanka and S is the simple type used to create and update it.
+// The concrete services wrap a baseService to keep their own method signatures.
+type baseService[T any, S any] struct {
+	*service
+	// kind is the name of T used in error messages, such as "Character".
+	kind string
+	// entity is the entity type of T as reported by Kanka, such as "character".
+	entity string
+	// label returns the name of the provided simple data used in error messages.
+	label func(S) string
+}
+
+// endpoint returns the endpoint of the baseService in the Campaign associated
+// with campID.
+func (bs baseService[T, S]) endpoint(campID int) (endpoint, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return "", fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+
+	return end.concat(bs.end), nil
+}
+
+// objectEndpoint returns the endpoint of the object associated with id in the
+// Campaign associated with campID.
+func (bs baseService[T, S]) objectEndpoint(campID int, id int) (endpoint, error) {
+	end, err := bs.endpoint(campID)
+	if err != nil {
+		return "", err
+	}
+
+	if end, err = end.id(id); err != nil {
+		return "", fmt.Errorf("invalid %s ID: %w", bs.kind, err)
+	}
+
+	return end, nil
+}
+
+// fail returns an APIError for the provided operation on the object of the
+// baseService associated with id, or 0 if the operation is not about a single
+// object, in the Campaign associated with campID. The message of the error is
+// formatted according to the provided format specifier and wraps err.
+func (bs baseService[T, S]) fail(op string, campID int, id int, err error, format string, a ...interface{}) error {
+	return &APIError{
+		Op:         op,
+		Kind:       bs.kind,
+		CampaignID: campID,
+		ID:         id,
+		Err:        err,
+		msg:        fmt.Sprintf(format, a...),
+	}
+}
+
+// Index returns the list of all objects in the Campaign associated with campID.
+// If a non-nil time is provided, Index will only return objects that have been
+// changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the objects that were decoded.
+func (bs baseService[T, S]) Index(campID int, sync *time.Time) ([]*T, error) {
+	end, err := bs.endpoint(campID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sync != nil {
+		end = end.sync(*sync)
+	}
+
+	var wrap response[[]json.RawMessage]
+
+	if err = bs.client.get(end, &wrap); err != nil {
+		return nil, bs.fail("index", campID, 0, err, "cannot get %s Index from Campaign (ID: %d)", bs.kind, campID)
+	}
+
+	var list []*T
+	if err = decodeList(wrap.Data, &list, bs.client.strict); err != nil {
+		return list, bs.fail("index", campID, 0, err, "cannot decode %s Index from Campaign (ID: %d)", bs.kind, campID)
+	}
+
+	return list, nil
+}
+
+// IndexAll returns the list of all objects in the Campaign associated with
+// campID from every page of the list. IndexAll follows the link to the next
+// page returned by Kanka until the last page is reached.
+// If a non-nil time is provided, IndexAll will only return objects that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the objects that were decoded.
+func (bs baseService[T, S]) IndexAll(campID int, sync *time.Time) ([]*T, error) {
+	end, err := bs.endpoint(campID)
+	if err != nil {
+		return nil, err
+	}
+
+	if sync != nil {
+		end = end.sync(*sync)
+	}
+
+	raws, err := bs.client.indexAll(end)
+	if err != nil {
+		return nil, bs.fail("index", campID, 0, err, "cannot get every page of %s Index from Campaign (ID: %d)", bs.kind, campID)
+	}
+
+	var list []*T
+	if err = decodeList(raws, &list, bs.client.strict); err != nil {
+		return list, bs.fail("index", campID, 0, err, "cannot decode %s Index from Campaign (ID: %d)", bs.kind, campID)
+	}
+
+	return list, nil
+}
+
+// children returns the list of all objects in the Campaign associated with
+// campID whose parent, as stored in the provided field and returned by the
+// provided parent function, is the object associated with parentID. The parent
+// field is sent to Kanka as a filter and the results are filtered again in
+// case the filter is ignored. Every page of the list is followed, so children
+// are found whether or not Kanka honors the filter.
+// If a non-nil time is provided, children will only return objects that have
+// been changed since that time.
+func (bs baseService[T, S]) children(campID int, parentID int, field string, parent func(*T) int, sync *time.Time) ([]*T, error) {
+	end, err := bs.endpoint(campID)
+	if err != nil {
+		return nil, err
+	}
+
+	if parentID <= 0 {
+		return nil, fmt.Errorf("invalid parent %s ID: provided ID (%d) must be positive", bs.kind, parentID)
+	}
+	end = end.query(field, strconv.Itoa(parentID))
+
+	if sync != nil {
+		end = end.sync(*sync)
+	}
+
+	raws, err := bs.client.indexAll(end)
+	if err != nil {
+		return nil, bs.fail("children", campID, parentID, err, "cannot get every page of children of %s (ID: %d) from Campaign (ID: %d)", bs.kind, parentID, campID)
+	}
+
+	var list []*T
+	err = decodeList(raws, &list, bs.client.strict)
+
+	var kids []*T
+	for _, obj := range list {
+		if parent(obj) == parentID {
+			kids = append(kids, obj)
+		}
+	}
+
+	if err != nil {
+		return kids, bs.fail("children", campID, parentID, err, "cannot decode children of %s (ID: %d) from Campaign (ID: %d)", bs.kind, parentID, campID)
+	}
+
+	return kids, nil
+}
+
+// Get returns the object associated with id from the Campaign associated
+// with campID.
+func (bs baseService[T, S]) Get(campID int, id int) (*T, error) {
+	end, err := bs.objectEndpoint(campID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var wrap response[*T]
+
+	if err = bs.client.get(end, &wrap); err != nil {
+		return nil, bs.fail("get", campID, id, err, "cannot get %s (ID: %d) from Campaign (ID: %d)", bs.kind, id, campID)
+	}
+
+	return wrap.Data, nil
+}
+
+// paths returns the chain of objects from the root of their tree to each of
+// the objects associated with the provided ids in the Campaign associated with
+// campID, keyed by ID. Each chain ends with the object itself. The provided
+// parent function returns the ID of the parent of an object, or 0 for a root.
+// Each object is fetched at most once, even if it is an ancestor shared by
+// several objects. Returns an error if a chain contains a cycle.
+func (bs baseService[T, S]) paths(campID int, ids []int, parent func(*T) int) (map[int][]*T, error) {
+	cache := make(map[int]*T)
+	paths := make(map[int][]*T, len(ids))
+
+	for _, objID := range ids {
+		if _, ok := paths[objID]; ok {
+			continue
+		}
+
+		var chain []*T
+		seen := make(map[int]bool)
+
+		for id := objID; id != 0; {
+			if seen[id] {
+				return nil, fmt.Errorf("cannot resolve path of %s (ID: %d): cycle at %s (ID: %d)", bs.kind, objID, bs.kind, id)
+			}
+			seen[id] = true
+
+			obj, ok := cache[id]
+			if !ok {
+				var err error
+				if obj, err = bs.Get(campID, id); err != nil {
+					return nil, fmt.Errorf("cannot resolve path of %s (ID: %d): %w", bs.kind, objID, err)
+				}
+				cache[id] = obj
+			}
+
+			chain = append(chain, obj)
+			id = parent(obj)
+		}
+
+		for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+			chain[i], chain[j] = chain[j], chain[i]
+		}
+		paths[objID] = chain
+	}
+
+	return paths, nil
+}
+
+// GetByName returns the object with the provided name from the Campaign
+// associated with campID using every page of the search endpoint. Names are
+// matched exactly or, failing that, regardless of case. GetByName returns an
+// error matching ErrNotFound if no object has the name and ErrAmbiguous if
+// more than one does.
+func (bs baseService[T, S]) GetByName(campID int, name string) (*T, error) {
+	res, err := bs.client.SearchAll(campID, name, nil)
+	if err != nil {
+		return nil, bs.fail("search", campID, 0, err, "cannot search for %s '%s' in Campaign (ID: %d)", bs.kind, name, campID)
+	}
+
+	var exact, folded []*Result
+	for _, r := range res {
+		if r.Type != bs.entity {
+			continue
+		}
+
+		switch {
+		case r.Name == name:
+			exact = append(exact, r)
+		case strings.EqualFold(r.Name, name):
+			folded = append(folded, r)
+		}
+	}
+
+	matches := exact
+	if len(matches) == 0 {
+		matches = folded
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, bs.fail("get", campID, 0, ErrNotFound, "cannot get %s '%s' from Campaign (ID: %d)", bs.kind, name, campID)
+	case 1:
+		return bs.Get(campID, matches[0].ID)
+	default:
+		return nil, bs.fail("get", campID, 0, ErrAmbiguous, "cannot get %s '%s' from Campaign (ID: %d): %d matches", bs.kind, name, campID, len(matches))
+	}
+}
+
+// GetMany returns the objects associated with each of the provided IDs from
+// the Campaign associated with campID, keyed by ID. GetMany requests the first
+// page of the list of every object, which includes the related data of each
+// object, such as its attributes and relations. If the IDs not on the first
+// page are fewer than the pages left, each of them is requested with Get.
+// Otherwise, GetMany pages through the rest of the list and stops as soon as
+// every ID has been found. Either way, GetMany takes at most one request more
+// than calling Get for each ID, and far fewer when many IDs share a page.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the objects that were decoded. Otherwise,
+// IDs that are not in the Campaign are reported in an error matching
+// ErrNotFound alongside the objects that were found.
+func (bs baseService[T, S]) GetMany(campID int, ids []int) (map[int]*T, error) {
+	end, err := bs.endpoint(campID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if id < 0 {
+			return nil, fmt.Errorf("invalid %s ID: provided ID (%d) cannot be negative", bs.kind, id)
+		}
+		pending[id] = true
+	}
+
+	objs, errs, err := getMany(bs.client, end, pending, func(id int) (*T, error) { return bs.Get(campID, id) })
+	if err != nil {
+		return nil, bs.fail("index", campID, 0, err, "cannot get %s (IDs: %v) from Campaign (ID: %d)", bs.kind, ids, campID)
+	}
+
+	if len(errs) > 0 {
+		return objs, bs.fail("index", campID, 0, errs, "cannot decode %s Index from Campaign (ID: %d)", bs.kind, campID)
+	}
+
+	if len(pending) > 0 {
+		missing := make([]int, 0, len(pending))
+		for id := range pending {
+			missing = append(missing, id)
+		}
+		sort.Ints(missing)
+
+		return objs, bs.fail("get", campID, 0, ErrNotFound, "cannot get %s (IDs: %v) from Campaign (ID: %d)", bs.kind, missing, campID)
+	}
+
+	return objs, nil
+}
+
+// Create creates a new object in the Campaign associated with campID using
+// the provided simple data.
+// Create returns the newly created object.
+func (bs baseService[T, S]) Create(campID int, data S, opts ...CreateOption) (*T, error) {
+	obj, _, err := bs.CreateWithResult(campID, data, opts...)
+	return obj, err
+}
+
+// CreateWithResult creates a new object in the Campaign associated with campID
+// using the provided simple data.
+// CreateWithResult returns the newly created object along with the metadata
+// of Kanka's response. If the Client was created WithDefaultPrivate, the
+// object is created private unless the Public CreateOption is provided. With
+// the CheckDuplicates CreateOption, likely duplicates are reported in the
+// Warnings of the result.
+func (bs baseService[T, S]) CreateWithResult(campID int, data S, opts ...CreateOption) (*T, *WriteResult, error) {
+	end, err := bs.endpoint(campID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var set createSettings
+	for _, opt := range opts {
+		opt(&set)
+	}
+
+	b, err := createBody(data, bs.client.defaultPrivate && !set.public)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot marshal Simple%s (Name: %s): %w", bs.kind, bs.label(data), err)
+	}
+
+	var warns []Warning
+	if set.duplicates {
+		w, err := bs.duplicates(campID, bs.label(data))
+		if err != nil {
+			return nil, nil, bs.fail("create", campID, 0, err, "cannot check %s (Name: %s) for duplicates in Campaign (ID: %d)", bs.kind, bs.label(data), campID)
+		}
+		warns = w
+	}
+
+	var wrap response[*T]
+
+	if err = bs.client.post(end, bytes.NewReader(b), &wrap); err != nil {
+		return nil, nil, bs.fail("create", campID, 0, err, "cannot create %s (Name: %s) for Campaign (ID: %d)", bs.kind, bs.label(data), campID)
+	}
+	wrap.result.Warnings = warns
+
+	return wrap.Data, &wrap.result, nil
+}
+
+// duplicates searches every page of the search results of the Campaign
+// associated with campID for objects of the baseService with the provided
+// name, ignoring case, and returns a Warning listing their IDs if any exist.
+func (bs baseService[T, S]) duplicates(campID int, name string) ([]Warning, error) {
+	res, err := bs.client.SearchAll(campID, name, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []int
+	for _, r := range res {
+		if r.Type == bs.entity && strings.EqualFold(r.Name, name) {
+			ids = append(ids, r.ID)
+		}
+	}
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	return []Warning{{
+		Code:    WarningDuplicateName,
+		Message: fmt.Sprintf("%s (Name: %s) already exists in Campaign (ID: %d)", bs.kind, name, campID),
+		IDs:     ids,
+	}}, nil
+}
+
+// Update updates an existing object associated with id from the Campaign
+// associated with campID using the provided simple data. Fields managed by
+// Kanka, such as the path of the current image, are never sent.
+// Update returns the newly updated object.
+func (bs baseService[T, S]) Update(campID int, id int, data S) (*T, error) {
+	obj, _, err := bs.UpdateWithResult(campID, id, data)
+	return obj, err
+}
+
+// UpdateWithResult updates an existing object associated with id from the
+// Campaign associated with campID using the provided simple data, as Update
+// does. UpdateWithResult returns the newly updated object along with the
+// metadata of Kanka's response.
+func (bs baseService[T, S]) UpdateWithResult(campID int, id int, data S) (*T, *WriteResult, error) {
+	end, err := bs.objectEndpoint(campID, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	b, err := updateBody(data)
+	if err != nil {
+		return nil, nil, fmt.Errorf("cannot marshal Simple%s (Name: %s): %w", bs.kind, bs.label(data), err)
+	}
+
+	var wrap response[*T]
+
+	if err = bs.client.put(end, bytes.NewReader(b), &wrap); err != nil {
+		return nil, nil, bs.fail("update", campID, id, err, "cannot update %s (Name: %s) for Campaign (ID: %d)", bs.kind, bs.label(data), campID)
+	}
+
+	return wrap.Data, &wrap.result, nil
+}
+
+// Clear clears each of the provided fields, such as "entry", of an existing
+// object associated with id from the Campaign associated with campID. Only the
+// provided fields are updated.
+// Clear returns the newly updated object.
+func (bs baseService[T, S]) Clear(campID int, id int, fields ...string) (*T, error) {
+	end, err := bs.objectEndpoint(campID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	body, err := clearFields(fields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid fields to clear: %w", err)
+	}
+
+	b, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal cleared fields of %s (ID: %d): %w", bs.kind, id, err)
+	}
+
+	var wrap response[*T]
+
+	if err = bs.client.patch(end, bytes.NewReader(b), &wrap); err != nil {
+		return nil, bs.fail("clear", campID, id, err, "cannot clear fields of %s (ID: %d) for Campaign (ID: %d)", bs.kind, id, campID)
+	}
+
+	return wrap.Data, nil
+}
+
+// SetHeader sets the header image of an existing object associated with id
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. Only the header image is updated.
+// SetHeader returns the newly updated object.
+func (bs baseService[T, S]) SetHeader(campID int, id int, uuid string) (*T, error) {
+	if blank.Is(uuid) {
+		return nil, fmt.Errorf("cannot set header image of %s (ID: %d) with a missing GalleryImage ID", bs.kind, id)
+	}
+
+	return bs.header(campID, id, fieldHeaderUUID, uuid)
+}
+
+// SetHeaderURL sets the header image of an existing object associated with id
+// from the Campaign associated with campID to the image found at the provided
+// URL. Only the header image is updated.
+// SetHeaderURL returns the newly updated object.
+func (bs baseService[T, S]) SetHeaderURL(campID int, id int, url string) (*T, error) {
+	if blank.Is(url) {
+		return nil, fmt.Errorf("cannot set header image of %s (ID: %d) with a missing URL", bs.kind, id)
+	}
+
+	return bs.header(campID, id, fieldHeaderURL, url)
+}
+
+// Fields used to set the header image of an object.
+const (
+	fieldHeaderUUID string = "entity_header_uuid"
+	fieldHeaderURL  string = "header_image_url"
+)
+
+// header updates only the provided header image field of an existing object
+// associated with id from the Campaign associated with campID to the provided
+// value.
+func (bs baseService[T, S]) header(campID int, id int, field string, val string) (*T, error) {
+	end, err := bs.objectEndpoint(campID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(map[string]string{field: val})
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal header image of %s (ID: %d): %w", bs.kind, id, err)
+	}
+
+	var wrap response[*T]
+
+	if err = bs.client.patch(end, bytes.NewReader(b), &wrap); err != nil {
+		return nil, bs.fail("header", campID, id, err, "cannot set header image of %s (ID: %d) for Campaign (ID: %d)", bs.kind, id, campID)
+	}
+
+	return wrap.Data, nil
+}
+
+// Delete deletes an existing object associated with id from the Campaign
+// associated with campID.
+func (bs baseService[T, S]) Delete(campID int, id int) error {
+	end, err := bs.objectEndpoint(campID, id)
+	if err != nil {
+		return err
+	}
+
+	if err = bs.client.delete(end); err != nil {
+		return bs.fail("delete", campID, id, err, "cannot delete %s (ID: %d) for Campaign (ID: %d)", bs.kind, id, campID)
+	}
+
+	return nil
+}
@@ -0,0 +1,547 @@
+package kanka
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBaseService_Requests(t *testing.T) {
+	tests := []struct {
+		name       string
+		file       string
+		call       func(bs baseService[Character, SimpleCharacter]) error
+		wantMethod string
+		wantURL    string
+	}{
+		{
+			name: "Index",
+			file: testCharacterIndex,
+			call: func(bs baseService[Character, SimpleCharacter]) error {
+				_, err := bs.Index(5272, nil)
+				return err
+			},
+			wantMethod: http.MethodGet,
+			wantURL:    "/campaigns/5272/characters?related=1",
+		},
+		{
+			name: "Get",
+			file: testCharacterGet,
+			call: func(bs baseService[Character, SimpleCharacter]) error {
+				_, err := bs.Get(5272, 111)
+				return err
+			},
+			wantMethod: http.MethodGet,
+			wantURL:    "/campaigns/5272/characters/111?related=1",
+		},
+		{
+			name: "Create",
+			file: testCharacterCreate,
+			call: func(bs baseService[Character, SimpleCharacter]) error {
+				_, err := bs.Create(5272, SimpleCharacter{Name: "Jon Snow"})
+				return err
+			},
+			wantMethod: http.MethodPost,
+			wantURL:    "/campaigns/5272/characters",
+		},
+		{
+			name: "Update",
+			file: testCharacterUpdate,
+			call: func(bs baseService[Character, SimpleCharacter]) error {
+				_, err := bs.Update(5272, 111, SimpleCharacter{Name: "Jon Snow"})
+				return err
+			},
+			wantMethod: http.MethodPut,
+			wantURL:    "/campaigns/5272/characters/111",
+		},
+		{
+			name: "Delete",
+			file: "",
+			call: func(bs baseService[Character, SimpleCharacter]) error {
+				return bs.Delete(5272, 111)
+			},
+			wantMethod: http.MethodDelete,
+			wantURL:    "/campaigns/5272/characters/111",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, http.StatusOK, test.file)
+			defer ts.Close()
+
+			if err := test.call(c.Characters.base()); err != nil {
+				t.Fatal(err)
+			}
+			if rec.method != test.wantMethod {
+				t.Errorf("got method: <%s>, want method: <%s>", rec.method, test.wantMethod)
+			}
+			if rec.url != test.wantURL {
+				t.Errorf("got url: <%s>, want url: <%s>", rec.url, test.wantURL)
+			}
+		})
+	}
+}
+
+func TestBaseService_InvalidID(t *testing.T) {
+	c := NewClient(testToken, nil)
+	bs := c.Characters.base()
+
+	want := "invalid Character ID: "
+	_, err := bs.Get(5272, -1)
+	if err == nil || !strings.HasPrefix(err.Error(), want) {
+		t.Errorf("got err: <%v>, want prefix: <%s>", err, want)
+	}
+}
+
+func TestBaseService_GetMany(t *testing.T) {
+	// Each page holds two characters: page 1 has IDs 1 and 2, page 2 has IDs
+	// 3 and 4, and page 3 has IDs 5 and 6.
+	tests := []struct {
+		name      string
+		ids       []int
+		want      []int
+		wantPages int
+		wantErr   error
+	}{
+		{
+			name:      "IDs on first page",
+			ids:       []int{2, 1},
+			want:      []int{1, 2},
+			wantPages: 1,
+			wantErr:   nil,
+		},
+		{
+			name:      "IDs across pages",
+			ids:       []int{1, 4, 4},
+			want:      []int{1, 4},
+			wantPages: 2,
+			wantErr:   nil,
+		},
+		{
+			name:      "Missing ID",
+			ids:       []int{6, 9},
+			want:      []int{6},
+			wantPages: 3,
+			wantErr:   ErrNotFound,
+		},
+		{
+			name:      "No IDs",
+			ids:       nil,
+			want:      nil,
+			wantPages: 0,
+			wantErr:   nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var pages int
+			var ts *httptest.Server
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pages++
+
+				page := pages
+				next := "null"
+				if page < 3 {
+					next = fmt.Sprintf(`"%s/campaigns/5272/characters?page=%d"`, ts.URL, page+1)
+				}
+				fmt.Fprintf(w, `{"data":[{"id":%d,"name":"Page %d"},{"id":%d,"name":"Page %d"}],"links":{"next":%s}}`, 2*page-1, page, 2*page, page, next)
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			chars, err := c.Characters.GetMany(5272, test.ids)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("got err: <%v>, want err: <%v>", err, test.wantErr)
+			}
+
+			var got []int
+			for id, ch := range chars {
+				if ch.ID != id {
+					t.Errorf("got Character (ID: %d) at key %d", ch.ID, id)
+				}
+				got = append(got, id)
+			}
+			sort.Ints(got)
+
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if pages != test.wantPages {
+				t.Errorf("got pages: <%d>, want pages: <%d>", pages, test.wantPages)
+			}
+		})
+	}
+}
+
+func TestBaseService_SetHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		call     func(bs baseService[Character, SimpleCharacter]) error
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name: "Gallery image",
+			call: func(bs baseService[Character, SimpleCharacter]) error {
+				_, err := bs.SetHeader(5272, 111, "9a1b2c3d")
+				return err
+			},
+			wantBody: `{"entity_header_uuid":"9a1b2c3d"}`,
+			wantErr:  false,
+		},
+		{
+			name: "URL",
+			call: func(bs baseService[Character, SimpleCharacter]) error {
+				_, err := bs.SetHeaderURL(5272, 111, "https://example.com/banner.png")
+				return err
+			},
+			wantBody: `{"header_image_url":"https://example.com/banner.png"}`,
+			wantErr:  false,
+		},
+		{
+			name: "Missing gallery image",
+			call: func(bs baseService[Character, SimpleCharacter]) error {
+				_, err := bs.SetHeader(5272, 111, " ")
+				return err
+			},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name: "Missing URL",
+			call: func(bs baseService[Character, SimpleCharacter]) error {
+				_, err := bs.SetHeaderURL(5272, 111, "")
+				return err
+			},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name: "Invalid ID",
+			call: func(bs baseService[Character, SimpleCharacter]) error {
+				_, err := bs.SetHeader(5272, -1, "9a1b2c3d")
+				return err
+			},
+			wantBody: "",
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, http.StatusOK, testCharacterUpdate)
+			defer ts.Close()
+
+			err := test.call(c.Characters.base())
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+			if test.wantBody != "" && (rec.method != http.MethodPatch || rec.url != "/campaigns/5272/characters/111") {
+				t.Errorf("got request: <%s %s>, want request: <PATCH /campaigns/5272/characters/111>", rec.method, rec.url)
+			}
+		})
+	}
+}
+
+func TestBaseService_UpdateWritable(t *testing.T) {
+	c, ts, rec := testRecordClient(t, http.StatusOK, testCharacterUpdate)
+	defer ts.Close()
+
+	// The Image of a Character returned by Kanka is the path of its current
+	// image, which Kanka rejects in an update.
+	ch := SimpleCharacter{Name: "Jon Snow", Title: "King in the North", Image: "characters/jon.png"}
+	if _, err := c.Characters.base().Update(5272, 111, ch); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `{"name":"Jon Snow","title":"King in the North"}`
+	if rec.body != want {
+		t.Errorf("got body: <%s>, want body: <%s>", rec.body, want)
+	}
+}
+
+func TestBaseService_GetManyByID(t *testing.T) {
+	// The list reports 50 pages of two characters each, so the IDs that are
+	// not on the first page are requested on their own.
+	tests := []struct {
+		name      string
+		ids       []int
+		want      []int
+		wantCalls []string
+		wantErr   error
+	}{
+		{
+			name:      "Few IDs after first page",
+			ids:       []int{1, 7, 99},
+			want:      []int{1, 7},
+			wantCalls: []string{"/campaigns/5272/characters", "/campaigns/5272/characters/7", "/campaigns/5272/characters/99"},
+			wantErr:   ErrNotFound,
+		},
+		{
+			name:      "IDs on first page",
+			ids:       []int{2, 1},
+			want:      []int{1, 2},
+			wantCalls: []string{"/campaigns/5272/characters"},
+			wantErr:   nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.URL.Path)
+
+				switch r.URL.Path {
+				case "/campaigns/5272/characters":
+					w.Write([]byte(`{"data":[{"id":1,"name":"Page 1"},{"id":2,"name":"Page 1"}],"meta":{"current_page":1,"last_page":50}}`))
+				case "/campaigns/5272/characters/7":
+					w.Write([]byte(`{"data":{"id":7,"name":"Page 4"}}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			chars, err := c.Characters.GetMany(5272, test.ids)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("got err: <%v>, want err: <%v>", err, test.wantErr)
+			}
+
+			var got []int
+			for id := range chars {
+				got = append(got, id)
+			}
+			sort.Ints(got)
+
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if diff := cmp.Diff(calls, test.wantCalls); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestBaseService_CreateDuplicates(t *testing.T) {
+	tests := []struct {
+		name         string
+		opts         []CreateOption
+		search       string
+		wantCalls    []string
+		wantWarnings []Warning
+		wantErr      bool
+	}{
+		{
+			name:         "Unchecked",
+			opts:         nil,
+			search:       `{"data":[{"id":7,"name":"Jon Snow","type":"character"}]}`,
+			wantCalls:    []string{"POST /campaigns/5272/characters"},
+			wantWarnings: nil,
+			wantErr:      false,
+		},
+		{
+			name:      "Duplicate",
+			opts:      []CreateOption{CheckDuplicates()},
+			search:    `{"data":[{"id":7,"name":"jon snow","type":"character"},{"id":8,"name":"Jon Snow","type":"location"},{"id":9,"name":"Jon Snow the Younger","type":"character"}]}`,
+			wantCalls: []string{"GET /campaigns/5272/search/Jon Snow", "POST /campaigns/5272/characters"},
+			wantWarnings: []Warning{{
+				Code:    WarningDuplicateName,
+				Message: "Character (Name: Jon Snow) already exists in Campaign (ID: 5272)",
+				IDs:     []int{7},
+			}},
+			wantErr: false,
+		},
+		{
+			name:         "No duplicate",
+			opts:         []CreateOption{CheckDuplicates()},
+			search:       `{"data":[]}`,
+			wantCalls:    []string{"GET /campaigns/5272/search/Jon Snow", "POST /campaigns/5272/characters"},
+			wantWarnings: nil,
+			wantErr:      false,
+		},
+		{
+			name:         "Search failure",
+			opts:         []CreateOption{CheckDuplicates()},
+			search:       "",
+			wantCalls:    []string{"GET /campaigns/5272/search/Jon Snow"},
+			wantWarnings: nil,
+			wantErr:      true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+
+				if r.Method == http.MethodPost {
+					w.Write([]byte(`{"data":{"id":111,"entity_id":430214,"name":"Jon Snow"}}`))
+					return
+				}
+				if test.search == "" {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.Write([]byte(test.search))
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			_, res, err := c.Characters.CreateWithResult(5272, SimpleCharacter{Name: "Jon Snow"}, test.opts...)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(calls, test.wantCalls); diff != "" {
+				t.Errorf(diff)
+			}
+			if test.wantErr {
+				return
+			}
+			if diff := cmp.Diff(res.Warnings, test.wantWarnings); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestBaseService_CreateDuplicatesPages(t *testing.T) {
+	var calls []string
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.RequestURI())
+
+		switch {
+		case r.Method == http.MethodPost:
+			w.Write([]byte(`{"data":{"id":111,"entity_id":430214,"name":"50% Snow"}}`))
+		case r.URL.Query().Get("page") == "2":
+			w.Write([]byte(`{"data":[{"id":8,"name":"50% snow","type":"character"}],"links":{"next":null}}`))
+		default:
+			fmt.Fprintf(w, `{"data":[{"id":7,"name":"50%% Snow","type":"location"}],"links":{"next":"%s/campaigns/5272/search/50%%25%%20Snow?page=2"}}`, ts.URL)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	_, res, err := c.Characters.CreateWithResult(5272, SimpleCharacter{Name: "50% Snow"}, CheckDuplicates())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantCalls := []string{
+		"GET /campaigns/5272/search/50%25%20Snow?related=1",
+		"GET /campaigns/5272/search/50%25%20Snow?page=2&related=1",
+		"POST /campaigns/5272/characters",
+	}
+	if diff := cmp.Diff(calls, wantCalls); diff != "" {
+		t.Errorf(diff)
+	}
+
+	wantWarnings := []Warning{{
+		Code:    WarningDuplicateName,
+		Message: "Character (Name: 50% Snow) already exists in Campaign (ID: 5272)",
+		IDs:     []int{8},
+	}}
+	if diff := cmp.Diff(res.Warnings, wantWarnings); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestBaseService_Paths(t *testing.T) {
+	// Location 1 is a root, 2 and 3 are children of 1, 4 is a child of 3, and
+	// 5 and 6 are parents of each other.
+	parents := map[int]int{1: 0, 2: 1, 3: 1, 4: 3, 5: 6, 6: 5}
+
+	tests := []struct {
+		name     string
+		ids      []int
+		want     map[int][]int
+		wantGets int
+		wantErr  bool
+	}{
+		{
+			name:     "Root",
+			ids:      []int{1},
+			want:     map[int][]int{1: {1}},
+			wantGets: 1,
+			wantErr:  false,
+		},
+		{
+			name:     "Shared ancestors",
+			ids:      []int{4, 2, 4},
+			want:     map[int][]int{4: {1, 3, 4}, 2: {1, 2}},
+			wantGets: 4,
+			wantErr:  false,
+		},
+		{
+			name:     "Cycle",
+			ids:      []int{5},
+			want:     map[int][]int{},
+			wantGets: 2,
+			wantErr:  true,
+		},
+		{
+			name:     "Missing Location",
+			ids:      []int{9},
+			want:     map[int][]int{},
+			wantGets: 1,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var gets int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				gets++
+
+				var id int
+				fmt.Sscanf(r.URL.Path, "/campaigns/5272/locations/%d", &id)
+				parent, ok := parents[id]
+				if !ok {
+					w.WriteHeader(http.StatusNotFound)
+					return
+				}
+				fmt.Fprintf(w, `{"data":{"id":%d,"name":"Location %d","parent_location_id":%d}}`, id, id, parent)
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			paths, err := c.Locations.Paths(5272, test.ids)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			got := make(map[int][]int)
+			for id, p := range paths {
+				for _, loc := range p {
+					got[id] = append(got[id], loc.ID)
+				}
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if gets != test.wantGets {
+				t.Errorf("got gets: <%d>, want gets: <%d>", gets, test.wantGets)
+			}
+		})
+	}
+}
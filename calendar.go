@@ -0,0 +1,313 @@
+package kanka
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Calendar contains information about a specific calendar.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/calendars
+type Calendar struct {
+	ID             int             `json:"id"`
+	Name           string          `json:"name"`
+	Entry          string          `json:"entry"`
+	Type           string          `json:"type"`
+	Date           string          `json:"date"`
+	Suffix         string          `json:"suffix"`
+	Months         []CalendarMonth `json:"months"`
+	Weekdays       []string        `json:"weekdays"`
+	HasLeapYear    bool            `json:"has_leap_year"`
+	LeapYearAmount int             `json:"leap_year_amount"`
+	LeapYearMonth  int             `json:"leap_year_month"`
+	LeapYearOffset int             `json:"leap_year_offset"`
+	LeapYearStart  int             `json:"leap_year_start"`
+	IsPrivate      bool            `json:"is_private"`
+	EntityID       int             `json:"entity_id"`
+	CreatedAt      time.Time       `json:"created_at"`
+	CreatedBy      int             `json:"created_by"`
+	UpdatedAt      time.Time       `json:"updated_at"`
+	UpdatedBy      int             `json:"updated_by"`
+}
+
+// CalendarMonth represents a single month of a calendar.
+type CalendarMonth struct {
+	Name   string `json:"name"`
+	Length int    `json:"length"`
+	Type   string `json:"type"`
+}
+
+// CalendarDate represents a date on a calendar. Months and days start at 1.
+type CalendarDate struct {
+	Year  int
+	Month int
+	Day   int
+}
+
+// before returns true if the CalendarDate is before the provided CalendarDate.
+func (d CalendarDate) before(o CalendarDate) bool {
+	if d.Year != o.Year {
+		return d.Year < o.Year
+	}
+	if d.Month != o.Month {
+		return d.Month < o.Month
+	}
+
+	return d.Day < o.Day
+}
+
+// Available recurring periodicities for entity events.
+const (
+	RecurringYear  string = "year"
+	RecurringMonth string = "month"
+)
+
+// isLeapYear returns true if the provided year is a leap year of the Calendar.
+func (c *Calendar) isLeapYear(year int) bool {
+	if !c.HasLeapYear || c.LeapYearOffset <= 0 || year < c.LeapYearStart {
+		return false
+	}
+
+	return (year-c.LeapYearStart)%c.LeapYearOffset == 0
+}
+
+// monthLength returns the number of days in the provided month of the
+// provided year, including any leap days.
+func (c *Calendar) monthLength(year int, month int) int {
+	if month < 1 || month > len(c.Months) {
+		return 0
+	}
+
+	n := c.Months[month-1].Length
+	if month == c.LeapYearMonth && c.isLeapYear(year) {
+		n += c.LeapYearAmount
+	}
+
+	return n
+}
+
+// Occurrences returns the dates the provided EntityEvent occurs on the Calendar
+// between the provided from and to dates, inclusive.
+// A recurring EntityEvent repeats every year or every month depending on its
+// RecurringPeriodicity, defaulting to every year, until the end of its
+// RecurringUntil year. Occurrences falling on a day that does not exist in a
+// given month, such as a leap day in a common year, are skipped.
+func (c *Calendar) Occurrences(evt *EntityEvent, from CalendarDate, to CalendarDate) []CalendarDate {
+	inRange := func(d CalendarDate) bool {
+		return !d.before(from) && !to.before(d) && d.Day <= c.monthLength(d.Year, d.Month)
+	}
+
+	start := CalendarDate{Year: evt.Year, Month: evt.Month, Day: evt.Day}
+	if !evt.IsRecurring {
+		if inRange(start) {
+			return []CalendarDate{start}
+		}
+		return nil
+	}
+
+	last := to.Year
+	if evt.RecurringUntil != 0 && evt.RecurringUntil < last {
+		last = evt.RecurringUntil
+	}
+
+	first := start.Year
+	if from.Year > first {
+		first = from.Year
+	}
+
+	var dates []CalendarDate
+	for y := first; y <= last; y++ {
+		if evt.RecurringPeriodicity != RecurringMonth {
+			if d := (CalendarDate{Year: y, Month: start.Month, Day: start.Day}); inRange(d) {
+				dates = append(dates, d)
+			}
+			continue
+		}
+
+		for m := 1; m <= len(c.Months); m++ {
+			d := CalendarDate{Year: y, Month: m, Day: start.Day}
+			if d.before(start) {
+				continue
+			}
+			if inRange(d) {
+				dates = append(dates, d)
+			}
+		}
+	}
+
+	return dates
+}
+
+// parseCalendarDate parses a date in the year-month-day layout Kanka uses for
+// the current date of a calendar, such as "1024-3-15". The year may be
+// negative, such as "-200-3-15".
+func parseCalendarDate(s string) (CalendarDate, error) {
+	neg := strings.HasPrefix(s, "-")
+	parts := strings.Split(strings.TrimPrefix(s, "-"), "-")
+	if len(parts) != 3 {
+		return CalendarDate{}, fmt.Errorf("calendar date '%s' is not in year-month-day layout", s)
+	}
+
+	var nums [3]int
+	for i, p := range parts {
+		n, err := strconv.Atoi(p)
+		if err != nil {
+			return CalendarDate{}, fmt.Errorf("calendar date '%s' is not in year-month-day layout: %w", s, err)
+		}
+		nums[i] = n
+	}
+
+	if neg {
+		nums[0] = -nums[0]
+	}
+
+	return CalendarDate{Year: nums[0], Month: nums[1], Day: nums[2]}, nil
+}
+
+// Current returns the current date of the Calendar.
+func (c *Calendar) Current() (CalendarDate, error) {
+	return parseCalendarDate(c.Date)
+}
+
+// Format returns the provided CalendarDate as written on the Calendar, such as
+// "15 Greening 1024 DR", using the names of its months and its year suffix.
+// Months that are not on the Calendar are written as numbers.
+func (c *Calendar) Format(d CalendarDate) string {
+	month := strconv.Itoa(d.Month)
+	if d.Month >= 1 && d.Month <= len(c.Months) {
+		month = c.Months[d.Month-1].Name
+	}
+
+	s := fmt.Sprintf("%d %s %d", d.Day, month, d.Year)
+	if c.Suffix != "" {
+		s += " " + c.Suffix
+	}
+
+	return s
+}
+
+// CurrentDate contains the current in-world date of a campaign according to
+// one of its calendars.
+type CurrentDate struct {
+	Calendar  *Calendar
+	Date      CalendarDate
+	Formatted string
+}
+
+// CalendarService handles communication with the Calendar endpoint.
+type CalendarService service
+
+// Index returns the list of all Calendars in the Campaign associated with
+// campID.
+// If a non-nil time is provided, Index will only return Calendars that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Calendars that were decoded.
+func (cs *CalendarService) Index(campID int, sync *time.Time) ([]*Calendar, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(cs.end)
+
+	if sync != nil {
+		end = end.sync(*sync)
+	}
+
+	var wrap response[[]json.RawMessage]
+
+	if err = cs.client.get(end, &wrap); err != nil {
+		return nil, fmt.Errorf("cannot get Calendar Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	var list []*Calendar
+	if err = decodeList(wrap.Data, &list, cs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode Calendar Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// IndexAll returns the list of all Calendars in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Calendars that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Calendars that were decoded.
+func (cs *CalendarService) IndexAll(campID int, sync *time.Time) ([]*Calendar, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(cs.end)
+
+	if sync != nil {
+		end = end.sync(*sync)
+	}
+
+	raws, err := cs.client.indexAll(end)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get every page of Calendar Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	var list []*Calendar
+	if err = decodeList(raws, &list, cs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode Calendar Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// Get returns the Calendar associated with calID from the Campaign associated
+// with campID.
+func (cs *CalendarService) Get(campID int, calID int) (*Calendar, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(cs.end)
+
+	if end, err = end.id(calID); err != nil {
+		return nil, fmt.Errorf("invalid Calendar ID: %w", err)
+	}
+
+	var wrap response[*Calendar]
+
+	if err = cs.client.get(end, &wrap); err != nil {
+		return nil, fmt.Errorf("cannot get Calendar (ID: %d) from Campaign (ID: %d): %w", calID, campID, err)
+	}
+
+	return wrap.Data, nil
+}
+
+// CurrentDate returns the current in-world date of the Campaign associated
+// with campID according to its calendar. CurrentDate returns an error
+// matching ErrNotFound if the Campaign has no calendar and ErrAmbiguous if it
+// has more than one, in which case the current date of the chosen Calendar is
+// found with its Current method.
+func (cs *CalendarService) CurrentDate(campID int) (*CurrentDate, error) {
+	cals, err := cs.Index(campID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	switch len(cals) {
+	case 0:
+		return nil, fmt.Errorf("cannot get current date of Campaign (ID: %d): no Calendar: %w", campID, ErrNotFound)
+	case 1:
+	default:
+		return nil, fmt.Errorf("cannot get current date of Campaign (ID: %d): %d Calendars: %w", campID, len(cals), ErrAmbiguous)
+	}
+
+	cal := cals[0]
+	d, err := cal.Current()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get current date of Calendar (ID: %d) from Campaign (ID: %d): %w", cal.ID, campID, err)
+	}
+
+	return &CurrentDate{Calendar: cal, Date: d, Formatted: cal.Format(d)}, nil
+}
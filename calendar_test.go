@@ -0,0 +1,350 @@
+package kanka
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testCalendarIndex string = "test_data/calendar_index.json"
+	testCalendarGet   string = "test_data/calendar_get.json"
+)
+
+// testHarptos returns the Calendar found in the calendar test data.
+func testHarptos() *Calendar {
+	return &Calendar{
+		ID:     111,
+		Name:   "Harptos",
+		Type:   "Solar",
+		Date:   "1024-3-15",
+		Suffix: "DR",
+		Months: []CalendarMonth{
+			{Name: "Frostmoot", Length: 30, Type: "standard"},
+			{Name: "Thawing", Length: 28, Type: "standard"},
+			{Name: "Greening", Length: 30, Type: "standard"},
+		},
+		Weekdays: []string{"Firstday", "Secondday"},
+		EntityID: 430214,
+	}
+}
+
+func TestCalendar_Occurrences(t *testing.T) {
+	cal := &Calendar{
+		Months: []CalendarMonth{
+			{Name: "Frostmoot", Length: 30},
+			{Name: "Thawing", Length: 28},
+			{Name: "Greening", Length: 30},
+		},
+		HasLeapYear:    true,
+		LeapYearAmount: 1,
+		LeapYearMonth:  2,
+		LeapYearOffset: 4,
+		LeapYearStart:  0,
+	}
+
+	evt := func(day, month, year int, recurring bool, until int, period string) *EntityEvent {
+		return &EntityEvent{SimpleEntityEvent: SimpleEntityEvent{
+			Day:                  day,
+			Month:                month,
+			Year:                 year,
+			IsRecurring:          recurring,
+			RecurringUntil:       until,
+			RecurringPeriodicity: period,
+		}}
+	}
+
+	tests := []struct {
+		name string
+		evt  *EntityEvent
+		from CalendarDate
+		to   CalendarDate
+		want []CalendarDate
+	}{
+		{
+			name: "Single event in range",
+			evt:  evt(15, 1, 1001, false, 0, ""),
+			from: CalendarDate{Year: 1001, Month: 1, Day: 1},
+			to:   CalendarDate{Year: 1001, Month: 3, Day: 30},
+			want: []CalendarDate{{Year: 1001, Month: 1, Day: 15}},
+		},
+		{
+			name: "Single event out of range",
+			evt:  evt(15, 1, 1000, false, 0, ""),
+			from: CalendarDate{Year: 1001, Month: 1, Day: 1},
+			to:   CalendarDate{Year: 1001, Month: 3, Day: 30},
+			want: nil,
+		},
+		{
+			name: "Yearly event without end",
+			evt:  evt(10, 3, 998, true, 0, RecurringYear),
+			from: CalendarDate{Year: 1000, Month: 3, Day: 11},
+			to:   CalendarDate{Year: 1003, Month: 1, Day: 1},
+			want: []CalendarDate{
+				{Year: 1001, Month: 3, Day: 10},
+				{Year: 1002, Month: 3, Day: 10},
+			},
+		},
+		{
+			name: "Yearly event with end",
+			evt:  evt(10, 3, 998, true, 1001, ""),
+			from: CalendarDate{Year: 1000, Month: 1, Day: 1},
+			to:   CalendarDate{Year: 1005, Month: 1, Day: 1},
+			want: []CalendarDate{
+				{Year: 1000, Month: 3, Day: 10},
+				{Year: 1001, Month: 3, Day: 10},
+			},
+		},
+		{
+			name: "Yearly leap day event",
+			evt:  evt(29, 2, 1000, true, 0, RecurringYear),
+			from: CalendarDate{Year: 1000, Month: 1, Day: 1},
+			to:   CalendarDate{Year: 1008, Month: 3, Day: 30},
+			want: []CalendarDate{
+				{Year: 1000, Month: 2, Day: 29},
+				{Year: 1004, Month: 2, Day: 29},
+				{Year: 1008, Month: 2, Day: 29},
+			},
+		},
+		{
+			name: "Monthly event",
+			evt:  evt(30, 1, 1001, true, 0, RecurringMonth),
+			from: CalendarDate{Year: 1001, Month: 1, Day: 1},
+			to:   CalendarDate{Year: 1002, Month: 1, Day: 30},
+			want: []CalendarDate{
+				{Year: 1001, Month: 1, Day: 30},
+				{Year: 1001, Month: 3, Day: 30},
+				{Year: 1002, Month: 1, Day: 30},
+			},
+		},
+		{
+			name: "Monthly event starting after range start",
+			evt:  evt(5, 2, 1001, true, 0, RecurringMonth),
+			from: CalendarDate{Year: 1000, Month: 1, Day: 1},
+			to:   CalendarDate{Year: 1001, Month: 3, Day: 30},
+			want: []CalendarDate{
+				{Year: 1001, Month: 2, Day: 5},
+				{Year: 1001, Month: 3, Day: 5},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := cal.Occurrences(test.evt, test.from, test.to)
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCalendarService_Index(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		campID  int
+		want    []*Calendar
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testCalendarIndex,
+			campID:  5272,
+			want:    []*Calendar{testHarptos()},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testCalendarIndex,
+			campID:  -123,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			campID:  5272,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			campID:  5272,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Calendars.Index(test.campID, nil)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCalendarService_Get(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		campID  int
+		calID   int
+		want    *Calendar
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testCalendarGet,
+			campID:  5272,
+			calID:   111,
+			want:    testHarptos(),
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid calID",
+			status:  http.StatusOK,
+			file:    testCalendarGet,
+			campID:  5272,
+			calID:   -123,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			campID:  5272,
+			calID:   111,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Calendars.Get(test.campID, test.calID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCalendarService_CurrentDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		dates   []string
+		want    CalendarDate
+		wantFmt string
+		wantErr error
+	}{
+		{
+			name:    "Single calendar",
+			dates:   []string{"1024-3-15"},
+			want:    CalendarDate{Year: 1024, Month: 3, Day: 15},
+			wantFmt: "15 Greening 1024 DR",
+			wantErr: nil,
+		},
+		{
+			name:    "No calendar",
+			dates:   nil,
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "Several calendars",
+			dates:   []string{"1024-3-15", "12-1-1"},
+			wantErr: ErrAmbiguous,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				cals := make([]string, len(test.dates))
+				for i, d := range test.dates {
+					cals[i] = fmt.Sprintf(`{"id":%d,"date":"%s","suffix":"DR","months":[{"name":"Frostmoot"},{"name":"Thawing"},{"name":"Greening"}]}`, i+1, d)
+				}
+				fmt.Fprintf(w, `{"data":[%s]}`, strings.Join(cals, ","))
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			got, err := c.Calendars.CurrentDate(5272)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("got err: <%v>, want err: <%v>", err, test.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if diff := cmp.Diff(got.Date, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if got.Formatted != test.wantFmt {
+				t.Errorf("got formatted: <%s>, want formatted: <%s>", got.Formatted, test.wantFmt)
+			}
+		})
+	}
+}
+
+func TestParseCalendarDate(t *testing.T) {
+	tests := []struct {
+		name    string
+		date    string
+		want    CalendarDate
+		wantErr bool
+	}{
+		{name: "Positive year", date: "1024-3-15", want: CalendarDate{Year: 1024, Month: 3, Day: 15}, wantErr: false},
+		{name: "Negative year", date: "-200-12-1", want: CalendarDate{Year: -200, Month: 12, Day: 1}, wantErr: false},
+		{name: "Missing day", date: "1024-3", want: CalendarDate{}, wantErr: true},
+		{name: "Not a number", date: "1024-Greening-15", want: CalendarDate{}, wantErr: true},
+		{name: "Empty", date: "", want: CalendarDate{}, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := parseCalendarDate(test.date)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
@@ -1,7 +1,10 @@
 package kanka
 
 import (
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 )
 
@@ -33,10 +36,31 @@ type Members struct {
 	Sync time.Time `json:"sync"`
 }
 
+// UnmarshalJSON unmarshals the JSON-encoded data into the Members. A null value
+// or a null list is unmarshaled into an empty list rather than a nil one.
+func (ms *Members) UnmarshalJSON(b []byte) error {
+	type alias Members
+	var al alias
+
+	if !isNull(b) {
+		if err := json.Unmarshal(b, &al); err != nil {
+			return err
+		}
+	}
+
+	if al.Data == nil {
+		al.Data = []*Member{}
+	}
+
+	*ms = Members(al)
+	return nil
+}
+
 // Member provides simple data about a member of a campaign.
 type Member struct {
-	ID   int  `json:"id"`
-	User User `json:"user"`
+	ID   int    `json:"id"`
+	User User   `json:"user"`
+	Role string `json:"role"`
 }
 
 // User provides simple data about a user.
@@ -46,6 +70,15 @@ type User struct {
 	Avatar string `json:"avatar"`
 }
 
+// Role provides simple data about a role of a campaign. Roles are used to
+// grant campaign members different levels of access.
+type Role struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	IsAdmin  bool   `json:"is_admin"`
+	IsPublic bool   `json:"is_public"`
+}
+
 // Links provides paging data.
 type Links struct {
 	First string      `json:"first"`
@@ -69,28 +102,28 @@ type Meta struct {
 type CampaignService service
 
 // Index returns a list of all the campaigns the user has access to.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Campaigns that were decoded.
 func (cs *CampaignService) Index() ([]*Campaign, error) {
-	var wrap struct {
-		Data  []*Campaign `json:"data"`
-		Links Links       `json:"links"`
-		Meta  Meta        `json:"meta"`
-		Sync  time.Time   `json:"sync"`
-		//TODO: Implement paging.
-	}
+	//TODO: Implement paging.
+	var wrap response[[]json.RawMessage]
 
 	err := cs.client.get(cs.end, &wrap)
 	if err != nil {
 		return nil, fmt.Errorf("cannot get Campaign index: %w", err)
 	}
 
-	return wrap.Data, nil
+	var list []*Campaign
+	if err = decodeList(wrap.Data, &list, cs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode Campaign index: %w", err)
+	}
+
+	return list, nil
 }
 
 // Get returns the Campaign corresponding with the provided ID.
 func (cs *CampaignService) Get(campID int) (*Campaign, error) {
-	var wrap struct {
-		Data *Campaign `json:"data"`
-	}
+	var wrap response[*Campaign]
 
 	end, err := cs.end.id(campID)
 	if err != nil {
@@ -105,23 +138,174 @@ func (cs *CampaignService) Get(campID int) (*Campaign, error) {
 	return wrap.Data, nil
 }
 
-const pathUsers string = "/users"
-
 // Members returns a list of all members of the Campaign corresponding with the
-// provided id.
+// provided id, following every page of the list.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Members that were decoded.
 func (cs *CampaignService) Members(campID int) ([]*Member, error) {
-	var wrap Members
+	end, err := cs.end.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(endpointCampaignUser)
+
+	raws, err := cs.client.indexAll(end)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Members from Campaign with ID '%d': %w", campID, err)
+	}
+
+	var list []*Member
+	if err = decodeList(raws, &list, cs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode Members from Campaign with ID '%d': %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// Roles returns a list of all roles of the Campaign corresponding with the
+// provided id, following every page of the list.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Roles that were decoded.
+func (cs *CampaignService) Roles(campID int) ([]*Role, error) {
+	end, err := cs.end.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(endpointCampaignRole)
+
+	raws, err := cs.client.indexAll(end)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Roles from Campaign with ID '%d': %w", campID, err)
+	}
+
+	var list []*Role
+	if err = decodeList(raws, &list, cs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode Roles from Campaign with ID '%d': %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// Module describes a module of a campaign, such as "characters" or
+// "locations", as configured by the campaign.
+type Module struct {
+	// Name is the key of the module, such as "locations".
+	Name string
+	// Enabled reports whether the module is turned on in the campaign.
+	Enabled bool
+	// Singular and Plural are the custom names given to the entities of the
+	// module, such as "Realm" and "Realms". Both are empty if the module keeps
+	// its default name.
+	Singular string
+	Plural   string
+}
+
+// moduleName contains the custom names of a module.
+type moduleName struct {
+	Singular string `json:"singular"`
+	Plural   string `json:"plural"`
+}
+
+// Modules returns the modules of the Campaign corresponding with the provided
+// id, ordered by name. A module is listed if the campaign settings returned by
+// Kanka include it, either to turn it on or off or to rename it. Modules that
+// are renamed but not turned off are reported as enabled.
+func (cs *CampaignService) Modules(campID int) ([]*Module, error) {
+	var wrap response[json.RawMessage]
 
 	end, err := cs.end.id(campID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 
-	end = end.append(pathUsers)
 	err = cs.client.get(end, &wrap)
 	if err != nil {
-		return nil, fmt.Errorf("cannot get Members from Campaign with ID '%d': %w", campID, err)
+		return nil, fmt.Errorf("cannot get Modules from Campaign with ID '%d': %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	// Only the settings of the campaign are decoded, so the rest of the
+	// campaign is never rejected by strict decoding.
+	var camp struct {
+		Setting map[string]json.RawMessage `json:"setting"`
+		Modules map[string]moduleName      `json:"modules"`
+	}
+	if err = json.Unmarshal(wrap.Data, &camp); err != nil {
+		return nil, fmt.Errorf("cannot decode Modules from Campaign with ID '%d': %w", campID, err)
+	}
+
+	mods := make(map[string]*Module)
+	module := func(name string) *Module {
+		if mods[name] == nil {
+			mods[name] = &Module{Name: name, Enabled: true}
+		}
+		return mods[name]
+	}
+
+	for name, raw := range camp.Setting {
+		switch strings.TrimSpace(string(raw)) {
+		case "true", "1":
+			module(name).Enabled = true
+		case "false", "0", "null":
+			module(name).Enabled = false
+		default:
+			return nil, fmt.Errorf("cannot decode setting '%s' of Campaign with ID '%d': unexpected value %s", name, campID, raw)
+		}
+	}
+
+	for name, n := range camp.Modules {
+		m := module(name)
+		m.Singular, m.Plural = n.Singular, n.Plural
+	}
+
+	list := make([]*Module, 0, len(mods))
+	for _, m := range mods {
+		list = append(list, m)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+
+	return list, nil
+}
+
+// Users maps user IDs to the Users they belong to, such as the IDs of the
+// CreatedBy and UpdatedBy fields of an object.
+type Users map[int]*User
+
+// ResolveUsers returns the Users associated with the provided ids among the
+// members of the Campaign associated with campID, keyed by user ID. The
+// members are retrieved with a single request. IDs of users who are not
+// members of the campaign, such as former members, are left out.
+func (c *Client) ResolveUsers(campID int, ids []int) (Users, error) {
+	mems, err := c.Campaigns.Members(campID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve users of Campaign (ID: %d): %w", campID, err)
+	}
+
+	want := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		want[id] = true
+	}
+
+	users := make(Users)
+	for _, m := range mems {
+		if want[m.User.ID] {
+			u := m.User
+			users[u.ID] = &u
+		}
+	}
+
+	return users, nil
+}
+
+// Audit contains the Users who created and last updated an object.
+type Audit struct {
+	// CreatedBy and UpdatedBy are nil if the user is unknown.
+	CreatedBy *User
+	UpdatedBy *User
+}
+
+// Audit returns the Users associated with the provided IDs of the CreatedBy
+// and UpdatedBy fields of an object, such as a Character, so that the object
+// can be shown as last edited by the name of a user.
+func (us Users) Audit(createdBy int, updatedBy int) Audit {
+	return Audit{CreatedBy: us[createdBy], UpdatedBy: us[updatedBy]}
 }
@@ -9,9 +9,12 @@ import (
 )
 
 const (
-	testCampaignIndex   string = "test_data/campaign_index.json"
-	testCampaignGet     string = "test_data/campaign_get.json"
-	testCampaignMembers string = "test_data/campaign_members.json"
+	testCampaignIndex        string = "test_data/campaign_index.json"
+	testCampaignGet          string = "test_data/campaign_get.json"
+	testCampaignMembers      string = "test_data/campaign_members.json"
+	testCampaignRoles        string = "test_data/campaign_roles.json"
+	testCampaignRolesPartial string = "test_data/campaign_roles_partial.json"
+	testCampaignModules      string = "test_data/campaign_modules.json"
 )
 
 func TestCampaignService_Index(t *testing.T) {
@@ -215,6 +218,7 @@ func TestCampaignService_Members(t *testing.T) {
 				Name:   "Jon",
 				Avatar: "jon_brooding.png",
 			},
+			Role: "Admin",
 		},
 		{
 			ID: 333,
@@ -223,6 +227,7 @@ func TestCampaignService_Members(t *testing.T) {
 				Name:   "Daenerys",
 				Avatar: "daeny_burning_something.png",
 			},
+			Role: "Player",
 		},
 		{
 			ID: 555,
@@ -321,3 +326,235 @@ func TestCampaignService_Members(t *testing.T) {
 		})
 	}
 }
+
+func TestCampaignService_Roles(t *testing.T) {
+	roles := []*Role{
+		{
+			ID:       111,
+			Name:     "Admin",
+			IsAdmin:  true,
+			IsPublic: false,
+		},
+		{
+			ID:       222,
+			Name:     "Player",
+			IsAdmin:  false,
+			IsPublic: false,
+		},
+		{
+			ID:       333,
+			Name:     "Public",
+			IsAdmin:  false,
+			IsPublic: true,
+		},
+	}
+	type args struct {
+		campID int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*Role
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testCampaignRoles,
+			args:    args{campID: 5272},
+			want:    roles,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, partially malformed response, valid args",
+			status:  http.StatusOK,
+			file:    testCampaignRolesPartial,
+			args:    args{campID: 5272},
+			want:    roles,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testCampaignRoles,
+			args:    args{campID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Campaigns.Roles(test.args.campID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got: <%v>, want error: <%v>", err, test.wantErr)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCampaignService_Modules(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		campID  int
+		want    []*Module
+		wantErr bool
+	}{
+		{
+			name:   "StatusOK, valid response, valid args",
+			status: http.StatusOK,
+			file:   testCampaignModules,
+			campID: 5272,
+			want: []*Module{
+				{Name: "characters", Enabled: true},
+				{Name: "journals", Enabled: false},
+				{Name: "locations", Enabled: true, Singular: "Realm", Plural: "Realms"},
+				{Name: "quests", Enabled: false},
+				{Name: "tags", Enabled: true, Singular: "Label", Plural: "Labels"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, no settings, valid args",
+			status:  http.StatusOK,
+			file:    testCampaignGet,
+			campID:  5272,
+			want:    []*Module{},
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testCampaignModules,
+			campID:  -123,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status Unauthorized, error response, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			campID:  5272,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, test.file)
+			defer ts.Close()
+
+			got, err := c.Campaigns.Modules(test.campID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if !test.wantErr && rec.url != "/campaigns/5272?related=1" {
+				t.Errorf("got url: <%s>, want url: </campaigns/5272?related=1>", rec.url)
+			}
+		})
+	}
+}
+
+func TestClient_ResolveUsers(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		ids     []int
+		want    Users
+		wantErr bool
+	}{
+		{
+			name:   "Members and former member",
+			status: http.StatusOK,
+			ids:    []int{222, 666, 999},
+			want: Users{
+				222: {ID: 222, Name: "Jon", Avatar: "jon_brooding.png"},
+				666: {ID: 666, Name: "Stannis", Avatar: "stannis_also_brooding.png"},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "No IDs",
+			status:  http.StatusOK,
+			ids:     nil,
+			want:    Users{},
+			wantErr: false,
+		},
+		{
+			name:    "Status Unauthorized",
+			status:  http.StatusUnauthorized,
+			ids:     []int{222},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, testCampaignMembers)
+			defer ts.Close()
+
+			got, err := c.ResolveUsers(5272, test.ids)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if rec.url != "/campaigns/5272/users?related=1" {
+				t.Errorf("got url: <%s>, want url: </campaigns/5272/users?related=1>", rec.url)
+			}
+		})
+	}
+}
+
+func TestUsers_Audit(t *testing.T) {
+	jon := &User{ID: 222, Name: "Jon"}
+	users := Users{222: jon}
+
+	got := users.Audit(222, 999)
+	want := Audit{CreatedBy: jon, UpdatedBy: nil}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
@@ -4,25 +4,27 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // Character contains information about a character.
 // For more information, visit: https://kanka.io/en-US/docs/1.0/characters
 type Character struct {
 	SimpleCharacter
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
-	Traits         Traits    `json:"traits"`
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
+	Traits          Traits    `json:"traits"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -41,6 +43,7 @@ type SimpleCharacter struct {
 	Title            string   `json:"title,omitempty"`
 	Age              string   `json:"age,omitempty"`
 	Sex              string   `json:"sex,omitempty"`
+	Pronouns         string   `json:"pronouns,omitempty"`
 	Type             string   `json:"type,omitempty"`
 	FamilyID         int      `json:"family_id,omitempty"`
 	LocationID       int      `json:"location_id,omitempty"`
@@ -48,19 +51,36 @@ type SimpleCharacter struct {
 	Tags             []int    `json:"tags,omitempty"`
 	IsDead           bool     `json:"is_dead,omitempty"`
 	IsPrivate        bool     `json:"is_private,omitempty"`
+	IsTemplate       *bool    `json:"is_template,omitempty"`
 	Image            string   `json:"image,omitempty"`
 	ImageURL         string   `json:"image_url,omitempty"`
+	ImageUUID        string   `json:"image_uuid,omitempty"`
+	FocusX           *float64 `json:"focus_x,omitempty"`
+	FocusY           *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL   string   `json:"header_image_url,omitempty"`
+	HeaderUUID       string   `json:"entity_header_uuid,omitempty"`
 	PersonalityName  []string `json:"personality_name,omitempty"`
 	PersonalityEntry []string `json:"personality_entry,omitempty"`
 	AppearanceName   []string `json:"appearance_name,omitempty"`
 	AppearanceEntry  []string `json:"appearance_entry,omitempty"`
+
+	// IsPersonalityVisible shows the personality traits of the character to
+	// players who are not campaign admins if true and hides them if false.
+	IsPersonalityVisible *bool `json:"is_personality_visible,omitempty"`
+	// IsPersonalityPinned and IsAppearancePinned show the personality and
+	// appearance traits of the character in its pinned sidebar if true.
+	IsPersonalityPinned *bool `json:"is_personality_pinned,omitempty"`
+	IsAppearancePinned  *bool `json:"is_appearance_pinned,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleCharacter into its JSON-encoded form if it
 // has the required populated fields.
 func (sc SimpleCharacter) MarshalJSON() ([]byte, error) {
-	if blank.Is(sc.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleCharacter into JSON with a missing Name")
+	if err := requireField("SimpleCharacter", "Name", sc.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleCharacter
@@ -73,6 +93,26 @@ type Traits struct {
 	Data []*Trait `json:"data"`
 }
 
+// UnmarshalJSON unmarshals the JSON-encoded data into the Traits. A null value
+// or a null list is unmarshaled into an empty list rather than a nil one.
+func (ts *Traits) UnmarshalJSON(b []byte) error {
+	type alias Traits
+	var al alias
+
+	if !isNull(b) {
+		if err := json.Unmarshal(b, &al); err != nil {
+			return err
+		}
+	}
+
+	if al.Data == nil {
+		al.Data = []*Trait{}
+	}
+
+	*ts = Traits(al)
+	return nil
+}
+
 // Trait represents a character's personality or appearance detail.
 type Trait struct {
 	ID           int    `json:"id"`
@@ -83,138 +123,647 @@ type Trait struct {
 	DefaultOrder int    `json:"default_order"`
 }
 
+// Available trait sections.
+const (
+	TraitPersonality string = "personality"
+	TraitAppearance  string = "appearance"
+)
+
+// SortTraitsBySection returns the provided traits grouped by Section, such as
+// TraitPersonality, with the traits of each section in display order. Traits
+// are ordered by DefaultOrder and then by ID.
+func SortTraitsBySection(traits []*Trait) map[string][]*Trait {
+	sections := make(map[string][]*Trait)
+	for _, t := range traits {
+		sections[t.Section] = append(sections[t.Section], t)
+	}
+
+	for _, list := range sections {
+		sort.SliceStable(list, func(i, j int) bool {
+			if list[i].DefaultOrder != list[j].DefaultOrder {
+				return list[i].DefaultOrder < list[j].DefaultOrder
+			}
+			return list[i].ID < list[j].ID
+		})
+	}
+
+	return sections
+}
+
+// Simple returns a copy of the SimpleCharacter data of the Character, ready to
+// be modified and passed to Update. The path of the current image is left out
+// and the slices of the copy do not share memory with the Character.
+func (c *Character) Simple() SimpleCharacter {
+	s := c.SimpleCharacter
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+	s.PersonalityName = append([]string(nil), s.PersonalityName...)
+	s.PersonalityEntry = append([]string(nil), s.PersonalityEntry...)
+	s.AppearanceName = append([]string(nil), s.AppearanceName...)
+	s.AppearanceEntry = append([]string(nil), s.AppearanceEntry...)
+
+	return s
+}
+
 // CharacterService handles communication with the Character endpoint.
 type CharacterService service
 
+// base returns the baseService implementing the common operations of the
+// CharacterService.
+func (cs *CharacterService) base() baseService[Character, SimpleCharacter] {
+	return baseService[Character, SimpleCharacter]{
+		service: (*service)(cs),
+		kind:    "Character",
+		entity:  "character",
+		label:   func(ch SimpleCharacter) string { return ch.Name },
+	}
+}
+
 // Index returns the list of all Characters in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Characters that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Characters that were decoded.
 func (cs *CharacterService) Index(campID int, sync *time.Time) ([]*Character, error) {
-	end, err := EndpointCampaign.id(campID)
+	return cs.base().Index(campID, sync)
+}
+
+// IndexAll returns the list of all Characters in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Characters that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Characters that were decoded.
+func (cs *CharacterService) IndexAll(campID int, sync *time.Time) ([]*Character, error) {
+	return cs.base().IndexAll(campID, sync)
+}
+
+// Query parameters Kanka reads the CharacterFilter from.
+const (
+	paramIsDead    string = "is_dead"
+	paramIsPrivate string = "is_private"
+	paramType      string = "type"
+)
+
+// CharacterFilter restricts a list of Characters to those matching every
+// populated field. A nil or empty field does not restrict the list.
+type CharacterFilter struct {
+	IsDead    *bool
+	IsPrivate *bool
+	Type      string
+}
+
+// apply returns the provided endpoint with the populated fields of the
+// CharacterFilter added as query parameters.
+func (f CharacterFilter) apply(end endpoint) endpoint {
+	flag := func(b bool) string {
+		if b {
+			return "1"
+		}
+		return "0"
+	}
+
+	if f.IsDead != nil {
+		end = end.query(paramIsDead, flag(*f.IsDead))
+	}
+	if f.IsPrivate != nil {
+		end = end.query(paramIsPrivate, flag(*f.IsPrivate))
+	}
+	if f.Type != "" {
+		end = end.query(paramType, f.Type)
+	}
+
+	return end
+}
+
+// matches reports whether the provided Character matches every populated field
+// of the CharacterFilter.
+func (f CharacterFilter) matches(ch *Character) bool {
+	if f.IsDead != nil && ch.IsDead != *f.IsDead {
+		return false
+	}
+	if f.IsPrivate != nil && ch.IsPrivate != *f.IsPrivate {
+		return false
+	}
+	if f.Type != "" && ch.Type != f.Type {
+		return false
+	}
+
+	return true
+}
+
+// IndexFiltered returns the list of all Characters in the Campaign associated
+// with campID that match the provided CharacterFilter, from every page of the
+// list. The filter is sent to Kanka and the results are filtered again in case
+// the filter is ignored.
+// If a non-nil time is provided, IndexFiltered will only return Characters
+// that have been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Characters that were decoded.
+func (cs *CharacterService) IndexFiltered(campID int, filter CharacterFilter, sync *time.Time) ([]*Character, error) {
+	bs := cs.base()
+
+	end, err := bs.endpoint(campID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return nil, err
 	}
-	end = end.concat(cs.end)
+	end = filter.apply(end)
 
 	if sync != nil {
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*Character `json:"data"`
+	raws, err := cs.client.indexAll(end)
+	if err != nil {
+		return nil, bs.fail("index", campID, 0, err, "cannot get every page of filtered Character Index from Campaign (ID: %d)", campID)
+	}
+
+	var list []*Character
+	err = decodeList(raws, &list, cs.client.strict)
+
+	var chars []*Character
+	for _, ch := range list {
+		if filter.matches(ch) {
+			chars = append(chars, ch)
+		}
 	}
 
-	err = cs.client.get(end, &wrap)
 	if err != nil {
-		return nil, fmt.Errorf("cannot get Character Index from Campaign (ID: %d): %w", campID, err)
+		return chars, bs.fail("index", campID, 0, err, "cannot decode filtered Character Index from Campaign (ID: %d)", campID)
 	}
 
-	return wrap.Data, nil
+	return chars, nil
+}
+
+// GetMany returns the Characters associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Characters, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Characters that were
+// found.
+func (cs *CharacterService) GetMany(campID int, ids []int) (map[int]*Character, error) {
+	return cs.base().GetMany(campID, ids)
+}
+
+// Count returns the number of Characters in the Campaign associated with campID
+// without retrieving them.
+func (cs *CharacterService) Count(campID int) (int, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(cs.end)
+
+	n, err := cs.client.count(end)
+	if err != nil {
+		return 0, fmt.Errorf("cannot count Characters in Campaign (ID: %d): %w", campID, err)
+	}
+
+	return n, nil
 }
 
 // Get returns the Character associated with charID from the Campaign
 // associated with campID.
 func (cs *CharacterService) Get(campID int, charID int) (*Character, error) {
+	return cs.base().Get(campID, charID)
+}
+
+// GetByName returns the Character with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Character has the name and ErrAmbiguous if more than one does.
+func (cs *CharacterService) GetByName(campID int, name string) (*Character, error) {
+	return cs.base().GetByName(campID, name)
+}
+
+// GetRaw returns the Character associated with charID from the Campaign
+// associated with campID along with the raw JSON of the response's data
+// element. The raw JSON preserves any fields the Character does not model.
+func (cs *CharacterService) GetRaw(campID int, charID int) (*Character, json.RawMessage, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return nil, nil, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(cs.end)
 
 	end, err = end.id(charID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Character ID: %w", err)
+		return nil, nil, fmt.Errorf("invalid Character ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *Character `json:"data"`
-	}
+	var wrap response[json.RawMessage]
 
 	err = cs.client.get(end, &wrap)
 	if err != nil {
-		return nil, fmt.Errorf("cannot get Character (ID: %d) from Campaign (ID: %d): %w", charID, campID, err)
+		return nil, nil, fmt.Errorf("cannot get Character (ID: %d) from Campaign (ID: %d): %w", charID, campID, err)
 	}
 
-	return wrap.Data, nil
+	var ch *Character
+	if err = unmarshal(wrap.Data, &ch, cs.client.strict); err != nil {
+		return nil, nil, fmt.Errorf("cannot decode Character (ID: %d) from Campaign (ID: %d): %w", charID, campID, err)
+	}
+
+	return ch, wrap.Data, nil
 }
 
 // Create creates a new Character in the Campaign associated with campID using
 // the provided SimpleCharacter data.
 // Create returns the newly created Character.
-func (cs *CharacterService) Create(campID int, ch SimpleCharacter) (*Character, error) {
+// The provided CreateOptions, such as Public, apply to this call only.
+func (cs *CharacterService) Create(campID int, ch SimpleCharacter, opts ...CreateOption) (*Character, error) {
+	return cs.base().Create(campID, ch, opts...)
+}
+
+// CreateWithResult creates a new Character in the Campaign associated with campID
+// using the provided SimpleCharacter data.
+// CreateWithResult returns the newly created Character along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (cs *CharacterService) CreateWithResult(campID int, ch SimpleCharacter, opts ...CreateOption) (*Character, *WriteResult, error) {
+	return cs.base().CreateWithResult(campID, ch, opts...)
+}
+
+// Update updates an existing Character associated with charID from the
+// Campaign associated with campID using the provided SimpleCharacter data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Character returned by Kanka, provide its SimpleCharacter.
+// Update returns the newly updated Character.
+func (cs *CharacterService) Update(campID int, charID int, ch SimpleCharacter) (*Character, error) {
+	return cs.base().Update(campID, charID, ch)
+}
+
+// UpdateWithResult updates an existing Character associated with charID from the
+// Campaign associated with campID using the provided SimpleCharacter data, as
+// Update does.
+// UpdateWithResult returns the newly updated Character along with the metadata of
+// Kanka's response, such as its status code.
+func (cs *CharacterService) UpdateWithResult(campID int, charID int, ch SimpleCharacter) (*Character, *WriteResult, error) {
+	return cs.base().UpdateWithResult(campID, charID, ch)
+}
+
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Character associated with charID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Character is left unchanged.
+// Clear returns the newly updated Character.
+func (cs *CharacterService) Clear(campID int, charID int, fields ...string) (*Character, error) {
+	return cs.base().Clear(campID, charID, fields...)
+}
+
+// SetHeader sets the header image of an existing Character associated with charID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Character on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Character.
+func (cs *CharacterService) SetHeader(campID int, charID int, uuid string) (*Character, error) {
+	return cs.base().SetHeader(campID, charID, uuid)
+}
+
+// SetHeaderURL sets the header image of an existing Character associated with
+// charID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Character.
+func (cs *CharacterService) SetHeaderURL(campID int, charID int, url string) (*Character, error) {
+	return cs.base().SetHeaderURL(campID, charID, url)
+}
+
+// Delete deletes an existing Character associated with charID from the
+// Campaign associated with campID.
+func (cs *CharacterService) Delete(campID int, charID int) error {
+	return cs.base().Delete(campID, charID)
+}
+
+// SetRelations sets the Family and Location of the Character associated with
+// charID in the Campaign associated with campID in a single request. A nil ID
+// leaves the corresponding field unchanged and an ID of 0 clears it. Every
+// other field of the Character is left unchanged.
+// SetRelations returns the newly updated Character.
+func (cs *CharacterService) SetRelations(campID int, charID int, familyID *int, locationID *int) (*Character, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(cs.end)
 
-	b, err := json.Marshal(ch)
+	end, err = end.id(charID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleCharacter (Name: %s): %w", ch.Name, err)
+		return nil, fmt.Errorf("invalid Character ID: %w", err)
+	}
+
+	fields, err := optionalIDFields(map[string]*int{
+		"family_id":   familyID,
+		"location_id": locationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("invalid relations of Character (ID: %d): %w", charID, err)
 	}
 
-	var wrap struct {
-		Data *Character `json:"data"`
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal relations of Character (ID: %d): %w", charID, err)
 	}
 
-	err = cs.client.post(end, bytes.NewReader(b), &wrap)
+	var wrap response[*Character]
+
+	err = cs.client.patch(end, bytes.NewReader(b), &wrap)
 	if err != nil {
-		return nil, fmt.Errorf("cannot create Character (Name: %s) for Campaign (ID: %d): %w", ch.Name, campID, err)
+		return nil, fmt.Errorf("cannot set relations of Character (ID: %d) for Campaign (ID: %d): %w", charID, campID, err)
 	}
 
 	return wrap.Data, nil
 }
 
-// Update updates an existing Character associated with charID from the
-// Campaign associated with campID using the provided SimpleCharacter data.
-// Update returns the newly updated Character.
-func (cs *CharacterService) Update(campID int, charID int, ch SimpleCharacter) (*Character, error) {
-	end, err := EndpointCampaign.id(campID)
+// FullCharacter contains a Character along with the Attributes and Relations
+// created for it by CreateFull.
+type FullCharacter struct {
+	Character  *Character
+	Attributes []*Attribute
+	Relations  []*Relation
+}
+
+// CreateFull creates a new Character in the Campaign associated with campID
+// using the provided SimpleCharacter data, then creates each of the provided
+// Attributes and Relations for the new Character's entity. The owner of each
+// Relation is set to the new Character's entity. The traits of the Character
+// are created along with it from the SimpleCharacter data. Every request is
+// subject to the rate limit of the Client, if any.
+// If any Attribute or Relation cannot be created, the new Character is deleted
+// along with everything created for it and the error is returned. The error
+// also reports a failure to delete the Character, in which case the returned
+// FullCharacter contains everything that was created.
+// CreateFull returns the newly created Character, Attributes, and Relations.
+func (cs *CharacterService) CreateFull(campID int, ch SimpleCharacter, atrs []SimpleAttribute, rels []SimpleRelation) (*FullCharacter, error) {
+	char, err := cs.Create(campID, ch)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return nil, err
 	}
-	end = end.concat(cs.end)
 
-	end, err = end.id(charID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Character ID: %w", err)
+	full := &FullCharacter{Character: char}
+
+	rollback := func(err error) (*FullCharacter, error) {
+		if derr := cs.Delete(campID, char.ID); derr != nil {
+			return full, fmt.Errorf("cannot create Character (Name: %s) in full: %w (rollback failed: %v)", ch.Name, err, derr)
+		}
+
+		return nil, fmt.Errorf("cannot create Character (Name: %s) in full: %w", ch.Name, err)
+	}
+
+	for _, atr := range atrs {
+		created, err := cs.client.Attributes.Create(campID, char.EntityID, atr)
+		if err != nil {
+			return rollback(err)
+		}
+		full.Attributes = append(full.Attributes, created)
 	}
 
-	b, err := json.Marshal(ch)
+	for _, rel := range rels {
+		rel.OwnerID = char.EntityID
+
+		created, err := cs.client.Relations.Create(campID, char.EntityID, rel)
+		if err != nil {
+			return rollback(err)
+		}
+		full.Relations = append(full.Relations, created)
+	}
+
+	return full, nil
+}
+
+// ReorderTraits rearranges the traits of the provided section, either
+// TraitPersonality or TraitAppearance, of the Character associated with charID
+// from the Campaign associated with campID into the order of the provided
+// trait IDs. Every trait of the section must be listed exactly once. Kanka
+// has no endpoint for traits, so the section is sent in its new order in an
+// update of the Character, which sets their DefaultOrder. Kanka may assign
+// new IDs to the rearranged traits. The traits of the other section are left
+// untouched.
+// ReorderTraits returns the newly updated Character.
+func (cs *CharacterService) ReorderTraits(campID int, charID int, section string, traitIDs []int) (*Character, error) {
+	if section != TraitPersonality && section != TraitAppearance {
+		return nil, fmt.Errorf("cannot reorder traits of Character (ID: %d) in unknown section '%s'", charID, section)
+	}
+
+	char, err := cs.Get(campID, charID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleCharacter (Name: %s): %w", ch.Name, err)
+		return nil, err
+	}
+
+	current := make(map[int]*Trait)
+	for _, t := range char.Traits.Data {
+		if t.Section == section {
+			current[t.ID] = t
+		}
+	}
+
+	if len(traitIDs) != len(current) {
+		return nil, fmt.Errorf("cannot reorder %d %s traits of Character (ID: %d) using %d IDs", len(current), section, charID, len(traitIDs))
 	}
 
-	var wrap struct {
-		Data *Character `json:"data"`
+	var names, entries []string
+	seen := make(map[int]bool)
+	for _, id := range traitIDs {
+		t, ok := current[id]
+		if !ok || seen[id] {
+			return nil, fmt.Errorf("cannot reorder %s traits of Character (ID: %d) using missing or repeated trait (ID: %d)", section, charID, id)
+		}
+		seen[id] = true
+
+		names = append(names, t.Name)
+		entries = append(entries, t.Entry)
 	}
 
-	err = cs.client.put(end, bytes.NewReader(b), &wrap)
+	ch := char.Simple()
+	if section == TraitPersonality {
+		ch.PersonalityName, ch.PersonalityEntry = names, entries
+	} else {
+		ch.AppearanceName, ch.AppearanceEntry = names, entries
+	}
+
+	return cs.Update(campID, charID, ch)
+}
+
+// CharacterWithPosts contains a Character along with the EntityNotes, also
+// known as posts, of its entity.
+type CharacterWithPosts struct {
+	Character *Character
+	Posts     []*EntityNote
+}
+
+// GetWithPosts returns the Character associated with charID from the Campaign
+// associated with campID along with the EntityNotes, including their entries,
+// of the Character's entity. The Character and its EntityNotes are retrieved
+// with separate requests, each subject to the rate limit of the Client, if any.
+func (cs *CharacterService) GetWithPosts(campID int, charID int) (*CharacterWithPosts, error) {
+	char, err := cs.Get(campID, charID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update Character (Name: %s) for Campaign (ID: %d): '%w'", ch.Name, campID, err)
+		return nil, err
 	}
 
-	return wrap.Data, nil
+	posts, err := cs.client.EntityNotes.Index(campID, char.EntityID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get posts of Character (ID: %d) from Campaign (ID: %d): %w", charID, campID, err)
+	}
+
+	return &CharacterWithPosts{Character: char, Posts: posts}, nil
 }
 
-// Delete deletes an existing Character associated with charID from the
-// Campaign associated with campID.
-func (cs *CharacterService) Delete(campID int, charID int) error {
-	end, err := EndpointCampaign.id(campID)
+// CreateIfAbsent searches every page of the search results of the Campaign
+// associated with campID for a Character with the same name as the provided
+// SimpleCharacter. Names are matched exactly or, failing that, regardless of
+// case. If one exists, it is returned along with false. Otherwise, a new
+// Character is created using the provided SimpleCharacter data and returned
+// along with true.
+func (cs *CharacterService) CreateIfAbsent(campID int, ch SimpleCharacter) (*Character, bool, error) {
+	res, err := cs.client.SearchAll(campID, ch.Name, nil)
 	if err != nil {
-		return fmt.Errorf("invalid Campaign ID: %w", err)
+		return nil, false, fmt.Errorf("cannot search for Character '%s' in Campaign (ID: %d): %w", ch.Name, campID, err)
 	}
-	end = end.concat(cs.end)
 
-	end, err = end.id(charID)
+	var match *Result
+	for _, r := range res {
+		if r.Type != "character" {
+			continue
+		}
+
+		if r.Name == ch.Name {
+			match = r
+			break
+		}
+		if match == nil && strings.EqualFold(r.Name, ch.Name) {
+			match = r
+		}
+	}
+
+	if match != nil {
+		char, err := cs.Get(campID, match.ID)
+		if err != nil {
+			return nil, false, err
+		}
+
+		return char, false, nil
+	}
+
+	char, err := cs.Create(campID, ch)
 	if err != nil {
-		return fmt.Errorf("invalid Character ID: %w", err)
+		return nil, false, err
 	}
 
-	err = cs.client.delete(end)
+	return char, true, nil
+}
+
+// CreateInCampaigns creates a new Character using the provided
+// SimpleCharacter data in each of the Campaigns associated with campIDs.
+// The Characters are created one Campaign at a time and a failure in one
+// Campaign does not prevent the creation in the others.
+// CreateInCampaigns returns the newly created Characters and the errors that
+// occurred, each keyed by Campaign ID. The error map is nil if every creation
+// succeeded.
+func (cs *CharacterService) CreateInCampaigns(campIDs []int, ch SimpleCharacter) (map[int]*Character, map[int]error) {
+	chars := make(map[int]*Character)
+	var errs map[int]error
+
+	for _, id := range campIDs {
+		char, err := cs.Create(id, ch)
+		if err != nil {
+			if errs == nil {
+				errs = make(map[int]error)
+			}
+			errs[id] = err
+			continue
+		}
+		chars[id] = char
+	}
+
+	return chars, errs
+}
+
+// RecreateFrom re-creates a deleted Character in the Campaign associated with
+// campID from the provided locally stored copy, for types of objects Kanka
+// cannot restore on its own. Fields managed by Kanka, such as the ID and the
+// path of the image, are not sent. The Attributes and Relations of the copy
+// are re-created for the new Character, along with the provided inventory
+// items, such as those stored from EntityInventories.Index. A Character holds
+// a single Inventory, so if no items are provided, only the Inventory of the
+// copy is re-created; pass the stored items to restore a full inventory. The
+// Family, Location, Race, Tags, Relation targets, and inventory items of the
+// copy are re-pointed using the provided IDMap, so objects the Character
+// refers to should be re-created first. A nil IDMap is treated as an empty
+// one.
+// If anything cannot be re-created, the new Character is deleted along with
+// everything created for it and the error is returned.
+// RecreateFrom returns the new Character and the IDMap with the old and new
+// IDs of the Character and its entity added.
+func (cs *CharacterService) RecreateFrom(campID int, stored *Character, ids *IDMap, items ...*EntityInventory) (*Character, *IDMap, error) {
+	if stored == nil {
+		return nil, ids, fmt.Errorf("cannot recreate Character from nil copy")
+	}
+
+	if ids == nil {
+		ids = NewIDMap()
+	}
+
+	ch := stored.SimpleCharacter
+	ch.Image = ""
+	ch.FamilyID = ids.Object("family", ch.FamilyID)
+	ch.LocationID = ids.Object("location", ch.LocationID)
+	ch.RaceID = ids.Object("race", ch.RaceID)
+	ch.Tags = ids.objects("tag", ch.Tags)
+
+	var atrs []SimpleAttribute
+	for _, atr := range stored.Attributes.Data {
+		atrs = append(atrs, atr.SimpleAttribute)
+	}
+
+	var rels []SimpleRelation
+	for _, rel := range stored.Relations.Data {
+		r := rel.SimpleRelation
+		r.TargetID = ids.Entity(r.TargetID)
+		rels = append(rels, r)
+	}
+
+	var invs []SimpleEntityInventory
+	for _, inv := range items {
+		if inv != nil {
+			invs = append(invs, inv.SimpleEntityInventory)
+		}
+	}
+	if len(items) == 0 && stored.Inventory.ItemID != 0 {
+		inv := stored.Inventory
+		invs = append(invs, SimpleEntityInventory{
+			ItemID:     inv.ItemID,
+			Amount:     inv.Amount,
+			Position:   inv.Position,
+			Visibility: inv.Visibility,
+			IsPrivate:  inv.IsPrivate,
+		})
+	}
+
+	full, err := cs.CreateFull(campID, ch, atrs, rels)
 	if err != nil {
-		return fmt.Errorf("cannot delete Character (ID: %d) for Campaign (ID: %d): %w", charID, campID, err)
+		return nil, ids, fmt.Errorf("cannot recreate Character (ID: %d): %w", stored.ID, err)
 	}
+	char := full.Character
 
-	return nil
+	for _, item := range invs {
+		item.EntityID = char.EntityID
+		item.ItemID = ids.Object("item", item.ItemID)
+
+		if _, err := cs.client.EntityInventories.Create(campID, char.EntityID, item); err != nil {
+			if derr := cs.Delete(campID, char.ID); derr != nil {
+				return char, ids, fmt.Errorf("cannot recreate Character (ID: %d): %w (rollback failed: %v)", stored.ID, err, derr)
+			}
+
+			return nil, ids, fmt.Errorf("cannot recreate Character (ID: %d): %w", stored.ID, err)
+		}
+	}
+
+	ids.add("character", stored.ID, char.ID, stored.EntityID, char.EntityID)
+
+	return char, ids, nil
 }
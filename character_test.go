@@ -1,10 +1,15 @@
 package kanka
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -12,11 +17,13 @@ import (
 )
 
 const (
-	testFileEmpty       string = "test_data/empty.json"
-	testCharacterIndex  string = "test_data/character_index.json"
-	testCharacterGet    string = "test_data/character_get.json"
-	testCharacterCreate string = "test_data/character_create.json"
-	testCharacterUpdate string = "test_data/character_update.json"
+	testFileEmpty        string = "test_data/empty.json"
+	testCharacterIndex   string = "test_data/character_index.json"
+	testCharacterPartial string = "test_data/character_index_partial.json"
+	testCharacterGet     string = "test_data/character_get.json"
+	testCharacterCreate  string = "test_data/character_create.json"
+	testCharacterUpdate  string = "test_data/character_update.json"
+	testCharacterSearch  string = "test_data/character_search.json"
 )
 
 func testClient(status int, resp io.Reader) (*Client, *httptest.Server) {
@@ -75,6 +82,14 @@ func TestCharacterService_Index(t *testing.T) {
 			want:    chars,
 			wantErr: false,
 		},
+		{
+			name:    "Status OK, partially malformed response, valid args",
+			status:  http.StatusOK,
+			file:    testCharacterPartial,
+			args:    args{campID: 5272, sync: now},
+			want:    []*Character{chars[0], chars[2]},
+			wantErr: true,
+		},
 		{
 			name:    "Status OK, valid response, invalid args",
 			status:  http.StatusOK,
@@ -337,6 +352,92 @@ func TestCharacterService_Get(t *testing.T) {
 	}
 }
 
+func TestCharacterService_GetRaw(t *testing.T) {
+	type args struct {
+		campID int
+		charID int
+	}
+	tests := []struct {
+		name     string
+		status   int
+		file     string
+		args     args
+		wantName string
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, valid response, valid args",
+			status:   http.StatusOK,
+			file:     testCharacterGet,
+			args:     args{campID: 5272, charID: 116623},
+			wantName: "Penny Galvenrise",
+			wantErr:  false,
+		},
+		{
+			name:     "Status OK, valid response, invalid campID",
+			status:   http.StatusOK,
+			file:     testCharacterGet,
+			args:     args{campID: -123, charID: 116623},
+			wantName: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Status OK, valid response, invalid charID",
+			status:   http.StatusOK,
+			file:     testCharacterGet,
+			args:     args{campID: 5272, charID: -123},
+			wantName: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Status OK, empty response, valid args",
+			status:   http.StatusOK,
+			file:     testFileEmpty,
+			args:     args{campID: 5272, charID: 116623},
+			wantName: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusNotFound, valid args",
+			status:   http.StatusNotFound,
+			file:     testFileEmpty,
+			args:     args{campID: 5272, charID: 116623},
+			wantName: "",
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, raw, err := c.Characters.GetRaw(test.args.campID, test.args.charID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+
+			var fields map[string]interface{}
+			if err := json.Unmarshal(raw, &fields); err != nil {
+				t.Fatalf("cannot unmarshal raw data: %v", err)
+			}
+			if fields["name"] != test.wantName {
+				t.Errorf("got raw name: <%v>, want raw name: <%v>", fields["name"], test.wantName)
+			}
+			if got.Name != test.wantName {
+				t.Errorf("got name: <%v>, want name: <%v>", got.Name, test.wantName)
+			}
+		})
+	}
+}
+
 func TestCharacterService_Create(t *testing.T) {
 	char := SimpleCharacter{
 		Name:  "Eddard Stark",
@@ -448,6 +549,119 @@ func TestCharacterService_Create(t *testing.T) {
 	}
 }
 
+func TestCharacterService_CreateWithResult(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		location string
+		want     *WriteResult
+		wantErr  bool
+	}{
+		{
+			name:     "Status Created with Location",
+			status:   http.StatusCreated,
+			location: "https://kanka.io/api/1.0/campaigns/5272/characters/135277",
+			want: &WriteResult{
+				StatusCode: http.StatusCreated,
+				Location:   "https://kanka.io/api/1.0/campaigns/5272/characters/135277",
+			},
+			wantErr: false,
+		},
+		{
+			name:     "Status OK without Location",
+			status:   http.StatusOK,
+			location: "",
+			want:     &WriteResult{StatusCode: http.StatusOK},
+			wantErr:  false,
+		},
+		{
+			name:     "Status Unauthorized",
+			status:   http.StatusUnauthorized,
+			location: "",
+			want:     nil,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if test.location != "" {
+					w.Header().Set("Location", test.location)
+				}
+				w.WriteHeader(test.status)
+
+				b, err := ioutil.ReadFile(testCharacterCreate)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				w.Write(b)
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			ch, got, err := c.Characters.CreateWithResult(5272, SimpleCharacter{Name: "Jon Snow"})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+
+			if !test.wantErr && ch == nil {
+				t.Errorf("got Character: <nil>, want non-nil Character")
+			}
+		})
+	}
+}
+
+func TestCharacterService_UpdateWithResult(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		want    *WriteResult
+		wantErr bool
+	}{
+		{
+			name:    "Status OK",
+			status:  http.StatusOK,
+			want:    &WriteResult{StatusCode: http.StatusOK},
+			wantErr: false,
+		},
+		{
+			name:    "Status Unauthorized",
+			status:  http.StatusUnauthorized,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, testCharacterUpdate)
+			defer ts.Close()
+
+			ch, got, err := c.Characters.UpdateWithResult(5272, 135277, SimpleCharacter{Name: "Jon Snow"})
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if rec.method != http.MethodPut {
+				t.Errorf("got method: <%s>, want method: <%s>", rec.method, http.MethodPut)
+			}
+
+			if !test.wantErr && ch == nil {
+				t.Errorf("got Character: <nil>, want non-nil Character")
+			}
+		})
+	}
+}
+
 func TestCharacterService_Update(t *testing.T) {
 	char := SimpleCharacter{
 		Name:  "Stannis Baratheon",
@@ -568,6 +782,128 @@ func TestCharacterService_Update(t *testing.T) {
 	}
 }
 
+func TestCharacterService_Clear(t *testing.T) {
+	tests := []struct {
+		name     string
+		fields   []string
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "Single field",
+			fields:   []string{"entry"},
+			wantBody: `{"entry":null}`,
+			wantErr:  false,
+		},
+		{
+			name:     "Multiple fields",
+			fields:   []string{"entry", "title"},
+			wantBody: `{"entry":null,"title":null}`,
+			wantErr:  false,
+		},
+		{
+			name:     "No fields",
+			fields:   nil,
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Required field",
+			fields:   []string{"entry", "name"},
+			wantBody: "",
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, http.StatusOK, testCharacterUpdate)
+			defer ts.Close()
+
+			_, err := c.Characters.Clear(5272, 111, test.fields...)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			if test.wantErr {
+				return
+			}
+
+			if rec.method != http.MethodPatch {
+				t.Errorf("got method: <%s>, want method: <%s>", rec.method, http.MethodPatch)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+		})
+	}
+}
+
+func TestCharacterService_SetRelations(t *testing.T) {
+	fam, loc, none, neg := 1234, 5678, 0, -1
+
+	tests := []struct {
+		name       string
+		familyID   *int
+		locationID *int
+		wantBody   string
+		wantErr    bool
+	}{
+		{
+			name:       "Set family and location",
+			familyID:   &fam,
+			locationID: &loc,
+			wantBody:   `{"family_id":1234,"location_id":5678}`,
+			wantErr:    false,
+		},
+		{
+			name:       "Set location only",
+			familyID:   nil,
+			locationID: &loc,
+			wantBody:   `{"location_id":5678}`,
+			wantErr:    false,
+		},
+		{
+			name:       "Clear family, set location",
+			familyID:   &none,
+			locationID: &loc,
+			wantBody:   `{"family_id":null,"location_id":5678}`,
+			wantErr:    false,
+		},
+		{
+			name:       "No IDs",
+			familyID:   nil,
+			locationID: nil,
+			wantBody:   "",
+			wantErr:    true,
+		},
+		{
+			name:       "Negative ID",
+			familyID:   &neg,
+			locationID: &loc,
+			wantBody:   "",
+			wantErr:    true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, http.StatusOK, testCharacterUpdate)
+			defer ts.Close()
+
+			_, err := c.Characters.SetRelations(5272, 111, test.familyID, test.locationID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+			if !test.wantErr && rec.method != http.MethodPatch {
+				t.Errorf("got method: <%s>, want method: <%s>", rec.method, http.MethodPatch)
+			}
+		})
+	}
+}
+
 func TestCharacterService_Delete(t *testing.T) {
 	type args struct {
 		campID int
@@ -639,3 +975,996 @@ func TestCharacterService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestSimpleCharacter_MarshalJSON(t *testing.T) {
+	tr := true
+	fl := false
+	fx, fy, zero := 120.5, 48.0, 0.0
+
+	tests := []struct {
+		name    string
+		ch      SimpleCharacter
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Valid character, nil IsTemplate",
+			ch:      SimpleCharacter{Name: "Jon Snow"},
+			want:    `{"name":"Jon Snow"}`,
+			wantErr: false,
+		},
+		{
+			name:    "Valid character, true IsTemplate",
+			ch:      SimpleCharacter{Name: "Jon Snow", IsTemplate: &tr},
+			want:    `{"name":"Jon Snow","is_template":true}`,
+			wantErr: false,
+		},
+		{
+			name:    "Valid character, false IsTemplate",
+			ch:      SimpleCharacter{Name: "Jon Snow", IsTemplate: &fl},
+			want:    `{"name":"Jon Snow","is_template":false}`,
+			wantErr: false,
+		},
+		{
+			name:    "Valid character, hidden personality",
+			ch:      SimpleCharacter{Name: "Jon Snow", IsPersonalityVisible: &fl},
+			want:    `{"name":"Jon Snow","is_personality_visible":false}`,
+			wantErr: false,
+		},
+		{
+			name:    "Valid character, pronouns and pinned traits",
+			ch:      SimpleCharacter{Name: "Jon Snow", Pronouns: "he/him", IsPersonalityPinned: &tr, IsAppearancePinned: &fl},
+			want:    `{"name":"Jon Snow","pronouns":"he/him","is_personality_pinned":true,"is_appearance_pinned":false}`,
+			wantErr: false,
+		},
+		{
+			name:    "Valid character, image focus",
+			ch:      SimpleCharacter{Name: "Jon Snow", FocusX: &fx, FocusY: &fy},
+			want:    `{"name":"Jon Snow","focus_x":120.5,"focus_y":48}`,
+			wantErr: false,
+		},
+		{
+			name:    "Valid character, zero image focus",
+			ch:      SimpleCharacter{Name: "Jon Snow", FocusX: &zero, FocusY: &zero},
+			want:    `{"name":"Jon Snow","focus_x":0,"focus_y":0}`,
+			wantErr: false,
+		},
+		{
+			name:    "Missing name",
+			ch:      SimpleCharacter{IsTemplate: &tr},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := json.Marshal(test.ch)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if string(got) != test.want {
+				t.Errorf("got: <%s>, want: <%s>", got, test.want)
+			}
+		})
+	}
+}
+
+func TestCharacterService_GetByName(t *testing.T) {
+	search, err := ioutil.ReadFile(testCharacterSearch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ambiguous := `{"data":[{"id":1,"name":"Jon Snow","type":"character"},{"id":2,"name":"Jon Snow","type":"character"}]}`
+
+	tests := []struct {
+		name    string
+		search  string
+		query   string
+		wantGet string
+		wantErr error
+	}{
+		{
+			name:    "Exact match",
+			search:  string(search),
+			query:   "Penny Galvenrise",
+			wantGet: "/campaigns/5272/characters/116623",
+			wantErr: nil,
+		},
+		{
+			name:    "Case-insensitive match",
+			search:  string(search),
+			query:   "penny galvenrise the younger",
+			wantGet: "/campaigns/5272/characters/116624",
+			wantErr: nil,
+		},
+		{
+			name:    "No match",
+			search:  string(search),
+			query:   "Arya Stark",
+			wantGet: "",
+			wantErr: ErrNotFound,
+		},
+		{
+			name:    "Multiple matches",
+			search:  ambiguous,
+			query:   "Jon Snow",
+			wantGet: "",
+			wantErr: ErrAmbiguous,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var get string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if strings.Contains(r.URL.Path, "/search/") {
+					w.Write([]byte(test.search))
+					return
+				}
+
+				get = r.URL.Path
+				b, err := ioutil.ReadFile(testCharacterGet)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				w.Write(b)
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			ch, err := c.Characters.GetByName(5272, test.query)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("got err: <%v>, want err: <%v>", err, test.wantErr)
+			}
+			if get != test.wantGet {
+				t.Errorf("got request: <%s>, want request: <%s>", get, test.wantGet)
+			}
+			if test.wantErr == nil && ch == nil {
+				t.Errorf("got Character: <nil>, want non-nil Character")
+			}
+		})
+	}
+}
+
+func TestCharacterService_GetByNamePages(t *testing.T) {
+	var search []string
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/search/") {
+			b, err := ioutil.ReadFile(testCharacterGet)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			w.Write(b)
+			return
+		}
+
+		search = append(search, r.URL.Path)
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"data":[{"id":2,"name":"Who? 50%","type":"character"}],"links":{"next":null}}`))
+			return
+		}
+		fmt.Fprintf(w, `{"data":[{"id":1,"name":"Who? 50%% Less","type":"character"}],"links":{"next":"%s/campaigns/5272/search/Who%%3F%%2050%%25?page=2"}}`, ts.URL)
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	if _, err := c.Characters.GetByName(5272, "Who? 50%"); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"/campaigns/5272/search/Who? 50%", "/campaigns/5272/search/Who? 50%"}
+	if diff := cmp.Diff(search, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestCharacterService_CreateIfAbsent(t *testing.T) {
+	type args struct {
+		campID int
+		ch     SimpleCharacter
+	}
+	tests := []struct {
+		name        string
+		search      string
+		args        args
+		wantName    string
+		wantCreated bool
+		wantPosts   int
+		wantErr     bool
+	}{
+		{
+			name:        "Existing Character, valid args",
+			search:      testCharacterSearch,
+			args:        args{campID: 5272, ch: SimpleCharacter{Name: "Penny Galvenrise"}},
+			wantName:    "Penny Galvenrise",
+			wantCreated: false,
+			wantPosts:   0,
+			wantErr:     false,
+		},
+		{
+			name:        "Absent Character, valid args",
+			search:      testSearch,
+			args:        args{campID: 5272, ch: SimpleCharacter{Name: "Eddard Stark"}},
+			wantName:    "Eddard Stark",
+			wantCreated: true,
+			wantPosts:   1,
+			wantErr:     false,
+		},
+		{
+			name:        "Existing Character, invalid campID",
+			search:      testCharacterSearch,
+			args:        args{campID: -123, ch: SimpleCharacter{Name: "Penny Galvenrise"}},
+			wantName:    "",
+			wantCreated: false,
+			wantPosts:   0,
+			wantErr:     true,
+		},
+		{
+			name:        "Missing name, valid campID",
+			search:      testCharacterSearch,
+			args:        args{campID: 5272, ch: SimpleCharacter{}},
+			wantName:    "",
+			wantCreated: false,
+			wantPosts:   0,
+			wantErr:     true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var posts int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				file := testCharacterGet
+				switch {
+				case r.Method == "POST":
+					posts++
+					file = testCharacterCreate
+				case strings.Contains(r.URL.Path, "/search/"):
+					file = test.search
+				}
+
+				b, err := ioutil.ReadFile(file)
+				if err != nil {
+					t.Error(err)
+					return
+				}
+				w.Write(b)
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			got, created, err := c.Characters.CreateIfAbsent(test.args.campID, test.args.ch)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if posts != test.wantPosts {
+				t.Errorf("got posts: <%d>, want posts: <%d>", posts, test.wantPosts)
+			}
+			if test.wantErr {
+				return
+			}
+			if created != test.wantCreated {
+				t.Errorf("got created: <%t>, want created: <%t>", created, test.wantCreated)
+			}
+			if got.Name != test.wantName {
+				t.Errorf("got name: <%s>, want name: <%s>", got.Name, test.wantName)
+			}
+		})
+	}
+}
+
+func TestCharacterService_CreateIfAbsent_Pages(t *testing.T) {
+	var posts int
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == "POST":
+			posts++
+			w.Write([]byte(`{"data":{"id":3,"name":"Penny Galvenrise"}}`))
+		case !strings.Contains(r.URL.Path, "/search/"):
+			b, err := ioutil.ReadFile(testCharacterGet)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			w.Write(b)
+		case r.URL.Query().Get("page") == "2":
+			w.Write([]byte(`{"data":[{"id":2,"name":"penny galvenrise","type":"character"}],"links":{"next":null}}`))
+		default:
+			fmt.Fprintf(w, `{"data":[{"id":1,"name":"Penny Galvenrise","type":"location"}],"links":{"next":"%s/campaigns/5272/search/Penny%%20Galvenrise?page=2"}}`, ts.URL)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	_, created, err := c.Characters.CreateIfAbsent(5272, SimpleCharacter{Name: "Penny Galvenrise"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created || posts != 0 {
+		t.Errorf("got created: <%t>, posts: <%d>, want created: <false>, posts: <0>", created, posts)
+	}
+}
+
+func TestCharacterService_CreateFull(t *testing.T) {
+	tests := []struct {
+		name         string
+		failPath     string
+		failDelete   bool
+		wantCalls    []string
+		wantRelOwner int
+		wantFull     bool
+		wantErr      bool
+	}{
+		{
+			name:     "Every creation succeeds",
+			failPath: "",
+			wantCalls: []string{
+				"POST /campaigns/5272/characters",
+				"POST /campaigns/5272/entities/430214/attributes",
+				"POST /campaigns/5272/entities/430214/attributes",
+				"POST /campaigns/5272/entities/430214/relations",
+			},
+			wantRelOwner: 430214,
+			wantFull:     true,
+			wantErr:      false,
+		},
+		{
+			name:     "Character creation fails",
+			failPath: "/campaigns/5272/characters",
+			wantCalls: []string{
+				"POST /campaigns/5272/characters",
+			},
+			wantFull: false,
+			wantErr:  true,
+		},
+		{
+			name:     "Relation creation fails",
+			failPath: "/campaigns/5272/entities/430214/relations",
+			wantCalls: []string{
+				"POST /campaigns/5272/characters",
+				"POST /campaigns/5272/entities/430214/attributes",
+				"POST /campaigns/5272/entities/430214/attributes",
+				"POST /campaigns/5272/entities/430214/relations",
+				"DELETE /campaigns/5272/characters/111",
+			},
+			wantFull: false,
+			wantErr:  true,
+		},
+		{
+			name:       "Rollback fails",
+			failPath:   "/campaigns/5272/entities/430214/attributes",
+			failDelete: true,
+			wantCalls: []string{
+				"POST /campaigns/5272/characters",
+				"POST /campaigns/5272/entities/430214/attributes",
+				"DELETE /campaigns/5272/characters/111",
+			},
+			wantFull: true,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls []string
+			var owner int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+
+				if r.URL.Path == test.failPath || (r.Method == "DELETE" && test.failDelete) {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				switch {
+				case r.Method == "DELETE":
+				case strings.HasSuffix(r.URL.Path, "/characters"):
+					w.Write([]byte(`{"data":{"id":111,"entity_id":430214,"name":"Jon Snow"}}`))
+				case strings.HasSuffix(r.URL.Path, "/attributes"):
+					w.Write([]byte(`{"data":{"id":1,"name":"Age"}}`))
+				case strings.HasSuffix(r.URL.Path, "/relations"):
+					var rel SimpleRelation
+					if err := json.NewDecoder(r.Body).Decode(&rel); err != nil {
+						t.Error(err)
+					}
+					owner = rel.OwnerID
+					w.Write([]byte(`{"data":{"id":1,"relation":"Brother"}}`))
+				}
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			atrs := []SimpleAttribute{{Name: "Age", Value: "17"}, {Name: "Height", Value: "6'"}}
+			rels := []SimpleRelation{{Relation: "Brother", TargetID: 430215}}
+
+			full, err := c.Characters.CreateFull(5272, SimpleCharacter{Name: "Jon Snow"}, atrs, rels)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if (full != nil) != test.wantFull {
+				t.Errorf("got FullCharacter?: <%t>, want FullCharacter?: <%t>", (full != nil), test.wantFull)
+			}
+			if diff := cmp.Diff(calls, test.wantCalls); diff != "" {
+				t.Errorf(diff)
+			}
+			if owner != test.wantRelOwner {
+				t.Errorf("got relation owner: <%d>, want relation owner: <%d>", owner, test.wantRelOwner)
+			}
+			if !test.wantErr && (len(full.Attributes) != len(atrs) || len(full.Relations) != len(rels)) {
+				t.Errorf("got %d attributes and %d relations, want %d and %d", len(full.Attributes), len(full.Relations), len(atrs), len(rels))
+			}
+		})
+	}
+}
+
+func TestCharacterService_GetWithPosts(t *testing.T) {
+	tests := []struct {
+		name      string
+		failPath  string
+		wantPosts []string
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			name:      "Character and posts",
+			failPath:  "",
+			wantPosts: []string{"Memories", "Secrets"},
+			wantCalls: []string{
+				"/campaigns/5272/characters/111",
+				"/campaigns/5272/entities/430214/entity_notes",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Character failure",
+			failPath:  "/campaigns/5272/characters/111",
+			wantPosts: nil,
+			wantCalls: []string{"/campaigns/5272/characters/111"},
+			wantErr:   true,
+		},
+		{
+			name:      "Posts failure",
+			failPath:  "/campaigns/5272/entities/430214/entity_notes",
+			wantPosts: nil,
+			wantCalls: []string{
+				"/campaigns/5272/characters/111",
+				"/campaigns/5272/entities/430214/entity_notes",
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.URL.Path)
+
+				if r.URL.Path == test.failPath {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/characters/111"):
+					w.Write([]byte(`{"data":{"id":111,"entity_id":430214,"name":"Jon Snow"}}`))
+				case strings.HasSuffix(r.URL.Path, "/entity_notes"):
+					w.Write([]byte(`{"data":[{"id":1,"name":"Memories","entry":"<p>Winterfell</p>"},{"id":2,"name":"Secrets"}]}`))
+				}
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			full, err := c.Characters.GetWithPosts(5272, 111)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(calls, test.wantCalls); diff != "" {
+				t.Errorf(diff)
+			}
+
+			var got []string
+			if full != nil {
+				if full.Character.Name != "Jon Snow" {
+					t.Errorf("got Character name: <%s>, want Character name: <%s>", full.Character.Name, "Jon Snow")
+				}
+				for _, p := range full.Posts {
+					got = append(got, p.Name)
+				}
+			}
+			if diff := cmp.Diff(got, test.wantPosts); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestCharacterService_CreateInCampaigns(t *testing.T) {
+	char := SimpleCharacter{
+		Name:  "Eddard Stark",
+		Title: "Lord of Winterfell",
+	}
+
+	type args struct {
+		campIDs []int
+		ch      SimpleCharacter
+	}
+	tests := []struct {
+		name      string
+		status    int
+		args      args
+		wantChars []int
+		wantErrs  []int
+	}{
+		{
+			name:      "StatusOK, valid args",
+			status:    http.StatusOK,
+			args:      args{campIDs: []int{111, 222}, ch: char},
+			wantChars: []int{111, 222},
+			wantErrs:  nil,
+		},
+		{
+			name:      "StatusOK, some invalid campIDs",
+			status:    http.StatusOK,
+			args:      args{campIDs: []int{111, -123, 222}, ch: char},
+			wantChars: []int{111, 222},
+			wantErrs:  []int{-123},
+		},
+		{
+			name:      "StatusOK, invalid character",
+			status:    http.StatusOK,
+			args:      args{campIDs: []int{111, 222}, ch: SimpleCharacter{}},
+			wantChars: nil,
+			wantErrs:  []int{111, 222},
+		},
+		{
+			name:      "StatusOK, no campIDs",
+			status:    http.StatusOK,
+			args:      args{campIDs: nil, ch: char},
+			wantChars: nil,
+			wantErrs:  nil,
+		},
+		{
+			name:      "StatusForbidden, valid args",
+			status:    http.StatusForbidden,
+			args:      args{campIDs: []int{111, 222}, ch: char},
+			wantChars: nil,
+			wantErrs:  []int{111, 222},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, _ := testMethodClient(t, test.status, map[string]string{"POST": testCharacterCreate})
+			defer ts.Close()
+
+			chars, errs := c.Characters.CreateInCampaigns(test.args.campIDs, test.args.ch)
+
+			var gotChars []int
+			for _, id := range test.args.campIDs {
+				if ch, ok := chars[id]; ok {
+					if ch.Name != test.args.ch.Name {
+						t.Errorf("got name: <%s>, want name: <%s>", ch.Name, test.args.ch.Name)
+					}
+					gotChars = append(gotChars, id)
+				}
+			}
+			var gotErrs []int
+			for _, id := range test.args.campIDs {
+				if _, ok := errs[id]; ok {
+					gotErrs = append(gotErrs, id)
+				}
+			}
+
+			if diff := cmp.Diff(gotChars, test.wantChars); diff != "" {
+				t.Errorf("created mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(gotErrs, test.wantErrs); diff != "" {
+				t.Errorf("error mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestCharacterService_RecreateFrom(t *testing.T) {
+	stored := &Character{
+		SimpleCharacter: SimpleCharacter{
+			Name:     "Jon Snow",
+			Image:    "characters/jon.png",
+			FamilyID: 7,
+			RaceID:   8,
+			Tags:     []int{3, 4},
+		},
+		ID:        99,
+		EntityID:  9900,
+		Relations: Relations{Data: []Relation{{SimpleRelation: SimpleRelation{Relation: "Brother", OwnerID: 9900, TargetID: 5500}}}},
+		Inventory: Inventory{ItemID: 12, Amount: 1, Position: "Equipped"},
+	}
+
+	tests := []struct {
+		name      string
+		failPath  string
+		wantCalls []string
+		wantChar  bool
+		wantErr   bool
+	}{
+		{
+			name:     "Every creation succeeds",
+			failPath: "",
+			wantCalls: []string{
+				"POST /campaigns/5272/characters",
+				"POST /campaigns/5272/entities/430214/relations",
+				"POST /campaigns/5272/entities/430214/inventory",
+			},
+			wantChar: true,
+			wantErr:  false,
+		},
+		{
+			name:     "Inventory creation fails",
+			failPath: "/campaigns/5272/entities/430214/inventory",
+			wantCalls: []string{
+				"POST /campaigns/5272/characters",
+				"POST /campaigns/5272/entities/430214/relations",
+				"POST /campaigns/5272/entities/430214/inventory",
+				"DELETE /campaigns/5272/characters/111",
+			},
+			wantChar: false,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls []string
+			bodies := make(map[string]string)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+				b, _ := io.ReadAll(r.Body)
+				bodies[r.URL.Path] = string(b)
+
+				if r.URL.Path == test.failPath {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				switch {
+				case r.Method == "DELETE":
+				case strings.HasSuffix(r.URL.Path, "/characters"):
+					w.Write([]byte(`{"data":{"id":111,"entity_id":430214,"name":"Jon Snow"}}`))
+				case strings.HasSuffix(r.URL.Path, "/relations"):
+					w.Write([]byte(`{"data":{"id":1,"relation":"Brother"}}`))
+				case strings.HasSuffix(r.URL.Path, "/inventory"):
+					w.Write([]byte(`{"data":{"id":1,"item_id":21}}`))
+				}
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			ids := NewIDMap()
+			ids.add("family", 7, 70, 700, 7000)
+			ids.add("tag", 3, 30, 300, 3000)
+			ids.add("item", 12, 21, 1200, 2100)
+			ids.add("location", 55, 56, 5500, 5600)
+
+			char, got, err := c.Characters.RecreateFrom(5272, stored, ids)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			if diff := cmp.Diff(calls, test.wantCalls); diff != "" {
+				t.Errorf(diff)
+			}
+
+			if (char != nil) != test.wantChar {
+				t.Errorf("got Character?: <%t>, want Character?: <%t>", (char != nil), test.wantChar)
+			}
+
+			wantBody := `{"name":"Jon Snow","family_id":70,"race_id":8,"tags":[30,4]}`
+			if body := bodies["/campaigns/5272/characters"]; body != wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", body, wantBody)
+			}
+
+			var rel SimpleRelation
+			if err := json.Unmarshal([]byte(bodies["/campaigns/5272/entities/430214/relations"]), &rel); err != nil {
+				t.Fatal(err)
+			}
+			if rel.OwnerID != 430214 || rel.TargetID != 5600 {
+				t.Errorf("got relation owner and target: <%d, %d>, want: <430214, 5600>", rel.OwnerID, rel.TargetID)
+			}
+
+			var inv SimpleEntityInventory
+			if err := json.Unmarshal([]byte(bodies["/campaigns/5272/entities/430214/inventory"]), &inv); err != nil {
+				t.Fatal(err)
+			}
+			if inv.ItemID != 21 {
+				t.Errorf("got inventory item: <%d>, want item: <21>", inv.ItemID)
+			}
+
+			if test.wantErr {
+				return
+			}
+
+			if got.Object("character", 99) != 111 || got.Entity(9900) != 430214 {
+				t.Errorf("got Character ID and Entity ID: <%d, %d>, want: <111, 430214>", got.Object("character", 99), got.Entity(9900))
+			}
+		})
+	}
+}
+
+func TestCharacterService_RecreateFromItems(t *testing.T) {
+	stored := &Character{
+		SimpleCharacter: SimpleCharacter{Name: "Jon Snow"},
+		ID:              99,
+		EntityID:        9900,
+		Inventory:       Inventory{ItemID: 12, Amount: 1},
+	}
+	items := []*EntityInventory{
+		{SimpleEntityInventory: SimpleEntityInventory{EntityID: 9900, ItemID: 12, Amount: 1, Position: "Equipped"}},
+		{SimpleEntityInventory: SimpleEntityInventory{EntityID: 9900, ItemID: 13, Amount: 3, Position: "Backpack"}},
+	}
+
+	var bodies []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/characters"):
+			w.Write([]byte(`{"data":{"id":111,"entity_id":430214,"name":"Jon Snow"}}`))
+		case strings.HasSuffix(r.URL.Path, "/inventory"):
+			b, _ := io.ReadAll(r.Body)
+			bodies = append(bodies, string(b))
+			w.Write([]byte(`{"data":{"id":1,"item_id":21}}`))
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	ids := NewIDMap()
+	ids.add("item", 12, 21, 1200, 2100)
+
+	if _, _, err := c.Characters.RecreateFrom(5272, stored, ids, items...); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`{"entity_id":430214,"item_id":21,"amount":1,"position":"Equipped"}`,
+		`{"entity_id":430214,"item_id":13,"amount":3,"position":"Backpack"}`,
+	}
+	if diff := cmp.Diff(bodies, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestCharacterService_RecreateFrom_nil(t *testing.T) {
+	c := NewClient(testToken, nil)
+
+	if _, _, err := c.Characters.RecreateFrom(5272, nil, nil); err == nil {
+		t.Errorf("got err?: <false>, want err?: <true>")
+	}
+}
+
+func TestCharacter_Simple(t *testing.T) {
+	f, err := os.Open(testCharacterGet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var wrap struct {
+		Data *Character `json:"data"`
+	}
+	if err := json.NewDecoder(f).Decode(&wrap); err != nil {
+		t.Fatal(err)
+	}
+	ch := wrap.Data
+	ch.Image = "characters/jon.png"
+	ch.Tags = []int{1, 2}
+
+	s := ch.Simple()
+	if s.Image != "" {
+		t.Errorf("got Image: <%s>, want no Image", s.Image)
+	}
+
+	want := ch.SimpleCharacter
+	want.Image = ""
+	if diff := cmp.Diff(s, want); diff != "" {
+		t.Errorf(diff)
+	}
+
+	s.Tags[0] = 3
+	if ch.Tags[0] != 1 {
+		t.Errorf("got Character Tags: <%v>, want Tags unchanged by edit of Simple", ch.Tags)
+	}
+
+	c, ts, rec := testRecordClient(t, http.StatusOK, testCharacterUpdate)
+	defer ts.Close()
+
+	s.Title = "King in the North"
+	if _, err := c.Characters.Update(5272, ch.ID, s); err != nil {
+		t.Fatal(err)
+	}
+
+	var sent SimpleCharacter
+	if err := json.Unmarshal([]byte(rec.body), &sent); err != nil {
+		t.Fatal(err)
+	}
+	want = s
+	if diff := cmp.Diff(sent, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestSortTraitsBySection(t *testing.T) {
+	traits := []*Trait{
+		{ID: 4, Name: "Eyes", Section: TraitAppearance, DefaultOrder: 1},
+		{ID: 3, Name: "Goals", Section: TraitPersonality, DefaultOrder: 1},
+		{ID: 2, Name: "Hair", Section: TraitAppearance, DefaultOrder: 0},
+		{ID: 1, Name: "Fears", Section: TraitPersonality, DefaultOrder: 1},
+		{ID: 5, Name: "Traits", Section: TraitPersonality, DefaultOrder: 0},
+	}
+
+	want := map[string][]string{
+		TraitPersonality: {"Traits", "Fears", "Goals"},
+		TraitAppearance:  {"Hair", "Eyes"},
+	}
+
+	got := make(map[string][]string)
+	for section, list := range SortTraitsBySection(traits) {
+		for _, t := range list {
+			got[section] = append(got[section], t.Name)
+		}
+	}
+
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestCharacterService_ReorderTraits(t *testing.T) {
+	tests := []struct {
+		name     string
+		section  string
+		ids      []int
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "Reordered appearance",
+			section:  TraitAppearance,
+			ids:      []int{85289, 85287, 85288},
+			wantBody: `"appearance_entry":["Fair","Pinks","Green"],"appearance_name":["Skin","Hair","Eyes"]`,
+			wantErr:  false,
+		},
+		{
+			name:     "Missing trait",
+			section:  TraitAppearance,
+			ids:      []int{85289, 85287},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Repeated trait",
+			section:  TraitAppearance,
+			ids:      []int{85289, 85287, 85287},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Trait of other section",
+			section:  TraitAppearance,
+			ids:      []int{85289, 85287, 85283},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Unknown section",
+			section:  "history",
+			ids:      nil,
+			wantBody: "",
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, http.StatusOK, testCharacterGet)
+			defer ts.Close()
+
+			_, err := c.Characters.ReorderTraits(5272, 111, test.section, test.ids)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if test.wantErr {
+				if rec.method == http.MethodPut {
+					t.Errorf("got update request, want none")
+				}
+				return
+			}
+
+			if rec.method != http.MethodPut || !strings.Contains(rec.body, test.wantBody) {
+				t.Errorf("got request: <%s %s>, want PUT with body containing: <%s>", rec.method, rec.body, test.wantBody)
+			}
+			if strings.Contains(rec.body, "personality_name") {
+				t.Errorf("got body: <%s>, want personality traits untouched", rec.body)
+			}
+		})
+	}
+}
+
+func TestCharacterService_IndexFiltered(t *testing.T) {
+	yes, no := true, false
+
+	tests := []struct {
+		name      string
+		filter    CharacterFilter
+		wantQuery string
+		wantNames []string
+	}{
+		{
+			name:      "Living public NPCs",
+			filter:    CharacterFilter{IsDead: &no, IsPrivate: &no, Type: "NPC"},
+			wantQuery: "is_dead=0&is_private=0&related=1&type=NPC",
+			wantNames: []string{"Penny"},
+		},
+		{
+			name:      "Dead",
+			filter:    CharacterFilter{IsDead: &yes},
+			wantQuery: "is_dead=1&related=1",
+			wantNames: []string{"Ned"},
+		},
+		{
+			name:      "Unfiltered",
+			filter:    CharacterFilter{},
+			wantQuery: "related=1",
+			wantNames: []string{"Penny", "Ned", "Arya", "Varys"},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var query string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				query = r.URL.Query().Encode()
+
+				// The filter is ignored so that the results must be filtered
+				// again by the client.
+				w.Write([]byte(`{"data":[
+					{"id":1,"name":"Penny","type":"NPC","is_dead":false,"is_private":false},
+					{"id":2,"name":"Ned","type":"NPC","is_dead":true,"is_private":false},
+					{"id":3,"name":"Arya","type":"PC","is_dead":false,"is_private":false},
+					{"id":4,"name":"Varys","type":"NPC","is_dead":false,"is_private":true}
+				],"links":{"next":null}}`))
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			chars, err := c.Characters.IndexFiltered(5272, test.filter, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if query != test.wantQuery {
+				t.Errorf("got query: <%s>, want query: <%s>", query, test.wantQuery)
+			}
+
+			var names []string
+			for _, ch := range chars {
+				names = append(names, ch.Name)
+			}
+			if diff := cmp.Diff(names, test.wantNames); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
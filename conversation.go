@@ -0,0 +1,274 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Conversation contains information about a specific conversation.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/conversations
+type Conversation struct {
+	SimpleConversation
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
+
+	Attributes   Attributes   `json:"attributes"`
+	EntityEvents EntityEvents `json:"entity_events"`
+	EntityFiles  EntityFiles  `json:"entity_files"`
+	EntityNotes  EntityNotes  `json:"entity_notes"`
+	Relations    Relations    `json:"relations"`
+	Inventory    Inventory    `json:"inventory"`
+}
+
+// SimpleConversation contains only the simple information about a conversation.
+// SimpleConversation is primarily used to create new conversations for posting to Kanka.
+type SimpleConversation struct {
+	Name           string   `json:"name"`
+	Type           string   `json:"type,omitempty"`
+	Target         string   `json:"target,omitempty"`
+	Tags           []int    `json:"tags,omitempty"`
+	IsPrivate      bool     `json:"is_private,omitempty"`
+	IsClosed       bool     `json:"is_closed,omitempty"`
+	IsTemplate     *bool    `json:"is_template,omitempty"`
+	Image          string   `json:"image,omitempty"`
+	ImageURL       string   `json:"image_url,omitempty"`
+	ImageUUID      string   `json:"image_uuid,omitempty"`
+	FocusX         *float64 `json:"focus_x,omitempty"`
+	FocusY         *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL string   `json:"header_image_url,omitempty"`
+	HeaderUUID     string   `json:"entity_header_uuid,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
+}
+
+// Available conversation targets. The target of a conversation determines
+// whether its participants are campaign members or characters.
+const (
+	ConversationTargetMembers    string = "members"
+	ConversationTargetCharacters string = "characters"
+)
+
+// MarshalJSON marshals the SimpleConversation into its JSON-encoded form if it
+// has the required populated fields.
+func (sc SimpleConversation) MarshalJSON() ([]byte, error) {
+	if err := requireField("SimpleConversation", "Name", sc.Name); err != nil {
+		return nil, err
+	}
+
+	type alias SimpleConversation
+	return json.Marshal(alias(sc))
+}
+
+// Simple returns a copy of the SimpleConversation data of the Conversation,
+// ready to be modified and passed to Update. The path of the current image is
+// left out and the slices of the copy do not share memory with the
+// Conversation.
+func (c *Conversation) Simple() SimpleConversation {
+	s := c.SimpleConversation
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+
+	return s
+}
+
+// ConversationService handles communication with the Conversation endpoint.
+type ConversationService service
+
+// base returns the baseService implementing the common operations of the
+// ConversationService.
+func (cs *ConversationService) base() baseService[Conversation, SimpleConversation] {
+	return baseService[Conversation, SimpleConversation]{
+		service: (*service)(cs),
+		kind:    "Conversation",
+		entity:  "conversation",
+		label:   func(conv SimpleConversation) string { return conv.Name },
+	}
+}
+
+// Index returns the list of all Conversations in the Campaign associated with campID.
+// If a non-nil time is provided, Index will only return Conversations that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Conversations that were decoded.
+func (cs *ConversationService) Index(campID int, sync *time.Time) ([]*Conversation, error) {
+	return cs.base().Index(campID, sync)
+}
+
+// IndexAll returns the list of all Conversations in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Conversations that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Conversations that were decoded.
+func (cs *ConversationService) IndexAll(campID int, sync *time.Time) ([]*Conversation, error) {
+	return cs.base().IndexAll(campID, sync)
+}
+
+// GetMany returns the Conversations associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Conversations, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Conversations that were
+// found.
+func (cs *ConversationService) GetMany(campID int, ids []int) (map[int]*Conversation, error) {
+	return cs.base().GetMany(campID, ids)
+}
+
+// Count returns the number of Conversations in the Campaign associated with campID
+// without retrieving them.
+func (cs *ConversationService) Count(campID int) (int, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(cs.end)
+
+	n, err := cs.client.count(end)
+	if err != nil {
+		return 0, fmt.Errorf("cannot count Conversations in Campaign (ID: %d): %w", campID, err)
+	}
+
+	return n, nil
+}
+
+// Get returns the Conversation associated with convID from the Campaign
+// associated with campID.
+func (cs *ConversationService) Get(campID int, convID int) (*Conversation, error) {
+	return cs.base().Get(campID, convID)
+}
+
+// GetByName returns the Conversation with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Conversation has the name and ErrAmbiguous if more than one does.
+func (cs *ConversationService) GetByName(campID int, name string) (*Conversation, error) {
+	return cs.base().GetByName(campID, name)
+}
+
+// Create creates a new Conversation in the Campaign associated with campID using
+// the provided SimpleConversation data.
+// Create returns the newly created Conversation.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (cs *ConversationService) Create(campID int, conv SimpleConversation, opts ...CreateOption) (*Conversation, error) {
+	return cs.base().Create(campID, conv, opts...)
+}
+
+// CreateWithResult creates a new Conversation in the Campaign associated with campID
+// using the provided SimpleConversation data.
+// CreateWithResult returns the newly created Conversation along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (cs *ConversationService) CreateWithResult(campID int, conv SimpleConversation, opts ...CreateOption) (*Conversation, *WriteResult, error) {
+	return cs.base().CreateWithResult(campID, conv, opts...)
+}
+
+// Update updates an existing Conversation associated with convID from the
+// Campaign associated with campID using the provided SimpleConversation data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Conversation returned by Kanka, provide its SimpleConversation.
+// Update returns the newly updated Conversation.
+func (cs *ConversationService) Update(campID int, convID int, conv SimpleConversation) (*Conversation, error) {
+	return cs.base().Update(campID, convID, conv)
+}
+
+// UpdateWithResult updates an existing Conversation associated with convID from the
+// Campaign associated with campID using the provided SimpleConversation data, as
+// Update does.
+// UpdateWithResult returns the newly updated Conversation along with the metadata of
+// Kanka's response, such as its status code.
+func (cs *ConversationService) UpdateWithResult(campID int, convID int, conv SimpleConversation) (*Conversation, *WriteResult, error) {
+	return cs.base().UpdateWithResult(campID, convID, conv)
+}
+
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Conversation associated with convID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Conversation is left unchanged.
+// Clear returns the newly updated Conversation.
+func (cs *ConversationService) Clear(campID int, convID int, fields ...string) (*Conversation, error) {
+	return cs.base().Clear(campID, convID, fields...)
+}
+
+// SetHeader sets the header image of an existing Conversation associated with convID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Conversation on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Conversation.
+func (cs *ConversationService) SetHeader(campID int, convID int, uuid string) (*Conversation, error) {
+	return cs.base().SetHeader(campID, convID, uuid)
+}
+
+// SetHeaderURL sets the header image of an existing Conversation associated with
+// convID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Conversation.
+func (cs *ConversationService) SetHeaderURL(campID int, convID int, url string) (*Conversation, error) {
+	return cs.base().SetHeaderURL(campID, convID, url)
+}
+
+// Delete deletes an existing Conversation associated with convID from the
+// Campaign associated with campID.
+func (cs *ConversationService) Delete(campID int, convID int) error {
+	return cs.base().Delete(campID, convID)
+}
+
+// Close closes the Conversation associated with convID from the Campaign
+// associated with campID so that no new messages can be posted to it.
+// Returns the updated Conversation if successful.
+func (cs *ConversationService) Close(campID int, convID int) (*Conversation, error) {
+	return cs.setClosed(campID, convID, true)
+}
+
+// Reopen reopens the Conversation associated with convID from the Campaign
+// associated with campID. Returns the updated Conversation if successful.
+func (cs *ConversationService) Reopen(campID int, convID int) (*Conversation, error) {
+	return cs.setClosed(campID, convID, false)
+}
+
+// setClosed partially updates the closed state of the Conversation associated
+// with convID. Only the closed field is sent so that a false value is not
+// dropped by SimpleConversation's omitempty tags.
+func (cs *ConversationService) setClosed(campID int, convID int, closed bool) (*Conversation, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(cs.end)
+
+	end, err = end.id(convID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Conversation ID: %w", err)
+	}
+
+	b, err := json.Marshal(map[string]bool{"is_closed": closed})
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal closed state of Conversation (ID: %d): %w", convID, err)
+	}
+
+	var wrap response[*Conversation]
+
+	err = cs.client.patch(end, bytes.NewReader(b), &wrap)
+	if err != nil {
+		return nil, fmt.Errorf("cannot set closed state of Conversation (ID: %d) for Campaign (ID: %d): %w", convID, campID, err)
+	}
+
+	return wrap.Data, nil
+}
@@ -0,0 +1,646 @@
+package kanka
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testConversationIndex  string = "test_data/conversation_index.json"
+	testConversationGet    string = "test_data/conversation_get.json"
+	testConversationCreate string = "test_data/conversation_create.json"
+	testConversationUpdate string = "test_data/conversation_update.json"
+)
+
+func TestConversationService_Index(t *testing.T) {
+	convs := []*Conversation{
+		{
+			SimpleConversation: SimpleConversation{
+				Name: "Council of Waterdeep",
+				Type: "Meeting",
+			},
+		},
+		{
+			SimpleConversation: SimpleConversation{
+				Name: "Thieves Cant",
+				Type: "Chat",
+			},
+		},
+		{
+			SimpleConversation: SimpleConversation{
+				Name: "The Long Night",
+				Type: "Letter",
+			},
+		},
+	}
+	n := time.Now()
+	now := &n
+
+	type args struct {
+		campID int
+		sync   *time.Time
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*Conversation
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testConversationIndex,
+			args:    args{campID: 5272, sync: now},
+			want:    convs,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testConversationIndex,
+			args:    args{campID: -123, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Conversations.Index(test.args.campID, test.args.sync)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConversationService_Get(t *testing.T) {
+	conv := &Conversation{
+		SimpleConversation: SimpleConversation{
+			Name:      "At The Magic Shop",
+			Type:      "In Person",
+			Target:    ConversationTargetCharacters,
+			Image:     "conversations/GmN8ljHwAbz12yT1cVzRN6fGXauhHLHUAVHHcyPQ.jpeg",
+			IsPrivate: false,
+			IsClosed:  true,
+			Tags:      []int{3742},
+		},
+		ID:             912,
+		ImageFull:      "https://kanka-user-assets.s3.eu-central-1.amazonaws.com/conversations/GmN8ljHwAbz12yT1cVzRN6fGXauhHLHUAVHHcyPQ.jpeg",
+		ImageThumb:     "https://kanka-user-assets.s3.eu-central-1.amazonaws.com/conversations/GmN8ljHwAbz12yT1cVzRN6fGXauhHLHUAVHHcyPQ_thumb.jpeg",
+		HasCustomImage: true,
+		EntityID:       443499,
+		CreatedBy:      5600,
+		UpdatedBy:      5600,
+	}
+
+	type args struct {
+		campID int
+		convID int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *Conversation
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testConversationGet,
+			args:    args{campID: 5272, convID: 2142},
+			want:    conv,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testConversationGet,
+			args:    args{campID: -123, convID: 2142},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid convID",
+			status:  http.StatusOK,
+			file:    testConversationGet,
+			args:    args{campID: 5272, convID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testConversationGet,
+			args:    args{campID: -123, convID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, convID: 2142},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, convID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, convID: 2142},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, convID: 2142},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, convID: 2142},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Conversations.Get(test.args.campID, test.args.convID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConversationService_Create(t *testing.T) {
+	conv := SimpleConversation{
+		Name: "Midnight Parley",
+		Type: "Meeting",
+	}
+	type args struct {
+		campID int
+		conv   SimpleConversation
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *Conversation
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testConversationCreate,
+			args:    args{campID: 5272, conv: conv},
+			want:    &Conversation{SimpleConversation: conv},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testConversationCreate,
+			args:    args{campID: -123, conv: conv},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid conversation",
+			status:  http.StatusOK,
+			file:    testConversationCreate,
+			args:    args{campID: 5272, conv: SimpleConversation{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testConversationCreate,
+			args:    args{campID: -123, conv: SimpleConversation{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, conv: conv},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, conv: SimpleConversation{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, conv: conv},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, conv: conv},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, conv: conv},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Conversations.Create(test.args.campID, test.args.conv)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConversationService_Update(t *testing.T) {
+	conv := SimpleConversation{
+		Name: "Dock Ward Rumors",
+		Type: "Chat",
+	}
+	type args struct {
+		campID int
+		convID int
+		conv   SimpleConversation
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *Conversation
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testConversationUpdate,
+			args:    args{campID: 5272, convID: 111, conv: conv},
+			want:    &Conversation{SimpleConversation: conv, ID: 111},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testConversationUpdate,
+			args:    args{campID: -123, convID: 111, conv: conv},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid convID",
+			status:  http.StatusOK,
+			file:    testConversationUpdate,
+			args:    args{campID: 5272, convID: -123, conv: conv},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid conversation",
+			status:  http.StatusOK,
+			file:    testConversationUpdate,
+			args:    args{campID: 5272, convID: 111, conv: SimpleConversation{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testConversationUpdate,
+			args:    args{campID: -123, convID: -123, conv: SimpleConversation{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, convID: 111, conv: conv},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, convID: -123, conv: SimpleConversation{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, convID: 111, conv: conv},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, convID: 111, conv: conv},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, convID: 111, conv: conv},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Conversations.Update(test.args.campID, test.args.convID, test.args.conv)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConversationService_Delete(t *testing.T) {
+	type args struct {
+		campID int
+		convID int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid args",
+			status:  http.StatusOK,
+			args:    args{campID: 5272, convID: 111},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, invalid campID",
+			status:  http.StatusOK,
+			args:    args{campID: -123, convID: 111},
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, invalid convID",
+			status:  http.StatusOK,
+			args:    args{campID: 5272, convID: -123},
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, invalid args",
+			status:  http.StatusOK,
+			args:    args{campID: -123, convID: -123},
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			args:    args{campID: 5272, convID: 111},
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			args:    args{campID: 5272, convID: 111},
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			args:    args{campID: 5272, convID: 111},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(testFileEmpty)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			err = c.Conversations.Delete(test.args.campID, test.args.convID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestConversationService_Close(t *testing.T) {
+	type args struct {
+		campID int
+		convID int
+		close  bool
+	}
+	tests := []struct {
+		name     string
+		status   int
+		file     string
+		args     args
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, valid response, close",
+			status:   http.StatusOK,
+			file:     testConversationUpdate,
+			args:     args{campID: 5272, convID: 111, close: true},
+			wantBody: `{"is_closed":true}`,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, valid response, reopen",
+			status:   http.StatusOK,
+			file:     testConversationUpdate,
+			args:     args{campID: 5272, convID: 111, close: false},
+			wantBody: `{"is_closed":false}`,
+			wantErr:  false,
+		},
+		{
+			name:     "Status OK, valid response, invalid campID",
+			status:   http.StatusOK,
+			file:     testConversationUpdate,
+			args:     args{campID: -123, convID: 111, close: true},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Status OK, valid response, invalid convID",
+			status:   http.StatusOK,
+			file:     testConversationUpdate,
+			args:     args{campID: 5272, convID: -123, close: true},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusNotFound, valid args",
+			status:   http.StatusNotFound,
+			file:     testFileEmpty,
+			args:     args{campID: 5272, convID: 111, close: false},
+			wantBody: `{"is_closed":false}`,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, test.file)
+			defer ts.Close()
+
+			var got *Conversation
+			var err error
+			if test.args.close {
+				got, err = c.Conversations.Close(test.args.campID, test.args.convID)
+			} else {
+				got, err = c.Conversations.Reopen(test.args.campID, test.args.convID)
+			}
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+			if test.wantErr {
+				return
+			}
+			if rec.method != "PATCH" {
+				t.Errorf("got method: <%s>, want method: <%s>", rec.method, "PATCH")
+			}
+			if got == nil || got.ID != 111 {
+				t.Errorf("got: <%v>, want Conversation with ID 111", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// ConversationParticipant contains information about a specific conversation
+// participant. A participant is either a character or a campaign member,
+// depending on the target of the conversation.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/conversations#participants
+type ConversationParticipant struct {
+	SimpleConversationParticipant
+	ID        int       `json:"id"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy int       `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy int       `json:"updated_by"`
+}
+
+// SimpleConversationParticipant contains only the simple information about a
+// conversation participant. Exactly one of CharacterID and UserID must be set.
+// SimpleConversationParticipant is primarily used to create new conversation
+// participants for posting to Kanka.
+type SimpleConversationParticipant struct {
+	ConversationID int `json:"conversation_id"`
+	CharacterID    int `json:"character_id,omitempty"`
+	UserID         int `json:"user_id,omitempty"`
+}
+
+// IsCharacter returns true if the participant is a character rather than a
+// campaign member.
+func (sp SimpleConversationParticipant) IsCharacter() bool {
+	return sp.CharacterID != 0
+}
+
+// MarshalJSON marshals the SimpleConversationParticipant into its JSON-encoded
+// form if exactly one of its CharacterID and UserID is populated.
+func (sp SimpleConversationParticipant) MarshalJSON() ([]byte, error) {
+	if (sp.CharacterID == 0) == (sp.UserID == 0) {
+		return nil, fmt.Errorf("cannot marshal SimpleConversationParticipant into JSON without exactly one of CharacterID and UserID")
+	}
+
+	type alias SimpleConversationParticipant
+	return json.Marshal(alias(sp))
+}
+
+// ConversationParticipantService handles communication with the
+// ConversationParticipant endpoint.
+type ConversationParticipantService service
+
+// Index returns the list of all ConversationParticipants for the conversation
+// associated with convID in the Campaign associated with campID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the ConversationParticipants that were decoded.
+func (cs *ConversationParticipantService) Index(campID int, convID int) ([]*ConversationParticipant, error) {
+	end, err := cs.endpoint(campID, convID)
+	if err != nil {
+		return nil, err
+	}
+
+	var wrap response[[]json.RawMessage]
+
+	if err = cs.client.get(end, &wrap); err != nil {
+		return nil, fmt.Errorf("cannot get ConversationParticipant Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	var list []*ConversationParticipant
+	if err = decodeList(wrap.Data, &list, cs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode ConversationParticipant Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// AddCharacter adds the Character associated with charID as a participant of
+// the conversation associated with convID in the Campaign associated with
+// campID. The conversation's target must be ConversationTargetCharacters.
+// AddCharacter returns the newly created ConversationParticipant.
+func (cs *ConversationParticipantService) AddCharacter(campID int, convID int, charID int) (*ConversationParticipant, error) {
+	return cs.create(campID, convID, SimpleConversationParticipant{ConversationID: convID, CharacterID: charID})
+}
+
+// AddUser adds the campaign member associated with userID as a participant of
+// the conversation associated with convID in the Campaign associated with
+// campID. The conversation's target must be ConversationTargetMembers.
+// AddUser returns the newly created ConversationParticipant.
+func (cs *ConversationParticipantService) AddUser(campID int, convID int, userID int) (*ConversationParticipant, error) {
+	return cs.create(campID, convID, SimpleConversationParticipant{ConversationID: convID, UserID: userID})
+}
+
+// create creates a new ConversationParticipant for the conversation
+// associated with convID using the provided SimpleConversationParticipant.
+func (cs *ConversationParticipantService) create(campID int, convID int, par SimpleConversationParticipant) (*ConversationParticipant, error) {
+	end, err := cs.endpoint(campID, convID)
+	if err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(par)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal SimpleConversationParticipant: %w", err)
+	}
+
+	var wrap response[*ConversationParticipant]
+
+	if err = cs.client.post(end, bytes.NewReader(b), &wrap); err != nil {
+		return nil, fmt.Errorf("cannot create ConversationParticipant for Campaign (ID: %d): %w", campID, err)
+	}
+
+	return wrap.Data, nil
+}
+
+// Delete removes the ConversationParticipant associated with parID from the
+// conversation associated with convID in the Campaign associated with campID.
+func (cs *ConversationParticipantService) Delete(campID int, convID int, parID int) error {
+	end, err := cs.endpoint(campID, convID)
+	if err != nil {
+		return err
+	}
+
+	if end, err = end.id(parID); err != nil {
+		return fmt.Errorf("invalid ConversationParticipant ID: %w", err)
+	}
+
+	if err = cs.client.delete(end); err != nil {
+		return fmt.Errorf("cannot delete ConversationParticipant (ID: %d) for Campaign (ID: %d): %w", parID, campID, err)
+	}
+
+	return nil
+}
+
+// endpoint returns the participants endpoint of the conversation associated
+// with convID in the Campaign associated with campID.
+func (cs *ConversationParticipantService) endpoint(campID int, convID int) (endpoint, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return "", fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(EndpointConversation)
+
+	if end, err = end.id(convID); err != nil {
+		return "", fmt.Errorf("invalid Conversation ID: %w", err)
+	}
+
+	return end.concat(cs.end), nil
+}
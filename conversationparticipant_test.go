@@ -0,0 +1,234 @@
+package kanka
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testConversationParticipantIndex  string = "test_data/conversationparticipant_index.json"
+	testConversationParticipantCreate string = "test_data/conversationparticipant_create.json"
+)
+
+func TestConversationParticipantService_Index(t *testing.T) {
+	pars := []*ConversationParticipant{
+		{
+			SimpleConversationParticipant: SimpleConversationParticipant{ConversationID: 912, CharacterID: 116623},
+			ID:                            1,
+			CreatedBy:                     5600,
+			UpdatedBy:                     5600,
+		},
+		{
+			SimpleConversationParticipant: SimpleConversationParticipant{ConversationID: 912, UserID: 5600},
+			ID:                            2,
+			CreatedBy:                     5600,
+			UpdatedBy:                     5600,
+		},
+	}
+
+	type args struct {
+		campID int
+		convID int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*ConversationParticipant
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testConversationParticipantIndex,
+			args:    args{campID: 5272, convID: 912},
+			want:    pars,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testConversationParticipantIndex,
+			args:    args{campID: -123, convID: 912},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid convID",
+			status:  http.StatusOK,
+			file:    testConversationParticipantIndex,
+			args:    args{campID: 5272, convID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, convID: 912},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, convID: 912},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.ConversationParticipants.Index(test.args.campID, test.args.convID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestConversationParticipantService_Add(t *testing.T) {
+	type args struct {
+		campID int
+		convID int
+		id     int
+		user   bool
+	}
+	tests := []struct {
+		name     string
+		status   int
+		args     args
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, character participant",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, convID: 912, id: 116623, user: false},
+			wantBody: `{"conversation_id":912,"character_id":116623}`,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, user participant",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, convID: 912, id: 5600, user: true},
+			wantBody: `{"conversation_id":912,"user_id":5600}`,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, missing participant",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, convID: 912, id: 0, user: true},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusOK, invalid convID",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, convID: -123, id: 116623, user: false},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusForbidden, valid args",
+			status:   http.StatusForbidden,
+			args:     args{campID: 5272, convID: 912, id: 116623, user: false},
+			wantBody: `{"conversation_id":912,"character_id":116623}`,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, testConversationParticipantCreate)
+			defer ts.Close()
+
+			var got *ConversationParticipant
+			var err error
+			if test.args.user {
+				got, err = c.ConversationParticipants.AddUser(test.args.campID, test.args.convID, test.args.id)
+			} else {
+				got, err = c.ConversationParticipants.AddCharacter(test.args.campID, test.args.convID, test.args.id)
+			}
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+			if test.wantErr {
+				return
+			}
+			if rec.url != "/campaigns/5272/conversations/912/conversation_participants" {
+				t.Errorf("got url: <%s>, want participants url", rec.url)
+			}
+			if got == nil || got.ID != 3 {
+				t.Errorf("got: <%v>, want ConversationParticipant with ID 3", got)
+			}
+		})
+	}
+}
+
+func TestConversationParticipantService_Delete(t *testing.T) {
+	type args struct {
+		campID int
+		convID int
+		parID  int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid args",
+			status:  http.StatusOK,
+			args:    args{campID: 5272, convID: 912, parID: 1},
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, invalid parID",
+			status:  http.StatusOK,
+			args:    args{campID: 5272, convID: 912, parID: -123},
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			args:    args{campID: 5272, convID: 912, parID: 1},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(testFileEmpty)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			err = c.ConversationParticipants.Delete(test.args.campID, test.args.convID, test.args.parID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+		})
+	}
+}
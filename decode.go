@@ -0,0 +1,92 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// RecordError represents a single record of a list that could not be
+// unmarshaled.
+type RecordError struct {
+	Index int
+	Err   error
+}
+
+// Error returns the position of the record and the reason it failed.
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("cannot unmarshal record at index %d: %v", e.Index, e.Err)
+}
+
+// Unwrap returns the underlying unmarshaling error.
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// RecordErrors collects every RecordError encountered while decoding a list.
+// RecordErrors can be retrieved from a returned error using errors.As.
+type RecordErrors []*RecordError
+
+// Error returns every record error joined into a single message.
+func (e RecordErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i, err := range e {
+		msgs[i] = err.Error()
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// isNull returns true if the provided JSON-encoded data is the null value.
+func isNull(b []byte) bool {
+	return bytes.Equal(bytes.TrimSpace(b), []byte("null"))
+}
+
+// decodeList unmarshals each of the provided raw records into a new element
+// appended to the slice pointed to by list. Records that cannot be
+// unmarshaled are skipped so that the remaining records are still decoded.
+// Every skipped record is reported in the returned RecordErrors.
+// If strict is true, records containing fields unknown to the element type are
+// skipped as well.
+func decodeList(raws []json.RawMessage, list interface{}, strict bool) error {
+	v := reflect.ValueOf(list)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("cannot decode list into non-slice pointer type %T", list)
+	}
+	v = v.Elem()
+
+	if raws != nil {
+		v.Set(reflect.MakeSlice(v.Type(), 0, len(raws)))
+	}
+
+	var errs RecordErrors
+	for i, raw := range raws {
+		elem := reflect.New(v.Type().Elem())
+		if err := unmarshal(raw, elem.Interface(), strict); err != nil {
+			errs = append(errs, &RecordError{Index: i, Err: err})
+			continue
+		}
+		v.Set(reflect.Append(v, elem.Elem()))
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+
+	return nil
+}
+
+// unmarshal unmarshals the provided JSON-encoded data into the value pointed
+// to by v. If strict is true, fields of the data unknown to v result in an
+// error naming the field.
+func unmarshal(b []byte, v interface{}, strict bool) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if strict {
+		dec.DisallowUnknownFields()
+	}
+
+	return dec.Decode(v)
+}
@@ -0,0 +1,199 @@
+package kanka
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDecodeList(t *testing.T) {
+	tests := []struct {
+		name        string
+		raws        []json.RawMessage
+		want        []*Tag
+		wantIndices []int
+	}{
+		{
+			name: "All valid records",
+			raws: []json.RawMessage{
+				json.RawMessage(`{"name":"Faction"}`),
+				json.RawMessage(`{"name":"Region"}`),
+			},
+			want: []*Tag{
+				{SimpleTag: SimpleTag{Name: "Faction"}},
+				{SimpleTag: SimpleTag{Name: "Region"}},
+			},
+			wantIndices: nil,
+		},
+		{
+			name: "Some invalid records",
+			raws: []json.RawMessage{
+				json.RawMessage(`{"name":"Faction"}`),
+				json.RawMessage(`{"name":123}`),
+				json.RawMessage(`{"name":"Region"}`),
+				json.RawMessage(`{"updated_at":"yesterday"}`),
+			},
+			want: []*Tag{
+				{SimpleTag: SimpleTag{Name: "Faction"}},
+				{SimpleTag: SimpleTag{Name: "Region"}},
+			},
+			wantIndices: []int{1, 3},
+		},
+		{
+			name:        "Empty records",
+			raws:        []json.RawMessage{},
+			want:        []*Tag{},
+			wantIndices: nil,
+		},
+		{
+			name:        "Nil records",
+			raws:        nil,
+			want:        nil,
+			wantIndices: nil,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got []*Tag
+			err := decodeList(test.raws, &got, false)
+
+			var errs RecordErrors
+			if errors.As(err, &errs) {
+				var idx []int
+				for _, e := range errs {
+					idx = append(idx, e.Index)
+				}
+				if diff := cmp.Diff(idx, test.wantIndices); diff != "" {
+					t.Errorf("index mismatch (-want +got):\n%s", diff)
+				}
+			} else if err != nil || test.wantIndices != nil {
+				t.Fatalf("got err: <%v>, want record errors at: <%v>", err, test.wantIndices)
+			}
+
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestDecodeList_NonSlice(t *testing.T) {
+	var tag Tag
+	if err := decodeList([]json.RawMessage{json.RawMessage(`{}`)}, &tag, false); err == nil {
+		t.Errorf("got nil err, want err for non-slice pointer")
+	}
+}
+
+func TestWrapper_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *Character
+		wantErr bool
+	}{
+		{
+			name:    "Null wrappers",
+			data:    `{"name":"Jon Snow","traits":null,"attributes":null,"relations":null,"entity_notes":null,"entity_events":null,"entity_files":null}`,
+			want:    &Character{SimpleCharacter: SimpleCharacter{Name: "Jon Snow"}},
+			wantErr: false,
+		},
+		{
+			name:    "Null lists",
+			data:    `{"name":"Jon Snow","traits":{"data":null},"attributes":{"data":null},"relations":{"data":null},"entity_notes":{"data":null},"entity_events":{"data":null},"entity_files":{"data":null}}`,
+			want:    &Character{SimpleCharacter: SimpleCharacter{Name: "Jon Snow"}},
+			wantErr: false,
+		},
+		{
+			name:    "Invalid wrapper",
+			data:    `{"name":"Jon Snow","traits":{"data":"not a list"}}`,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got Character
+			err := json.Unmarshal([]byte(test.data), &got)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+
+			if got.Traits.Data == nil || got.Attributes.Data == nil || got.Relations.Data == nil ||
+				got.EntityNotes.Data == nil || got.EntityEvents.Data == nil || got.EntityFiles.Data == nil {
+				t.Errorf("got nil wrapped list: <%+v>", got)
+			}
+			if len(got.Traits.Data)+len(got.Attributes.Data)+len(got.Relations.Data) != 0 {
+				t.Errorf("got non-empty wrapped list: <%+v>", got)
+			}
+			if got.Name != test.want.Name {
+				t.Errorf("got name: <%s>, want name: <%s>", got.Name, test.want.Name)
+			}
+		})
+	}
+}
+
+func TestDecode_LargeID(t *testing.T) {
+	if strconv.IntSize < 64 {
+		t.Skip("large IDs require a 64-bit int")
+	}
+
+	tests := []struct {
+		name    string
+		body    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "ID beyond float64 precision",
+			body:    `{"data":{"id":9007199254740993,"entity_id":9007199254740995,"name":"Penny Galvenrise"}}`,
+			want:    9007199254740993,
+			wantErr: false,
+		},
+		{
+			name:    "Maximum ID",
+			body:    `{"data":{"id":9223372036854775807,"name":"Penny Galvenrise"}}`,
+			want:    9223372036854775807,
+			wantErr: false,
+		},
+		{
+			name:    "ID overflowing int",
+			body:    `{"data":{"id":9223372036854775808,"name":"Penny Galvenrise"}}`,
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts := testClient(http.StatusOK, strings.NewReader(test.body))
+			defer ts.Close()
+
+			got, err := c.Characters.Get(5272, 116623)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+			if got.ID != test.want {
+				t.Errorf("got ID: <%d>, want ID: <%d>", got.ID, test.want)
+			}
+
+			var list []*Character
+			raw := json.RawMessage(strings.TrimSuffix(strings.TrimPrefix(test.body, `{"data":`), "}"))
+			if err := decodeList([]json.RawMessage{raw}, &list, false); err != nil {
+				t.Fatal(err)
+			}
+			if list[0].ID != test.want {
+				t.Errorf("got Index ID: <%d>, want Index ID: <%d>", list[0].ID, test.want)
+			}
+		})
+	}
+}
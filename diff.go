@@ -0,0 +1,105 @@
+package kanka
+
+import (
+	"reflect"
+	"sort"
+)
+
+// Change describes a single field that differs between two versions of an
+// object.
+type Change struct {
+	Field string
+	Old   interface{}
+	New   interface{}
+}
+
+// DiffCharacters returns the list of changes needed to turn Character a into
+// Character b. Only the writable fields found in SimpleCharacter are compared,
+// along with the Character's Traits and Attributes. Traits are matched by
+// Section and Name while Attributes are matched by Name.
+// A nil Character is treated as an empty Character.
+func DiffCharacters(a, b *Character) []Change {
+	if a == nil {
+		a = &Character{}
+	}
+	if b == nil {
+		b = &Character{}
+	}
+
+	chs := diffFields("", reflect.ValueOf(a.SimpleCharacter), reflect.ValueOf(b.SimpleCharacter))
+
+	at := make(map[string]interface{})
+	for _, t := range a.Traits.Data {
+		at[t.Section+"/"+t.Name] = t.Entry
+	}
+	bt := make(map[string]interface{})
+	for _, t := range b.Traits.Data {
+		bt[t.Section+"/"+t.Name] = t.Entry
+	}
+	chs = append(chs, diffKeyed("Traits", at, bt)...)
+
+	aa := make(map[string]interface{})
+	for _, atr := range a.Attributes.Data {
+		aa[atr.Name] = atr.SimpleAttribute
+	}
+	ba := make(map[string]interface{})
+	for _, atr := range b.Attributes.Data {
+		ba[atr.Name] = atr.SimpleAttribute
+	}
+	chs = append(chs, diffKeyed("Attributes", aa, ba)...)
+
+	return chs
+}
+
+// diffFields returns the changes between the exported fields of the provided
+// struct values, which must be of the same type. Embedded structs are
+// compared field by field.
+func diffFields(prefix string, a, b reflect.Value) []Change {
+	var chs []Change
+	for i := 0; i < a.NumField(); i++ {
+		f := a.Type().Field(i)
+		if f.PkgPath != "" {
+			continue
+		}
+
+		if f.Anonymous && f.Type.Kind() == reflect.Struct {
+			chs = append(chs, diffFields(prefix, a.Field(i), b.Field(i))...)
+			continue
+		}
+
+		av := a.Field(i).Interface()
+		bv := b.Field(i).Interface()
+		if !reflect.DeepEqual(av, bv) {
+			chs = append(chs, Change{Field: prefix + f.Name, Old: av, New: bv})
+		}
+	}
+
+	return chs
+}
+
+// diffKeyed returns the changes between the provided keyed values, sorted by
+// key. A value missing from one side is reported as nil.
+func diffKeyed(field string, a, b map[string]interface{}) []Change {
+	keys := make(map[string]bool)
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+
+	sorted := make([]string, 0, len(keys))
+	for k := range keys {
+		sorted = append(sorted, k)
+	}
+	sort.Strings(sorted)
+
+	var chs []Change
+	for _, k := range sorted {
+		if !reflect.DeepEqual(a[k], b[k]) {
+			chs = append(chs, Change{Field: field + "[" + k + "]", Old: a[k], New: b[k]})
+		}
+	}
+
+	return chs
+}
@@ -0,0 +1,96 @@
+package kanka
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDiffCharacters(t *testing.T) {
+	base := &Character{
+		SimpleCharacter: SimpleCharacter{
+			Name:  "Jon Snow",
+			Title: "Bastard of Winterfell",
+			Tags:  []int{1, 2},
+		},
+		ID: 111,
+		Traits: Traits{
+			Data: []*Trait{
+				{Name: "Hair", Entry: "Black", Section: "appearance"},
+				{Name: "Goals", Entry: "Hold the Wall", Section: "personality"},
+			},
+		},
+		Attributes: Attributes{
+			Data: []*Attribute{
+				{SimpleAttribute: SimpleAttribute{Name: "Strength", Value: "15"}},
+			},
+		},
+	}
+
+	changed := &Character{
+		SimpleCharacter: SimpleCharacter{
+			Name:  "Jon Snow",
+			Title: "King in the North",
+			Tags:  []int{1, 2},
+		},
+		ID: 222,
+		Traits: Traits{
+			Data: []*Trait{
+				{Name: "Hair", Entry: "Black", Section: "appearance"},
+				{Name: "Goals", Entry: "Defeat the Night King", Section: "personality"},
+			},
+		},
+		Attributes: Attributes{
+			Data: []*Attribute{
+				{SimpleAttribute: SimpleAttribute{Name: "Charisma", Value: "12"}},
+			},
+		},
+	}
+
+	tests := []struct {
+		name string
+		a    *Character
+		b    *Character
+		want []Change
+	}{
+		{
+			name: "Identical characters",
+			a:    base,
+			b:    base,
+			want: nil,
+		},
+		{
+			name: "Changed characters",
+			a:    base,
+			b:    changed,
+			want: []Change{
+				{Field: "Title", Old: "Bastard of Winterfell", New: "King in the North"},
+				{Field: "Traits[personality/Goals]", Old: "Hold the Wall", New: "Defeat the Night King"},
+				{Field: "Attributes[Charisma]", Old: nil, New: SimpleAttribute{Name: "Charisma", Value: "12"}},
+				{Field: "Attributes[Strength]", Old: SimpleAttribute{Name: "Strength", Value: "15"}, New: nil},
+			},
+		},
+		{
+			name: "Nil characters",
+			a:    nil,
+			b:    nil,
+			want: nil,
+		},
+		{
+			name: "Nil original character",
+			a:    nil,
+			b:    &Character{SimpleCharacter: SimpleCharacter{Name: "Arya Stark"}},
+			want: []Change{
+				{Field: "Name", Old: "", New: "Arya Stark"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := DiffCharacters(test.a, test.b)
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package kanka
 
 import (
 	"fmt"
+	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -29,21 +31,37 @@ const (
 	EndpointQuestLocation      endpoint = "quest_locations"
 	EndpointQuestItem          endpoint = "quest_items"
 	EndpointQuestOrganization  endpoint = "quest_organisations"
+	EndpointQuestElement       endpoint = "quest_elements"
 	EndpointJournal            endpoint = "journals"
 	EndpointTag                endpoint = "tags"
 	EndpointConversation       endpoint = "conversations"
 	EndpointDiceRoll           endpoint = "dice_rolls"
+	EndpointAbility            endpoint = "abilities"
+	EndpointGallery            endpoint = "gallery"
+	EndpointEntityType         endpoint = "entity-types"
 
 	// Entities
 	EndpointAttribute         endpoint = "attributes"
+	EndpointEntityAbility     endpoint = "entity_abilities"
+	EndpointEntityAsset       endpoint = "entity_assets"
 	EndpointEntityEvent       endpoint = "entity_events"
 	EndpointEntityFile        endpoint = "entity_files"
+	EndpointEntityLog         endpoint = "entity_logs"
 	EndpointEntityInventories endpoint = "inventories"
 	EndpointEntityInventory   endpoint = "inventory"
 	EndpointEntityNote        endpoint = "entity_notes"
+	EndpointEntityPermission  endpoint = "entity_permissions"
 	EndpointEntityTag         endpoint = "entity_tags"
 	EndpointRelation          endpoint = "relations"
 	endpointEntity            endpoint = "entities"
+	endpointRecovery          endpoint = "recovery"
+
+	// Campaigns
+	endpointCampaignUser endpoint = "users"
+	endpointCampaignRole endpoint = "campaign_roles"
+
+	// Conversations
+	EndpointConversationParticipant endpoint = "conversation_participants"
 
 	// Search
 	EndpointSearch endpoint = "search"
@@ -55,9 +73,23 @@ func (e endpoint) append(s string) endpoint {
 }
 
 // concat returns an endpoint appropriately concatenated with the provided
-// endpoint.
+// endpoint. Stray leading, trailing, or repeated slashes in the provided
+// endpoint are dropped, as are the trailing slashes of the receiver, so that
+// the two are joined by a single slash. The receiver is otherwise left as it
+// is, and is returned unchanged if the provided endpoint has no segments.
 func (e endpoint) concat(end endpoint) endpoint {
-	return e.append("/" + string(end))
+	var segs []string
+	for _, seg := range strings.Split(string(end), "/") {
+		if seg != "" {
+			segs = append(segs, seg)
+		}
+	}
+
+	if len(segs) == 0 {
+		return e
+	}
+
+	return e.trim().append("/" + strings.Join(segs, "/"))
 }
 
 // id returns an endpoint appropriately formatted with the provided id.
@@ -66,11 +98,35 @@ func (e endpoint) id(id int) (endpoint, error) {
 		return "", fmt.Errorf("provided ID (%d) cannot be negative", id)
 	}
 
-	return e.append("/" + strconv.Itoa(id)), nil
+	return e.trim().append("/" + strconv.Itoa(id)), nil
+}
+
+// trim returns the endpoint without any trailing slashes.
+func (e endpoint) trim() endpoint {
+	return endpoint(strings.TrimRight(string(e), "/"))
+}
+
+// query returns an endpoint appended with the provided query parameter.
+func (e endpoint) query(key string, val string) endpoint {
+	sep := "?"
+	if strings.Contains(string(e), "?") {
+		sep = "&"
+	}
+
+	return e.append(sep + url.QueryEscape(key) + "=" + url.QueryEscape(val))
 }
 
+// paramSync is the query parameter Kanka reads the last sync time from.
+const paramSync string = "lastSync"
+
+// SyncFormat is the layout of the lastSync time sent to Kanka. It matches the
+// layout of the sync times Kanka returns. Times are always sent in UTC so that
+// no offset needs to be escaped; an incorrectly formatted time is ignored by
+// Kanka, which then returns the full dataset.
+const SyncFormat string = "2006-01-02T15:04:05.000000Z07:00"
+
 // sync returns an endpoint appropriately formatted with the provided lastSync
 // time.
 func (e endpoint) sync(t time.Time) endpoint {
-	return e.append("/?lastSync=" + t.Format(time.RFC3339))
+	return e.query(paramSync, t.UTC().Format(SyncFormat))
 }
@@ -0,0 +1,191 @@
+package kanka
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEndpoint_concat(t *testing.T) {
+	tests := []struct {
+		name string
+		end  endpoint
+		next endpoint
+		want endpoint
+	}{
+		{
+			name: "Plain segments",
+			end:  "campaigns/5272",
+			next: "characters",
+			want: "campaigns/5272/characters",
+		},
+		{
+			name: "Trailing slash",
+			end:  "campaigns/5272/",
+			next: "characters",
+			want: "campaigns/5272/characters",
+		},
+		{
+			name: "Leading slash",
+			end:  "campaigns/5272",
+			next: "/characters",
+			want: "campaigns/5272/characters",
+		},
+		{
+			name: "Leading and trailing slashes",
+			end:  "campaigns/5272//",
+			next: "//characters/",
+			want: "campaigns/5272/characters",
+		},
+		{
+			name: "Repeated inner slashes",
+			end:  "campaigns/5272",
+			next: "entities//430214",
+			want: "campaigns/5272/entities/430214",
+		},
+		{
+			name: "Receiver slashes kept",
+			end:  "/campaigns//5272",
+			next: "characters",
+			want: "/campaigns//5272/characters",
+		},
+		{
+			name: "Empty endpoint",
+			end:  "campaigns/5272",
+			next: "",
+			want: "campaigns/5272",
+		},
+		{
+			name: "Slash only endpoint",
+			end:  "campaigns/5272",
+			next: "/",
+			want: "campaigns/5272",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.end.concat(test.next)
+			if got != test.want {
+				t.Errorf("got: <%s>, want: <%s>", got, test.want)
+			}
+		})
+	}
+}
+
+func TestEndpoint_id(t *testing.T) {
+	tests := []struct {
+		name    string
+		end     endpoint
+		id      int
+		want    endpoint
+		wantErr bool
+	}{
+		{
+			name:    "Plain endpoint",
+			end:     "campaigns",
+			id:      5272,
+			want:    "campaigns/5272",
+			wantErr: false,
+		},
+		{
+			name:    "Trailing slash",
+			end:     "campaigns/",
+			id:      5272,
+			want:    "campaigns/5272",
+			wantErr: false,
+		},
+		{
+			name:    "Negative ID",
+			end:     "campaigns",
+			id:      -1,
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := test.end.id(test.id)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if got != test.want {
+				t.Errorf("got: <%s>, want: <%s>", got, test.want)
+			}
+		})
+	}
+}
+
+func TestEndpoint_query(t *testing.T) {
+	type args struct {
+		key string
+		val string
+	}
+	tests := []struct {
+		name string
+		end  endpoint
+		args args
+		want endpoint
+	}{
+		{
+			name: "No existing query",
+			end:  "campaigns/5272/entities",
+			args: args{key: "is_template", val: "1"},
+			want: "campaigns/5272/entities?is_template=1",
+		},
+		{
+			name: "Existing query",
+			end:  "campaigns/5272/entities?is_template=1",
+			args: args{key: "related", val: "1"},
+			want: "campaigns/5272/entities?is_template=1&related=1",
+		},
+		{
+			name: "Escaped value",
+			end:  "campaigns/5272/entities",
+			args: args{key: "name", val: "Jon Snow"},
+			want: "campaigns/5272/entities?name=Jon+Snow",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.end.query(test.args.key, test.args.val)
+			if got != test.want {
+				t.Errorf("got: <%s>, want: <%s>", got, test.want)
+			}
+		})
+	}
+}
+
+func TestEndpoint_sync(t *testing.T) {
+	tests := []struct {
+		name string
+		end  endpoint
+		t    time.Time
+		want endpoint
+	}{
+		{
+			name: "UTC time",
+			end:  "campaigns/5272/characters",
+			t:    time.Date(2020, time.January, 26, 3, 22, 31, 34959000, time.UTC),
+			want: "campaigns/5272/characters?lastSync=2020-01-26T03%3A22%3A31.034959Z",
+		},
+		{
+			name: "Time with positive offset",
+			end:  "campaigns/5272/characters",
+			t:    time.Date(2020, time.January, 26, 5, 22, 31, 0, time.FixedZone("EET", 2*60*60)),
+			want: "campaigns/5272/characters?lastSync=2020-01-26T03%3A22%3A31.000000Z",
+		},
+		{
+			name: "Existing query",
+			end:  "campaigns/5272/entities?is_template=1",
+			t:    time.Date(2020, time.January, 26, 3, 22, 31, 0, time.UTC),
+			want: "campaigns/5272/entities?is_template=1&lastSync=2020-01-26T03%3A22%3A31.000000Z",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.end.sync(test.t)
+			if got != test.want {
+				t.Errorf("got: <%s>, want: <%s>", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,467 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// Entity contains the information shared by every type of entity.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/entities
+type Entity struct {
+	ID         int       `json:"id"`
+	Name       string    `json:"name"`
+	Type       string    `json:"type"`
+	ChildID    int       `json:"child_id"`
+	CampaignID int       `json:"campaign_id"`
+	Tags       TagList   `json:"tags"`
+	IsPrivate  bool      `json:"is_private"`
+	IsTemplate bool      `json:"is_template"`
+	CreatedAt  time.Time `json:"created_at"`
+	CreatedBy  int       `json:"created_by"`
+	UpdatedAt  time.Time `json:"updated_at"`
+	UpdatedBy  int       `json:"updated_by"`
+}
+
+// TagList contains the Tags of an entity. Kanka returns the tags of an entity
+// either as a list of tag IDs or as a list of tag objects. In the former case,
+// only the ID of each Tag is populated.
+type TagList []*Tag
+
+// IDs returns the IDs of the Tags in the TagList.
+func (tl TagList) IDs() []int {
+	ids := make([]int, 0, len(tl))
+	for _, t := range tl {
+		ids = append(ids, t.ID)
+	}
+
+	return ids
+}
+
+// UnmarshalJSON unmarshals the JSON-encoded data into the TagList. The data
+// may be either a list of tag IDs or a list of tag objects.
+func (tl *TagList) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		*tl = nil
+		return nil
+	}
+
+	var ids []int
+	if err := json.Unmarshal(b, &ids); err == nil {
+		tags := make(TagList, 0, len(ids))
+		for _, id := range ids {
+			tags = append(tags, &Tag{ID: id})
+		}
+		*tl = tags
+		return nil
+	}
+
+	var tags []*Tag
+	if err := json.Unmarshal(b, &tags); err != nil {
+		return fmt.Errorf("cannot unmarshal tags as IDs or objects: %w", err)
+	}
+
+	*tl = tags
+	return nil
+}
+
+// EntityLog represents a single change made to an entity.
+type EntityLog struct {
+	ID        int       `json:"id"`
+	EntityID  int       `json:"entity_id"`
+	Action    string    `json:"action"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy int       `json:"created_by"`
+}
+
+// DeletedEntity represents an entity that was recently deleted and can still
+// be restored.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/recovery
+type DeletedEntity struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	ChildID   int       `json:"child_id"`
+	DeletedAt time.Time `json:"deleted_at"`
+	DeletedBy int       `json:"deleted_by"`
+}
+
+// resolver fetches the concrete object associated with childID from the
+// Campaign associated with campID.
+type resolver func(c *Client, campID int, childID int) (interface{}, error)
+
+// entityTypes maps each Entity type to the resolver for its concrete object.
+var entityTypes = map[string]resolver{
+	"character": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Characters.Get(campID, childID)
+	},
+	"location": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Locations.Get(campID, childID)
+	},
+	"family": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Families.Get(campID, childID)
+	},
+	"organisation": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Organizations.Get(campID, childID)
+	},
+	"item": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Items.Get(campID, childID)
+	},
+	"note": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Notes.Get(campID, childID)
+	},
+	"event": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Events.Get(campID, childID)
+	},
+	"race": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Races.Get(campID, childID)
+	},
+	"quest": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Quests.Get(campID, childID)
+	},
+	"journal": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Journals.Get(campID, childID)
+	},
+	"tag": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Tags.Get(campID, childID)
+	},
+	"ability": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Abilities.Get(campID, childID)
+	},
+	"conversation": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Conversations.Get(campID, childID)
+	},
+	"calendar": func(c *Client, campID int, childID int) (interface{}, error) {
+		return c.Calendars.Get(campID, childID)
+	},
+}
+
+// Resolve fetches the concrete object the Entity represents, such as a
+// *Character or *Location, from the Campaign associated with campID.
+// Resolve returns an error if the Entity's Type is not supported.
+func (e *Entity) Resolve(c *Client, campID int) (interface{}, error) {
+	res, ok := entityTypes[e.Type]
+	if !ok {
+		return nil, fmt.Errorf("cannot resolve Entity (ID: %d) with unsupported type '%s'", e.ID, e.Type)
+	}
+
+	obj, err := res(c, campID, e.ChildID)
+	if err != nil {
+		return nil, fmt.Errorf("cannot resolve Entity (ID: %d): %w", e.ID, err)
+	}
+
+	return obj, nil
+}
+
+// EntityService handles communication with the Entity endpoint.
+type EntityService service
+
+// Index returns the list of all Entities in the Campaign associated with campID.
+// If a non-nil time is provided, Index will only return Entities that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Entities that were decoded.
+func (es *EntityService) Index(campID int, sync *time.Time) ([]*Entity, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(es.end)
+
+	if sync != nil {
+		end = end.sync(*sync)
+	}
+
+	var wrap response[[]json.RawMessage]
+
+	if err = es.client.get(end, &wrap); err != nil {
+		return nil, fmt.Errorf("cannot get Entity Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	var list []*Entity
+	if err = decodeList(wrap.Data, &list, es.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode Entity Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// Count returns the number of Entities in the Campaign associated with campID
+// without retrieving them.
+func (es *EntityService) Count(campID int) (int, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(es.end)
+
+	n, err := es.client.count(end)
+	if err != nil {
+		return 0, fmt.Errorf("cannot count Entities in Campaign (ID: %d): %w", campID, err)
+	}
+
+	return n, nil
+}
+
+// Get returns the Entity associated with entID from the Campaign associated
+// with campID.
+func (es *EntityService) Get(campID int, entID int) (*Entity, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(es.end)
+
+	if end, err = end.id(entID); err != nil {
+		return nil, fmt.Errorf("invalid Entity ID: %w", err)
+	}
+
+	var wrap response[*Entity]
+
+	if err = es.client.get(end, &wrap); err != nil {
+		return nil, fmt.Errorf("cannot get Entity (ID: %d) from Campaign (ID: %d): %w", entID, campID, err)
+	}
+
+	return wrap.Data, nil
+}
+
+// GetMany returns the Entities associated with each of the provided IDs from
+// the Campaign associated with campID, keyed by ID. GetMany reads the first
+// page of the list of Entities, then either requests the remaining IDs with
+// Get or pages through the rest of the list, whichever takes fewer requests.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Entities that were decoded. Otherwise,
+// IDs that are not in the Campaign are reported in an error matching
+// ErrNotFound alongside the Entities that were found.
+func (es *EntityService) GetMany(campID int, ids []int) (map[int]*Entity, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(es.end)
+
+	pending := make(map[int]bool, len(ids))
+	for _, id := range ids {
+		if id < 0 {
+			return nil, fmt.Errorf("invalid Entity ID: provided ID (%d) cannot be negative", id)
+		}
+		pending[id] = true
+	}
+
+	ents, errs, err := getMany(es.client, end, pending, func(id int) (*Entity, error) { return es.Get(campID, id) })
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Entities (IDs: %v) from Campaign (ID: %d): %w", ids, campID, err)
+	}
+
+	if len(errs) > 0 {
+		return ents, fmt.Errorf("cannot decode Entity Index from Campaign (ID: %d): %w", campID, errs)
+	}
+
+	if len(pending) > 0 {
+		missing := make([]int, 0, len(pending))
+		for id := range pending {
+			missing = append(missing, id)
+		}
+		sort.Ints(missing)
+
+		return ents, fmt.Errorf("cannot get Entities (IDs: %v) from Campaign (ID: %d): %w", missing, campID, ErrNotFound)
+	}
+
+	return ents, nil
+}
+
+// paramOrderBy and paramDesc are the query parameters Kanka reads the field
+// and the direction of the ordering of a list from.
+const (
+	paramOrderBy string = "order_by"
+	paramDesc    string = "desc"
+)
+
+// Recent returns the Entities of every type in the Campaign associated with
+// campID that have been changed since the provided time, most recently
+// updated first. At most limit Entities are returned; a non-positive limit
+// returns every changed Entity. A zero time considers every Entity.
+// Kanka is asked to order the list by its update time, so Recent stops
+// paging as soon as limit Entities have been read. Without a limit, every
+// page of the list is read, each a separate request subject to the rate
+// limit of the Client.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Entities that were decoded.
+func (es *EntityService) Recent(campID int, since time.Time, limit int) ([]*Entity, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(es.end)
+
+	if !since.IsZero() {
+		end = end.sync(since)
+	}
+	end = end.query(paramOrderBy, "updated_at")
+	end = end.query(paramDesc, "1")
+
+	var raws []json.RawMessage
+	err = es.client.pages(end, func(page []json.RawMessage, left int) bool {
+		raws = append(raws, page...)
+		return limit <= 0 || len(raws) < limit
+	})
+	if err != nil {
+		return nil, fmt.Errorf("cannot get recent Entities from Campaign (ID: %d): %w", campID, err)
+	}
+
+	var list []*Entity
+	err = decodeList(raws, &list, es.client.strict)
+
+	sort.SliceStable(list, func(i, j int) bool {
+		return list[i].UpdatedAt.After(list[j].UpdatedAt)
+	})
+
+	if limit > 0 && len(list) > limit {
+		list = list[:limit]
+	}
+
+	if err != nil {
+		return list, fmt.Errorf("cannot decode recent Entities from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+const paramIsTemplate string = "is_template"
+
+// Templates returns the list of all entities marked as templates in the
+// Campaign associated with campID from every page of the list.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Entities that were decoded.
+func (es *EntityService) Templates(campID int) ([]*Entity, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(es.end)
+	end = end.query(paramIsTemplate, "1")
+
+	raws, err := es.client.indexAll(end)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get template Entities from Campaign (ID: %d): %w", campID, err)
+	}
+
+	var list []*Entity
+	if err = decodeList(raws, &list, es.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode template Entities from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// Logs returns the change log of the Entity associated with entID from the
+// Campaign associated with campID, following every page of the list.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityLogs that were decoded.
+func (es *EntityService) Logs(campID int, entID int) ([]*EntityLog, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(es.end)
+
+	if end, err = end.id(entID); err != nil {
+		return nil, fmt.Errorf("invalid Entity ID: %w", err)
+	}
+	end = end.concat(EndpointEntityLog)
+
+	raws, err := es.client.indexAll(end)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get EntityLogs for Entity (ID: %d) from Campaign (ID: %d): %w", entID, campID, err)
+	}
+
+	var list []*EntityLog
+	if err = decodeList(raws, &list, es.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode EntityLogs for Entity (ID: %d) from Campaign (ID: %d): %w", entID, campID, err)
+	}
+
+	return list, nil
+}
+
+// Deleted returns the list of Entities that were recently deleted from the
+// Campaign associated with campID and can still be restored with Restore,
+// following every page of the list.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the DeletedEntities that were decoded.
+func (es *EntityService) Deleted(campID int) ([]*DeletedEntity, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(endpointRecovery)
+
+	raws, err := es.client.indexAll(end)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get deleted Entities from Campaign (ID: %d): %w", campID, err)
+	}
+
+	var list []*DeletedEntity
+	if err = decodeList(raws, &list, es.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode deleted Entities from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// Restore restores each of the recently deleted Entities associated with the
+// provided entIDs to the Campaign associated with campID in a single request.
+// The IDs of the deleted Entities are listed by Deleted.
+func (es *EntityService) Restore(campID int, entIDs ...int) error {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(endpointRecovery)
+
+	if len(entIDs) == 0 {
+		return fmt.Errorf("cannot restore Entities to Campaign (ID: %d) without any Entity IDs", campID)
+	}
+
+	for _, id := range entIDs {
+		if id < 0 {
+			return fmt.Errorf("invalid Entity ID: provided ID (%d) cannot be negative", id)
+		}
+	}
+
+	b, err := json.Marshal(struct {
+		Entities []int `json:"entities"`
+	}{entIDs})
+	if err != nil {
+		return fmt.Errorf("cannot marshal Entity IDs: %w", err)
+	}
+
+	if err = es.client.post(end, bytes.NewReader(b), nil); err != nil {
+		return fmt.Errorf("cannot restore Entities (IDs: %v) to Campaign (ID: %d): %w", entIDs, campID, err)
+	}
+
+	return nil
+}
+
+// EntityURL returns the URL of the page of the Entity associated with entID
+// from the Campaign associated with campID on the Kanka website, or on the
+// self-hosted instance set with WithBaseURL. The entity ID of an object such
+// as a Character is found in its EntityID field.
+func (c *Client) EntityURL(campID int, entID int) (string, error) {
+	if campID < 0 {
+		return "", fmt.Errorf("invalid Campaign ID: provided ID (%d) cannot be negative", campID)
+	}
+
+	if entID < 0 {
+		return "", fmt.Errorf("invalid Entity ID: provided ID (%d) cannot be negative", entID)
+	}
+
+	return c.entityURL(campID, entID), nil
+}
+
+// entityURL returns the URL of the page of the Entity associated with entID
+// from the Campaign associated with campID on the Kanka website.
+func (c *Client) entityURL(campID int, entID int) string {
+	return fmt.Sprintf("%scampaign/%d/entities/%d", c.webURL, campID, entID)
+}
@@ -0,0 +1,972 @@
+package kanka
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testEntityIndex            string = "test_data/entity_index.json"
+	testEntityGet              string = "test_data/entity_get.json"
+	testEntityRecent           string = "test_data/entity_recent.json"
+	testEntityTemplates        string = "test_data/entity_templates.json"
+	testEntityTemplatesPartial string = "test_data/entity_templates_partial.json"
+	testEntityLogs             string = "test_data/entity_logs.json"
+	testEntityLogsPartial      string = "test_data/entity_logs_partial.json"
+	testEntityDeleted          string = "test_data/entity_deleted.json"
+)
+
+func TestEntityService_Index(t *testing.T) {
+	ents := []*Entity{
+		{
+			ID:         430214,
+			Name:       "Penny Galvenrise",
+			Type:       "character",
+			ChildID:    116623,
+			CampaignID: 5272,
+		},
+		{
+			ID:         80918,
+			Name:       "The Rope Shop",
+			Type:       "location",
+			ChildID:    26141,
+			CampaignID: 5272,
+		},
+	}
+	n := time.Now()
+	now := &n
+
+	type args struct {
+		campID int
+		sync   *time.Time
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*Entity
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testEntityIndex,
+			args:    args{campID: 5272, sync: now},
+			want:    ents,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testEntityIndex,
+			args:    args{campID: -123, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Entities.Index(test.args.campID, test.args.sync)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntityService_Get(t *testing.T) {
+	ent := &Entity{
+		ID:         430214,
+		Name:       "Penny Galvenrise",
+		Type:       "character",
+		ChildID:    116623,
+		CampaignID: 5272,
+		Tags:       TagList{{ID: 34696}},
+		CreatedBy:  5600,
+		UpdatedBy:  5600,
+	}
+
+	type args struct {
+		campID int
+		entID  int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *Entity
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testEntityGet,
+			args:    args{campID: 5272, entID: 430214},
+			want:    ent,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testEntityGet,
+			args:    args{campID: -123, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid entID",
+			status:  http.StatusOK,
+			file:    testEntityGet,
+			args:    args{campID: 5272, entID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Entities.Get(test.args.campID, test.args.entID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntity_Resolve(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		file     string
+		ent      *Entity
+		wantType string
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, character entity",
+			status:   http.StatusOK,
+			file:     testCharacterGet,
+			ent:      &Entity{ID: 430214, Type: "character", ChildID: 116623},
+			wantType: "*kanka.Character",
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, location entity",
+			status:   http.StatusOK,
+			file:     testLocationGet,
+			ent:      &Entity{ID: 80918, Type: "location", ChildID: 26141},
+			wantType: "*kanka.Location",
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, calendar entity",
+			status:   http.StatusOK,
+			file:     testCalendarGet,
+			ent:      &Entity{ID: 80920, Type: "calendar", ChildID: 111},
+			wantType: "*kanka.Calendar",
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, unsupported entity type",
+			status:   http.StatusOK,
+			file:     testCharacterGet,
+			ent:      &Entity{ID: 111, Type: "dice_roll", ChildID: 222},
+			wantType: "<nil>",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusNotFound, character entity",
+			status:   http.StatusNotFound,
+			file:     testFileEmpty,
+			ent:      &Entity{ID: 430214, Type: "character", ChildID: 116623},
+			wantType: "<nil>",
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := test.ent.Resolve(c, 5272)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if typ := fmt.Sprintf("%T", got); typ != test.wantType {
+				t.Errorf("got type: <%s>, want type: <%s>", typ, test.wantType)
+			}
+		})
+	}
+}
+
+func TestEntity_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    *Entity
+		wantErr bool
+	}{
+		{
+			name:    "Tag IDs",
+			data:    `{"id":430214,"tags":[34696,34697]}`,
+			want:    &Entity{ID: 430214, Tags: TagList{{ID: 34696}, {ID: 34697}}},
+			wantErr: false,
+		},
+		{
+			name: "Tag objects",
+			data: `{"id":430214,"tags":[{"id":34696,"name":"Stark"},{"id":34697,"name":"North"}]}`,
+			want: &Entity{
+				ID: 430214,
+				Tags: TagList{
+					{SimpleTag: SimpleTag{Name: "Stark"}, ID: 34696},
+					{SimpleTag: SimpleTag{Name: "North"}, ID: 34697},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Null tags",
+			data:    `{"id":430214,"tags":null}`,
+			want:    &Entity{ID: 430214},
+			wantErr: false,
+		},
+		{
+			name:    "Missing tags",
+			data:    `{"id":430214}`,
+			want:    &Entity{ID: 430214},
+			wantErr: false,
+		},
+		{
+			name:    "Invalid tags",
+			data:    `{"id":430214,"tags":"Stark"}`,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got *Entity
+			err := json.Unmarshal([]byte(test.data), &got)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			if test.wantErr {
+				return
+			}
+
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestEntity_UnmarshalJSONStrict(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr bool
+	}{
+		{
+			name:    "Known fields",
+			data:    `{"id":430214,"tags":[{"id":34696,"name":"Stark"}]}`,
+			wantErr: false,
+		},
+		{
+			name:    "Unknown field",
+			data:    `{"id":430214,"bogus_field":3}`,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := unmarshal([]byte(test.data), &Entity{}, true)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestTagList_IDs(t *testing.T) {
+	tl := TagList{{ID: 34696}, {SimpleTag: SimpleTag{Name: "North"}, ID: 34697}}
+
+	if diff := cmp.Diff(tl.IDs(), []int{34696, 34697}); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestEntityService_GetMany(t *testing.T) {
+	var calls []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.URL.Path)
+
+		switch r.URL.Path {
+		case "/campaigns/5272/entities":
+			w.Write([]byte(`{"data":[{"id":1,"name":"Arya"},{"id":2,"name":"Bran"}],"meta":{"current_page":1,"last_page":40}}`))
+		case "/campaigns/5272/entities/7":
+			w.Write([]byte(`{"data":{"id":7,"name":"Sansa"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	ents, err := c.Entities.GetMany(5272, []int{2, 7, 9})
+	if !errors.Is(err, ErrNotFound) {
+		t.Fatalf("got err: <%v>, want err: <%v>", err, ErrNotFound)
+	}
+
+	got := make(map[int]string)
+	for id, ent := range ents {
+		got[id] = ent.Name
+	}
+	if diff := cmp.Diff(got, map[int]string{2: "Bran", 7: "Sansa"}); diff != "" {
+		t.Errorf(diff)
+	}
+
+	wantCalls := []string{"/campaigns/5272/entities", "/campaigns/5272/entities/7", "/campaigns/5272/entities/9"}
+	if diff := cmp.Diff(calls, wantCalls); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestEntityService_Recent(t *testing.T) {
+	since := time.Date(2020, time.January, 20, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		since   time.Time
+		limit   int
+		want    []int
+		wantURL string
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, no limit",
+			status:  http.StatusOK,
+			file:    testEntityRecent,
+			since:   since,
+			limit:   0,
+			want:    []int{430215, 430216, 430214},
+			wantURL: "/campaigns/5272/entities?lastSync=2020-01-20T00%3A00%3A00.000000Z&order_by=updated_at&desc=1&related=1",
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, limit",
+			status:  http.StatusOK,
+			file:    testEntityRecent,
+			since:   since,
+			limit:   2,
+			want:    []int{430215, 430216},
+			wantURL: "/campaigns/5272/entities?lastSync=2020-01-20T00%3A00%3A00.000000Z&order_by=updated_at&desc=1&related=1",
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, zero time",
+			status:  http.StatusOK,
+			file:    testEntityRecent,
+			since:   time.Time{},
+			limit:   1,
+			want:    []int{430215},
+			wantURL: "/campaigns/5272/entities?order_by=updated_at&desc=1&related=1",
+			wantErr: false,
+		},
+		{
+			name:    "StatusUnauthorized",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			since:   since,
+			limit:   0,
+			want:    nil,
+			wantURL: "/campaigns/5272/entities?lastSync=2020-01-20T00%3A00%3A00.000000Z&order_by=updated_at&desc=1&related=1",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, test.file)
+			defer ts.Close()
+
+			ents, err := c.Entities.Recent(5272, test.since, test.limit)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			var got []int
+			for _, e := range ents {
+				got = append(got, e.ID)
+			}
+
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if rec.url != test.wantURL {
+				t.Errorf("got url: <%s>, want url: <%s>", rec.url, test.wantURL)
+			}
+		})
+	}
+}
+
+func TestEntityService_RecentLimit(t *testing.T) {
+	var pages int
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+		fmt.Fprintf(w, `{"data":[{"id":430215,"updated_at":"2020-01-22T00:00:00.000000Z"}],"links":{"next":"%s/campaigns/5272/entities?page=2"}}`, ts.URL)
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	ents, err := c.Entities.Recent(5272, time.Time{}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ents) != 1 || pages != 1 {
+		t.Errorf("got %d Entities from %d pages, want 1 Entity from 1 page", len(ents), pages)
+	}
+}
+
+func TestEntityService_Templates(t *testing.T) {
+	ents := []*Entity{
+		{
+			ID:         430214,
+			Name:       "Town Guard",
+			Type:       "character",
+			ChildID:    116623,
+			CampaignID: 5272,
+			IsPrivate:  false,
+			IsTemplate: true,
+		},
+		{
+			ID:         430215,
+			Name:       "Tavern",
+			Type:       "location",
+			ChildID:    26145,
+			CampaignID: 5272,
+			IsPrivate:  true,
+			IsTemplate: true,
+		},
+	}
+
+	type args struct {
+		campID int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*Entity
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testEntityTemplates,
+			args:    args{campID: 5272},
+			want:    ents,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, partially malformed response, valid args",
+			status:  http.StatusOK,
+			file:    testEntityTemplatesPartial,
+			args:    args{campID: 5272},
+			want:    ents,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testEntityTemplates,
+			args:    args{campID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Entities.Templates(test.args.campID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntityService_Logs(t *testing.T) {
+	logs := []*EntityLog{
+		{
+			ID:        111,
+			EntityID:  430214,
+			Action:    "create",
+			CreatedAt: time.Date(2020, time.January, 2, 15, 4, 5, 0, time.UTC),
+			CreatedBy: 5600,
+		},
+		{
+			ID:        222,
+			EntityID:  430214,
+			Action:    "update",
+			CreatedAt: time.Date(2020, time.January, 3, 15, 4, 5, 0, time.UTC),
+			CreatedBy: 5601,
+		},
+	}
+
+	type args struct {
+		campID int
+		entID  int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*EntityLog
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testEntityLogs,
+			args:    args{campID: 5272, entID: 430214},
+			want:    logs,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, partially malformed response, valid args",
+			status:  http.StatusOK,
+			file:    testEntityLogsPartial,
+			args:    args{campID: 5272, entID: 430214},
+			want:    logs,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testEntityLogs,
+			args:    args{campID: -123, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid entID",
+			status:  http.StatusOK,
+			file:    testEntityLogs,
+			args:    args{campID: 5272, entID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Entities.Logs(test.args.campID, test.args.entID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntityService_LogsPages(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"data":[{"id":112,"entity_id":430214,"action":"update"}],"links":{"next":null}}`))
+			return
+		}
+		fmt.Fprintf(w, `{"data":[{"id":111,"entity_id":430214,"action":"create"}],"links":{"next":"%s/campaigns/5272/entities/430214/entity_logs?page=2"}}`, ts.URL)
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	logs, err := c.Entities.Logs(5272, 430214)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logs) != 2 {
+		t.Errorf("got %d EntityLogs, want 2 from both pages", len(logs))
+	}
+}
+
+func TestEntityService_Deleted(t *testing.T) {
+	ents := []*DeletedEntity{
+		{
+			ID:        430214,
+			Name:      "Town Guard",
+			Type:      "character",
+			ChildID:   116623,
+			DeletedAt: time.Date(2020, time.January, 26, 3, 22, 31, 0, time.UTC),
+			DeletedBy: 5600,
+		},
+		{
+			ID:        430215,
+			Name:      "Tavern",
+			Type:      "location",
+			ChildID:   26145,
+			DeletedAt: time.Date(2020, time.January, 27, 8, 15, 0, 0, time.UTC),
+			DeletedBy: 5600,
+		},
+	}
+
+	type args struct {
+		campID int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*DeletedEntity
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testEntityDeleted,
+			args:    args{campID: 5272},
+			want:    ents,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testEntityDeleted,
+			args:    args{campID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Entities.Deleted(test.args.campID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntityService_DeletedPages(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"data":[{"id":430215,"name":"Tavern"}],"links":{"next":null}}`))
+			return
+		}
+		fmt.Fprintf(w, `{"data":[{"id":430214,"name":"Town Guard"}],"links":{"next":"%s/campaigns/5272/recovery?page=2"}}`, ts.URL)
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	ents, err := c.Entities.Deleted(5272)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ents) != 2 {
+		t.Errorf("got %d DeletedEntities, want 2 from both pages", len(ents))
+	}
+}
+
+func TestEntityService_Restore(t *testing.T) {
+	type args struct {
+		campID int
+		entIDs []int
+	}
+	tests := []struct {
+		name     string
+		status   int
+		args     args
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, valid args",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entIDs: []int{430214, 430215}},
+			wantBody: `{"entities":[430214,430215]}`,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, invalid campID",
+			status:   http.StatusOK,
+			args:     args{campID: -123, entIDs: []int{430214}},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusOK, invalid entID",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entIDs: []int{430214, -123}},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusOK, no entIDs",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entIDs: nil},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusNotFound, valid args",
+			status:   http.StatusNotFound,
+			args:     args{campID: 5272, entIDs: []int{430214}},
+			wantBody: `{"entities":[430214]}`,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, "")
+			defer ts.Close()
+
+			err := c.Entities.Restore(test.args.campID, test.args.entIDs...)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+			if test.wantBody != "" && (rec.method != http.MethodPost || rec.url != "/campaigns/5272/recovery") {
+				t.Errorf("got request: <%s %s>, want request: <POST /campaigns/5272/recovery>", rec.method, rec.url)
+			}
+		})
+	}
+}
+
+func TestClient_EntityURL(t *testing.T) {
+	type args struct {
+		campID int
+		entID  int
+	}
+	tests := []struct {
+		name    string
+		opts    []Option
+		args    args
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "Default base URL",
+			opts:    nil,
+			args:    args{campID: 5272, entID: 430214},
+			want:    "https://kanka.io/campaign/5272/entities/430214",
+			wantErr: false,
+		},
+		{
+			name:    "Self-hosted base URL",
+			opts:    []Option{WithBaseURL("https://kanka.example.com")},
+			args:    args{campID: 5272, entID: 430214},
+			want:    "https://kanka.example.com/campaign/5272/entities/430214",
+			wantErr: false,
+		},
+		{
+			name:    "Invalid campID",
+			opts:    nil,
+			args:    args{campID: -123, entID: 430214},
+			want:    "",
+			wantErr: true,
+		},
+		{
+			name:    "Invalid entID",
+			opts:    nil,
+			args:    args{campID: 5272, entID: -123},
+			want:    "",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewClient(testToken, nil, test.opts...)
+
+			got, err := c.EntityURL(test.args.campID, test.args.entID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if got != test.want {
+				t.Errorf("got: <%s>, want: <%s>", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package kanka
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EntityAbility represents a specific ability relating to the parent entity.
+type EntityAbility struct {
+	ID         int        `json:"id"`
+	EntityID   int        `json:"entity_id"`
+	AbilityID  int        `json:"ability_id"`
+	Charges    int        `json:"charges"`
+	Visibility Visibility `json:"visibility"`
+	CreatedAt  time.Time  `json:"created_at"`
+	CreatedBy  int        `json:"created_by"`
+	UpdatedAt  time.Time  `json:"updated_at"`
+	UpdatedBy  int        `json:"updated_by"`
+}
+
+// ResolvedAbility contains an EntityAbility along with the Ability it refers
+// to and the chain of that Ability's parents.
+type ResolvedAbility struct {
+	*EntityAbility
+	Ability *Ability
+	// Parents lists the parent Abilities from the direct parent of Ability to
+	// the root of its ability tree.
+	Parents []*Ability
+}
+
+// EntityAbilityService handles communication with the EntityAbility endpoint.
+type EntityAbilityService service
+
+// Index returns the list of all EntityAbilities for the entity associated
+// with entID in the Campaign associated with campID.
+// If a non-nil time is provided, Index will only return EntityAbilities that
+// have been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityAbilities that were decoded.
+func (es *EntityAbilityService) Index(campID int, entID int, sync *time.Time) ([]*EntityAbility, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(endpointEntity)
+
+	if end, err = end.id(entID); err != nil {
+		return nil, fmt.Errorf("invalid Entity ID: %w", err)
+	}
+	end = end.concat(es.end)
+
+	if sync != nil {
+		end = end.sync(*sync)
+	}
+
+	var wrap response[[]json.RawMessage]
+
+	if err = es.client.get(end, &wrap); err != nil {
+		return nil, fmt.Errorf("cannot get EntityAbility Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	var list []*EntityAbility
+	if err = decodeList(wrap.Data, &list, es.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode EntityAbility Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// IndexResolved returns the list of all EntityAbilities for the entity
+// associated with entID in the Campaign associated with campID along with
+// each referenced Ability and its chain of parents. Every Ability is fetched
+// only once, even if it is shared by several ability trees.
+func (es *EntityAbilityService) IndexResolved(campID int, entID int) ([]*ResolvedAbility, error) {
+	list, err := es.Index(campID, entID, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	cache := make(map[int]*Ability)
+	get := func(id int) (*Ability, error) {
+		if abl, ok := cache[id]; ok {
+			return abl, nil
+		}
+
+		abl, err := es.client.Abilities.Get(campID, id)
+		if err != nil {
+			return nil, err
+		}
+		cache[id] = abl
+
+		return abl, nil
+	}
+
+	res := make([]*ResolvedAbility, 0, len(list))
+	for _, ea := range list {
+		abl, err := get(ea.AbilityID)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve EntityAbility (ID: %d): %w", ea.ID, err)
+		}
+
+		parents, err := es.client.Abilities.parents(abl, get)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve EntityAbility (ID: %d): %w", ea.ID, err)
+		}
+
+		res = append(res, &ResolvedAbility{EntityAbility: ea, Ability: abl, Parents: parents})
+	}
+
+	return res, nil
+}
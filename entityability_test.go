@@ -0,0 +1,143 @@
+package kanka
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestEntityAbilityService_Index(t *testing.T) {
+	abls := []*EntityAbility{
+		{ID: 10, EntityID: 430214, AbilityID: 1},
+		{ID: 11, EntityID: 430214, AbilityID: 2},
+	}
+
+	type args struct {
+		campID int
+		entID  int
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*EntityAbility
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			args:    args{campID: 5272, entID: 430214},
+			want:    abls,
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, empty list, valid args",
+			args:    args{campID: 5272, entID: 430217},
+			want:    []*EntityAbility{},
+			wantErr: false,
+		},
+		{
+			name:    "Invalid campID",
+			args:    args{campID: -123, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid entID",
+			args:    args{campID: 5272, entID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			args:    args{campID: 5272, entID: 999},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, _ := testAbilityTreeClient(t)
+			defer ts.Close()
+
+			got, err := c.EntityAbilities.Index(test.args.campID, test.args.entID, nil)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntityAbilityService_IndexResolved(t *testing.T) {
+	tests := []struct {
+		name        string
+		entID       int
+		wantNames   []string
+		wantParents [][]string
+		wantErr     bool
+	}{
+		{
+			name:        "Shared ability tree",
+			entID:       430214,
+			wantNames:   []string{"Fireball", "Evocation"},
+			wantParents: [][]string{{"Evocation", "Arcane"}, {"Arcane"}},
+			wantErr:     false,
+		},
+		{
+			name:        "No abilities",
+			entID:       430217,
+			wantNames:   []string{},
+			wantParents: [][]string{},
+			wantErr:     false,
+		},
+		{
+			name:    "Cyclic ability tree",
+			entID:   430215,
+			wantErr: true,
+		},
+		{
+			name:    "Missing ability",
+			entID:   430216,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, counts := testAbilityTreeClient(t)
+			defer ts.Close()
+
+			got, err := c.EntityAbilities.IndexResolved(5272, test.entID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if test.wantErr {
+				return
+			}
+
+			names := []string{}
+			parents := [][]string{}
+			for _, r := range got {
+				names = append(names, r.Ability.Name)
+
+				ps := []string{}
+				for _, p := range r.Parents {
+					ps = append(ps, p.Name)
+				}
+				parents = append(parents, ps)
+			}
+			if diff := cmp.Diff(names, test.wantNames); diff != "" {
+				t.Errorf("ability mismatch (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(parents, test.wantParents); diff != "" {
+				t.Errorf("parent mismatch (-want +got):\n%s", diff)
+			}
+
+			for path, n := range counts {
+				if n > 1 {
+					t.Errorf("got <%d> requests to <%s>, want at most 1", n, path)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/Henry-Sarabia/blank"
+)
+
+// AssetType identifies the kind of an entity asset.
+type AssetType int
+
+// Available entity asset types.
+const (
+	AssetFile  AssetType = 1
+	AssetLink  AssetType = 2
+	AssetAlias AssetType = 3
+)
+
+// EntityAsset contains information about a specific entity asset.
+// EntityAsset represents a file, link, or alias relating to the parent entity.
+type EntityAsset struct {
+	SimpleEntityAsset
+	ID        int       `json:"id"`
+	EntityID  int       `json:"entity_id"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy int       `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy int       `json:"updated_by"`
+}
+
+// AssetMetadata contains the additional information of an entity asset, such
+// as the URL of a link.
+type AssetMetadata struct {
+	URL  string `json:"url,omitempty"`
+	Icon string `json:"icon,omitempty"`
+}
+
+// SimpleEntityAsset contains only the simple information about an entity asset.
+// SimpleEntityAsset is primarily used to create new entity assets for posting to Kanka.
+type SimpleEntityAsset struct {
+	Type       AssetType     `json:"type_id"`
+	Name       string        `json:"name"`
+	Metadata   AssetMetadata `json:"metadata"`
+	Visibility Visibility    `json:"visibility,omitempty"`
+}
+
+// MarshalJSON marshals the SimpleEntityAsset into its JSON-encoded form if it
+// has the required populated fields.
+func (sa SimpleEntityAsset) MarshalJSON() ([]byte, error) {
+	if err := requireField("SimpleEntityAsset", "Name", sa.Name); err != nil {
+		return nil, err
+	}
+
+	type alias SimpleEntityAsset
+	return json.Marshal(alias(sa))
+}
+
+// EntityAssetService handles communication with the EntityAsset endpoint.
+type EntityAssetService service
+
+// Index returns the list of all EntityAssets for the entity associated with
+// entID in the Campaign associated with campID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityAssets that were decoded.
+func (es *EntityAssetService) Index(campID int, entID int) ([]*EntityAsset, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(endpointEntity)
+
+	if end, err = end.id(entID); err != nil {
+		return nil, fmt.Errorf("invalid Entity ID: %w", err)
+	}
+	end = end.concat(es.end)
+
+	var wrap response[[]json.RawMessage]
+
+	if err = es.client.get(end, &wrap); err != nil {
+		return nil, fmt.Errorf("cannot get EntityAsset Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	var list []*EntityAsset
+	if err = decodeList(wrap.Data, &list, es.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode EntityAsset Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// IndexAll returns the list of all EntityAssets for the entity associated with
+// entID in the Campaign associated with campID from every page of the list.
+// Each page is a separate request subject to the rate limit of the Client.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityAssets that were decoded.
+func (es *EntityAssetService) IndexAll(campID int, entID int) ([]*EntityAsset, error) {
+	return entityIndexAll[EntityAsset](es.client, campID, entID, es.end, "EntityAsset", nil)
+}
+
+// Create creates a new EntityAsset for the entity associated with entID in the
+// Campaign associated with campID using the provided SimpleEntityAsset data.
+// Create returns the newly created EntityAsset.
+func (es *EntityAssetService) Create(campID int, entID int, asset SimpleEntityAsset) (*EntityAsset, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(endpointEntity)
+
+	if end, err = end.id(entID); err != nil {
+		return nil, fmt.Errorf("invalid Entity ID: %w", err)
+	}
+	end = end.concat(es.end)
+
+	b, err := json.Marshal(asset)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal SimpleEntityAsset: %w", err)
+	}
+
+	var wrap response[*EntityAsset]
+
+	if err = es.client.post(end, bytes.NewReader(b), &wrap); err != nil {
+		return nil, fmt.Errorf("cannot create EntityAsset for Campaign (ID: %d): %w", campID, err)
+	}
+
+	return wrap.Data, nil
+}
+
+// ListAliases returns the list of all aliases, the alternate names used to
+// mention an entity, of the entity associated with entID in the Campaign
+// associated with campID. Aliases are the EntityAssets of type AssetAlias,
+// read from every page of the list.
+func (es *EntityAssetService) ListAliases(campID int, entID int) ([]*EntityAsset, error) {
+	assets, err := es.IndexAll(campID, entID)
+
+	var aliases []*EntityAsset
+	for _, a := range assets {
+		if a.Type == AssetAlias {
+			aliases = append(aliases, a)
+		}
+	}
+
+	return aliases, err
+}
+
+// ListFiles returns the list of all files attached to the entity associated
+// with entID in the Campaign associated with campID. Files are the
+// EntityAssets of type AssetFile, read from every page of the list.
+func (es *EntityAssetService) ListFiles(campID int, entID int) ([]*EntityAsset, error) {
+	assets, err := es.IndexAll(campID, entID)
+
+	var files []*EntityAsset
+	for _, a := range assets {
+		if a.Type == AssetFile {
+			files = append(files, a)
+		}
+	}
+
+	return files, err
+}
+
+// CreateAlias creates a new alias with the provided name for the entity
+// associated with entID in the Campaign associated with campID.
+// CreateAlias returns the newly created alias.
+func (es *EntityAssetService) CreateAlias(campID int, entID int, name string) (*EntityAsset, error) {
+	if blank.Is(name) {
+		return nil, fmt.Errorf("cannot create alias for Entity (ID: %d) with a missing name", entID)
+	}
+
+	return es.Create(campID, entID, SimpleEntityAsset{Type: AssetAlias, Name: name})
+}
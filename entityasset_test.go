@@ -0,0 +1,277 @@
+package kanka
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testEntityAssetIndex  string = "test_data/entityasset_index.json"
+	testEntityAssetCreate string = "test_data/entityasset_create.json"
+)
+
+func TestEntityAssetService_Index(t *testing.T) {
+	assets := []*EntityAsset{
+		{
+			SimpleEntityAsset: SimpleEntityAsset{
+				Type: AssetLink,
+				Name: "Wiki Page",
+				Metadata: AssetMetadata{
+					URL:  "https://example.com/wiki/penny",
+					Icon: "fa-solid fa-link",
+				},
+				Visibility: VisibilityAll,
+			},
+			ID:        111,
+			EntityID:  430214,
+			CreatedBy: 5600,
+			UpdatedBy: 5600,
+		},
+		{
+			SimpleEntityAsset: SimpleEntityAsset{
+				Type:       AssetAlias,
+				Name:       "Pen",
+				Visibility: VisibilityAdmin,
+			},
+			ID:        222,
+			EntityID:  430214,
+			CreatedBy: 5600,
+			UpdatedBy: 5600,
+		},
+	}
+
+	type args struct {
+		campID int
+		entID  int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*EntityAsset
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testEntityAssetIndex,
+			args:    args{campID: 5272, entID: 430214},
+			want:    assets,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testEntityAssetIndex,
+			args:    args{campID: -123, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid entID",
+			status:  http.StatusOK,
+			file:    testEntityAssetIndex,
+			args:    args{campID: 5272, entID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.EntityAssets.Index(test.args.campID, test.args.entID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntityAssetService_Create(t *testing.T) {
+	asset := SimpleEntityAsset{
+		Type: AssetLink,
+		Name: "Character Sheet",
+		Metadata: AssetMetadata{
+			URL: "https://example.com/sheets/penny.pdf",
+		},
+	}
+
+	type args struct {
+		campID int
+		entID  int
+		asset  SimpleEntityAsset
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *EntityAsset
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testEntityAssetCreate,
+			args:    args{campID: 5272, entID: 430214, asset: asset},
+			want:    &EntityAsset{SimpleEntityAsset: asset, ID: 333, EntityID: 430214, CreatedBy: 5600, UpdatedBy: 5600},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testEntityAssetCreate,
+			args:    args{campID: -123, entID: 430214, asset: asset},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid entID",
+			status:  http.StatusOK,
+			file:    testEntityAssetCreate,
+			args:    args{campID: 5272, entID: -123, asset: asset},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, missing name",
+			status:  http.StatusOK,
+			file:    testEntityAssetCreate,
+			args:    args{campID: 5272, entID: 430214, asset: SimpleEntityAsset{Type: AssetLink}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 430214, asset: asset},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 430214, asset: asset},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.EntityAssets.Create(test.args.campID, test.args.entID, test.args.asset)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntityAssetService_ListAliases(t *testing.T) {
+	f, err := os.Open(testEntityAssetIndex)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	c, _ := testClient(http.StatusOK, f)
+
+	got, err := c.EntityAssets.ListAliases(5272, 430214)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*EntityAsset{
+		{
+			SimpleEntityAsset: SimpleEntityAsset{Type: AssetAlias, Name: "Pen", Visibility: VisibilityAdmin},
+			ID:                222,
+			EntityID:          430214,
+			CreatedBy:         5600,
+			UpdatedBy:         5600,
+		},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestEntityAssetService_CreateAlias(t *testing.T) {
+	tests := []struct {
+		name     string
+		alias    string
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "Valid name",
+			alias:    "The Bastard of Winterfell",
+			wantBody: `{"type_id":3,"name":"The Bastard of Winterfell","metadata":{}}`,
+			wantErr:  false,
+		},
+		{
+			name:     "Blank name",
+			alias:    " ",
+			wantBody: "",
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, http.StatusOK, testEntityAssetCreate)
+			defer ts.Close()
+
+			_, err := c.EntityAssets.CreateAlias(5272, 430214, test.alias)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+			if test.wantBody != "" && rec.url != "/campaigns/5272/entities/430214/entity_assets" {
+				t.Errorf("got url: <%s>, want url: <%s>", rec.url, "/campaigns/5272/entities/430214/entity_assets")
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package kanka
+
+import (
+	"fmt"
+	"sync"
+)
+
+// EntityDetails contains the sub-resources of a specific entity, which the Get
+// function of its typed service may not include.
+type EntityDetails struct {
+	Attributes  []*Attribute
+	Relations   []*Relation
+	Inventory   []*EntityInventory
+	EntityNotes []*EntityNote
+	Events      []*EntityEvent
+	Files       []*EntityAsset
+	Tags        []*EntityTag
+}
+
+// EntityDetails returns the attributes, relations, inventory, entity notes,
+// entity events, files, and tags of the entity associated with entID in the
+// Campaign associated with campID. The sub-resources are retrieved
+// concurrently, each following every page of its list with separate
+// requests subject to the timeout and rate limit of the Client, if any.
+// Sub-resources that cannot be retrieved are reported in an error wrapping
+// TypeErrors, keyed by kind such as "attributes", alongside the EntityDetails
+// that were retrieved. A sub-resource whose records could only partly be
+// decoded is kept with the records that were decoded.
+func (c *Client) EntityDetails(campID int, entID int) (*EntityDetails, error) {
+	if _, err := EndpointCampaign.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	if _, err := endpointEntity.id(entID); err != nil {
+		return nil, fmt.Errorf("invalid Entity ID: %w", err)
+	}
+
+	d := &EntityDetails{}
+	fetches := map[string]func() error{
+		"attributes": func() (err error) {
+			d.Attributes, err = c.Attributes.IndexAll(campID, entID, nil)
+			return err
+		},
+		"relations": func() (err error) {
+			d.Relations, err = c.Relations.IndexAll(campID, entID, nil)
+			return err
+		},
+		"inventory": func() (err error) {
+			d.Inventory, err = c.EntityInventories.IndexAll(campID, entID, nil)
+			return err
+		},
+		"entity_notes": func() (err error) {
+			d.EntityNotes, err = c.EntityNotes.IndexAll(campID, entID, nil)
+			return err
+		},
+		"entity_events": func() (err error) {
+			d.Events, err = c.EntityEvents.IndexAll(campID, entID, nil)
+			return err
+		},
+		"files": func() (err error) {
+			d.Files, err = c.EntityAssets.ListFiles(campID, entID)
+			return err
+		},
+		"tags": func() (err error) {
+			d.Tags, err = c.EntityTags.IndexAll(campID, entID, nil)
+			return err
+		},
+	}
+
+	if errs := details(fetches); len(errs) > 0 {
+		return d, fmt.Errorf("cannot get every detail of Entity (ID: %d) from Campaign (ID: %d): %w", entID, campID, errs)
+	}
+
+	return d, nil
+}
+
+// details calls each of the provided fetch functions concurrently and returns
+// the errors encountered keyed by kind. A fetch whose records could only
+// partly be decoded is reported without discarding the decoded records.
+func details(fetches map[string]func() error) TypeErrors {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		errs = make(TypeErrors)
+	)
+
+	for kind, fetch := range fetches {
+		wg.Add(1)
+		go func(kind string, fetch func() error) {
+			defer wg.Done()
+
+			if err := fetch(); err != nil {
+				mu.Lock()
+				errs[kind] = err
+				mu.Unlock()
+			}
+		}(kind, fetch)
+	}
+	wg.Wait()
+
+	return errs
+}
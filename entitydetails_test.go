@@ -0,0 +1,142 @@
+package kanka
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_EntityDetails(t *testing.T) {
+	bodies := map[string]string{
+		"attributes":    `{"data":[{"id":1,"name":"Age","value":"16"}]}`,
+		"relations":     `{"data":[{"id":2,"relation":"Brother","target_id":5}]}`,
+		"inventory":     `{"data":[{"id":3,"name":"Longclaw","amount":1}]}`,
+		"entity_notes":  `{"data":[{"id":4,"name":"Oath","entry":"Night gathers"}]}`,
+		"entity_events": `{"data":[{"id":5,"calendar_id":1,"day":1,"month":1,"year":298}]}`,
+		"entity_assets": `{"data":[{"id":6,"type_id":1,"name":"map.pdf"},{"id":7,"type_id":3,"name":"Lord Snow"}]}`,
+		"entity_tags":   `{"data":[{"id":8,"entity_id":430214,"tag_id":35115}]}`,
+	}
+
+	tests := []struct {
+		name     string
+		fail     string
+		wantKeys []string
+		wantErr  bool
+	}{
+		{
+			name:     "All details",
+			fail:     "",
+			wantKeys: nil,
+			wantErr:  false,
+		},
+		{
+			name:     "Failed relations",
+			fail:     "relations",
+			wantKeys: []string{"relations"},
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				mu    sync.Mutex
+				calls = make(map[string]int)
+			)
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				kind := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+				mu.Lock()
+				calls[kind]++
+				mu.Unlock()
+
+				if !strings.HasPrefix(r.URL.Path, "/campaigns/5272/entities/430214/") || kind == test.fail {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+				w.Write([]byte(bodies[kind]))
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			d, err := c.EntityDetails(5272, 430214)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			var keys []string
+			var typeErrs TypeErrors
+			if errors.As(err, &typeErrs) {
+				for k := range typeErrs {
+					keys = append(keys, k)
+				}
+			}
+			if diff := cmp.Diff(keys, test.wantKeys); diff != "" {
+				t.Errorf(diff)
+			}
+
+			for kind := range bodies {
+				if calls[kind] != 1 {
+					t.Errorf("got %d requests for %s, want 1", calls[kind], kind)
+				}
+			}
+
+			if len(d.Attributes) != 1 || len(d.Inventory) != 1 || len(d.EntityNotes) != 1 || len(d.Events) != 1 || len(d.Tags) != 1 {
+				t.Errorf("got details: <%+v>, want one of each", d)
+			}
+			if len(d.Files) != 1 || d.Files[0].Name != "map.pdf" {
+				t.Errorf("got Files: <%v>, want only map.pdf", d.Files)
+			}
+			if wantRels := 1 - len(test.wantKeys); len(d.Relations) != wantRels {
+				t.Errorf("got %d Relations, want %d", len(d.Relations), wantRels)
+			}
+		})
+	}
+}
+
+func TestClient_EntityDetailsPages(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		kind := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		switch {
+		case kind != "attributes":
+			w.Write([]byte(`{"data":[]}`))
+		case r.URL.Query().Get("page") == "2":
+			w.Write([]byte(`{"data":[{"id":2,"name":"Title","value":"Lord Commander"}],"links":{"next":null}}`))
+		default:
+			fmt.Fprintf(w, `{"data":[{"id":1,"name":"Age","value":"16"}],"links":{"next":"%s/campaigns/5272/entities/430214/attributes?page=2"}}`, ts.URL)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	d, err := c.EntityDetails(5272, 430214)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(d.Attributes) != 2 {
+		t.Errorf("got %d Attributes, want 2 from both pages", len(d.Attributes))
+	}
+}
+
+func TestClient_EntityDetailsInvalidID(t *testing.T) {
+	c := NewClient(testToken, nil)
+
+	if _, err := c.EntityDetails(-1, 430214); err == nil {
+		t.Error("got nil error for invalid Campaign ID, want error")
+	}
+	if _, err := c.EntityDetails(5272, -1); err == nil {
+		t.Error("got nil error for invalid Entity ID, want error")
+	}
+}
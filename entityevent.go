@@ -25,16 +25,17 @@ type EntityEvent struct {
 // SimpleEntityEvent contains only the simple information about an entity event.
 // SimpleEntityEvent is primarily used to create new entity events for posting to Kanka.
 type SimpleEntityEvent struct {
-	Day            int    `json:"day"`
-	Month          int    `json:"month"`
-	Year           int    `json:"year"`
-	Length         int    `json:"length"`
-	EntityID       int    `json:"entity_id"`
-	Colour         string `json:"colour,omitempty"`
-	Comment        string `json:"comment,omitempty"`
-	IsRecurring    bool   `json:"is_recurring,omitempty"`
-	IsPrivate      bool   `json:"is_private,omitempty"`
-	RecurringUntil int    `json:"recurring_until,omitempty"`
+	Day                  int    `json:"day"`
+	Month                int    `json:"month"`
+	Year                 int    `json:"year"`
+	Length               int    `json:"length"`
+	EntityID             int    `json:"entity_id"`
+	Colour               string `json:"colour,omitempty"`
+	Comment              string `json:"comment,omitempty"`
+	IsRecurring          bool   `json:"is_recurring,omitempty"`
+	IsPrivate            bool   `json:"is_private,omitempty"`
+	RecurringUntil       int    `json:"recurring_until,omitempty"`
+	RecurringPeriodicity string `json:"recurring_periodicity,omitempty"`
 }
 
 // EntityEvents wraps a list of entity events.
@@ -44,6 +45,26 @@ type EntityEvents struct {
 	Sync time.Time      `json:"sync"`
 }
 
+// UnmarshalJSON unmarshals the JSON-encoded data into the EntityEvents. A null value
+// or a null list is unmarshaled into an empty list rather than a nil one.
+func (es *EntityEvents) UnmarshalJSON(b []byte) error {
+	type alias EntityEvents
+	var al alias
+
+	if !isNull(b) {
+		if err := json.Unmarshal(b, &al); err != nil {
+			return err
+		}
+	}
+
+	if al.Data == nil {
+		al.Data = []*EntityEvent{}
+	}
+
+	*es = EntityEvents(al)
+	return nil
+}
+
 // EntityEventService handles communication with the EntityEvent endpoint.
 type EntityEventService service
 
@@ -51,6 +72,8 @@ type EntityEventService service
 // entID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return EntityEvents that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityEvents that were decoded.
 func (es *EntityEventService) Index(campID int, entID int, sync *time.Time) ([]*EntityEvent, error) {
 	var err error
 	end := EndpointCampaign
@@ -69,15 +92,29 @@ func (es *EntityEventService) Index(campID int, entID int, sync *time.Time) ([]*
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*EntityEvent `json:"data"`
-	}
+	var wrap response[[]json.RawMessage]
 
 	if err = es.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get EntityEvent Index from Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	var list []*EntityEvent
+	if err = decodeList(wrap.Data, &list, es.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode EntityEvent Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// IndexAll returns the list of all EntityEvents for the entity associated with
+// entID in the Campaign associated with campID from every page of the list.
+// Each page is a separate request subject to the rate limit of the Client.
+// If a non-nil time is provided, IndexAll will only return EntityEvents that
+// have been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityEvents that were decoded.
+func (es *EntityEventService) IndexAll(campID int, entID int, sync *time.Time) ([]*EntityEvent, error) {
+	return entityIndexAll[EntityEvent](es.client, campID, entID, es.end, "EntityEvent", sync)
 }
 
 // Get returns the EntityEvent associated with evtID for the entity associated
@@ -100,9 +137,7 @@ func (es *EntityEventService) Get(campID int, entID int, evtID int) (*EntityEven
 		return nil, fmt.Errorf("invalid EntityEvent ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *EntityEvent `json:"data"`
-	}
+	var wrap response[*EntityEvent]
 
 	if err = es.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get EntityEvent (ID: %d) from Campaign (ID: %d): %w", evtID, campID, err)
@@ -133,9 +168,7 @@ func (es *EntityEventService) Create(campID int, entID int, evt SimpleEntityEven
 		return nil, fmt.Errorf("cannot marshal SimpleEntityEvent: %w", err)
 	}
 
-	var wrap struct {
-		Data *EntityEvent `json:"data"`
-	}
+	var wrap response[*EntityEvent]
 
 	if err = es.client.post(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot create EntityEvent for Campaign (ID: %d): %w", campID, err)
@@ -171,9 +204,7 @@ func (es *EntityEventService) Update(campID int, entID int, evtID int, evt Simpl
 		return nil, fmt.Errorf("cannot marshal SimpleEntityEvent: %w", err)
 	}
 
-	var wrap struct {
-		Data *EntityEvent `json:"data"`
-	}
+	var wrap response[*EntityEvent]
 
 	if err = es.client.put(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot update EntityEvent for Campaign (ID: %d): '%w'", campID, err)
@@ -1,6 +1,9 @@
 package kanka
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // EntityFiles wraps a list of entity files.
 // EntityFiles exists to satisfy the API's JSON structure.
@@ -9,6 +12,26 @@ type EntityFiles struct {
 	Sync time.Time     `json:"sync"`
 }
 
+// UnmarshalJSON unmarshals the JSON-encoded data into the EntityFiles. A null value
+// or a null list is unmarshaled into an empty list rather than a nil one.
+func (es *EntityFiles) UnmarshalJSON(b []byte) error {
+	type alias EntityFiles
+	var al alias
+
+	if !isNull(b) {
+		if err := json.Unmarshal(b, &al); err != nil {
+			return err
+		}
+	}
+
+	if al.Data == nil {
+		al.Data = []*EntityFile{}
+	}
+
+	*es = EntityFiles(al)
+	return nil
+}
+
 // EntityFile represents a specific calendar event relating to the parent
 // entity.
 type EntityFile struct {
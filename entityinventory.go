@@ -3,6 +3,7 @@ package kanka
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
 )
@@ -21,9 +22,12 @@ type EntityInventory struct {
 // SimpleEntityInventory contains only the simple information about an entity inventory.
 // SimpleEntityInventory is primarily used to create new entity inventories for posting to Kanka.
 type SimpleEntityInventory struct {
-	EntityID   int    `json:"entity_id"`
-	ItemID     int    `json:"item_id"`
-	Amount     int    `json:"amount"`
+	EntityID int `json:"entity_id"`
+	ItemID   int `json:"item_id"`
+	Amount   int `json:"amount"`
+	// Position is where the item is kept, such as "Equipped" or "Backpack".
+	// Kanka groups an inventory by Position; it is not a sort index, and
+	// the API offers no way to choose the order of the items.
 	Position   string `json:"position,omitempty"`
 	Visibility string `json:"visibility,omitempty"`
 	IsPrivate  bool   `json:"is_private,omitempty"`
@@ -36,6 +40,8 @@ type EntityInventoryService service
 // entID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return EntityInventories that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityInventories that were decoded.
 func (es *EntityInventoryService) Index(campID int, entID int, sync *time.Time) ([]*EntityInventory, error) {
 	var err error
 	end := EndpointCampaign
@@ -54,15 +60,30 @@ func (es *EntityInventoryService) Index(campID int, entID int, sync *time.Time)
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*EntityInventory `json:"data"`
-	}
+	var wrap response[[]json.RawMessage]
 
 	if err = es.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get EntityInventory Index from Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	var list []*EntityInventory
+	if err = decodeList(wrap.Data, &list, es.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode EntityInventory Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// IndexAll returns the list of all EntityInventories for the entity associated
+// with entID in the Campaign associated with campID from every page of the
+// list. Each page is a separate request subject to the rate limit of the
+// Client.
+// If a non-nil time is provided, IndexAll will only return EntityInventories
+// that have been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityInventories that were decoded.
+func (es *EntityInventoryService) IndexAll(campID int, entID int, sync *time.Time) ([]*EntityInventory, error) {
+	return entityIndexAll[EntityInventory](es.client, campID, entID, es.end, "EntityInventory", sync)
 }
 
 // Create creates a new EntityInventory for the entity associated with entID in the
@@ -87,9 +108,7 @@ func (es *EntityInventoryService) Create(campID int, entID int, inv SimpleEntity
 		return nil, fmt.Errorf("cannot marshal SimpleEntityInventory: %w", err)
 	}
 
-	var wrap struct {
-		Data *EntityInventory `json:"data"`
-	}
+	var wrap response[*EntityInventory]
 
 	if err = es.client.post(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot create EntityInventory for Campaign (ID: %d): %w", campID, err)
@@ -125,9 +144,7 @@ func (es *EntityInventoryService) Update(campID int, entID int, invID int, inv S
 		return nil, fmt.Errorf("cannot marshal SimpleEntityInventory: %w", err)
 	}
 
-	var wrap struct {
-		Data *EntityInventory `json:"data"`
-	}
+	var wrap response[*EntityInventory]
 
 	if err = es.client.put(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot update EntityInventory for Campaign (ID: %d): '%w'", campID, err)
@@ -162,3 +179,61 @@ func (es *EntityInventoryService) Delete(campID int, entID int, invID int) error
 
 	return nil
 }
+
+// Transfer moves the provided amount of the EntityInventory associated with
+// invID from the entity associated with fromID to the entity associated with
+// toID in the Campaign associated with campID. The amount must be positive and
+// no greater than the amount held. Kanka has no endpoint for moving inventory,
+// so the item is first added to the target entity and then removed from the
+// source entity, or only reduced there if part of it is kept. If the removal
+// fails, the addition is deleted again so that the item is never duplicated.
+// Transfer returns the newly created EntityInventory of the target entity.
+func (es *EntityInventoryService) Transfer(campID int, fromID int, toID int, invID int, amount int) (*EntityInventory, error) {
+	invs, err := es.IndexAll(campID, fromID, nil)
+	var recErrs RecordErrors
+	if err != nil && !errors.As(err, &recErrs) {
+		return nil, fmt.Errorf("cannot find EntityInventory (ID: %d) to transfer: %w", invID, err)
+	}
+
+	var src *EntityInventory
+	for _, inv := range invs {
+		if inv.ID == invID {
+			src = inv
+			break
+		}
+	}
+	if src == nil {
+		return nil, fmt.Errorf("cannot find EntityInventory (ID: %d) of Entity (ID: %d) in Campaign (ID: %d): %w", invID, fromID, campID, ErrNotFound)
+	}
+
+	if amount <= 0 || amount > src.Amount {
+		return nil, fmt.Errorf("cannot transfer %d of EntityInventory (ID: %d) holding %d", amount, invID, src.Amount)
+	}
+
+	moved := src.SimpleEntityInventory
+	moved.EntityID = toID
+	moved.Amount = amount
+
+	dst, err := es.Create(campID, toID, moved)
+	if err != nil {
+		return nil, fmt.Errorf("cannot transfer EntityInventory (ID: %d) to Entity (ID: %d): %w", invID, toID, err)
+	}
+
+	if amount == src.Amount {
+		err = es.Delete(campID, fromID, invID)
+	} else {
+		kept := src.SimpleEntityInventory
+		kept.Amount -= amount
+		_, err = es.Update(campID, fromID, invID, kept)
+	}
+
+	if err != nil {
+		if derr := es.Delete(campID, toID, dst.ID); derr != nil {
+			return dst, fmt.Errorf("cannot transfer EntityInventory (ID: %d) from Entity (ID: %d): %w (rollback failed: %v)", invID, fromID, err, derr)
+		}
+
+		return nil, fmt.Errorf("cannot transfer EntityInventory (ID: %d) from Entity (ID: %d): %w", invID, fromID, err)
+	}
+
+	return dst, nil
+}
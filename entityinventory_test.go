@@ -1,8 +1,13 @@
 package kanka
 
 import (
+	"errors"
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -478,3 +483,154 @@ func TestEntityInventoryService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestEntityInventoryService_Transfer(t *testing.T) {
+	tests := []struct {
+		name      string
+		amount    int
+		failSrc   bool
+		wantCalls []string
+		wantErr   bool
+	}{
+		{
+			name:    "Whole amount",
+			amount:  3,
+			failSrc: false,
+			wantCalls: []string{
+				"GET /campaigns/5272/entities/1/inventory",
+				"POST /campaigns/5272/entities/2/inventory",
+				"DELETE /campaigns/5272/entities/1/inventory/10",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Partial amount",
+			amount:  1,
+			failSrc: false,
+			wantCalls: []string{
+				"GET /campaigns/5272/entities/1/inventory",
+				"POST /campaigns/5272/entities/2/inventory",
+				"PUT /campaigns/5272/entities/1/inventory/10",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "Rollback",
+			amount:  3,
+			failSrc: true,
+			wantCalls: []string{
+				"GET /campaigns/5272/entities/1/inventory",
+				"POST /campaigns/5272/entities/2/inventory",
+				"DELETE /campaigns/5272/entities/1/inventory/10",
+				"DELETE /campaigns/5272/entities/2/inventory/20",
+			},
+			wantErr: true,
+		},
+		{
+			name:      "Excessive amount",
+			amount:    4,
+			failSrc:   false,
+			wantCalls: []string{"GET /campaigns/5272/entities/1/inventory"},
+			wantErr:   true,
+		},
+		{
+			name:      "Zero amount",
+			amount:    0,
+			failSrc:   false,
+			wantCalls: []string{"GET /campaigns/5272/entities/1/inventory"},
+			wantErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls []string
+			var body string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.Method+" "+r.URL.Path)
+
+				switch r.Method {
+				case http.MethodGet:
+					w.Write([]byte(`{"data":[{"id":9,"entity_id":1,"item_id":40,"amount":1},{"id":10,"entity_id":1,"item_id":41,"amount":3,"position":"Backpack"}]}`))
+				case http.MethodPost:
+					b, _ := ioutil.ReadAll(r.Body)
+					body = string(b)
+					w.Write([]byte(`{"data":{"id":20,"entity_id":2,"item_id":41,"amount":3}}`))
+				case http.MethodPut:
+					b, _ := ioutil.ReadAll(r.Body)
+					if want := `"amount":2`; !strings.Contains(string(b), want) {
+						t.Errorf("got update body: <%s>, want body containing: <%s>", b, want)
+					}
+					w.Write([]byte(`{"data":{"id":10,"entity_id":1,"item_id":41,"amount":2}}`))
+				case http.MethodDelete:
+					if test.failSrc && strings.HasSuffix(r.URL.Path, "/10") {
+						w.WriteHeader(http.StatusInternalServerError)
+						return
+					}
+					w.WriteHeader(http.StatusNoContent)
+				}
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			_, err := c.EntityInventories.Transfer(5272, 1, 2, 10, test.amount)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(calls, test.wantCalls); diff != "" {
+				t.Errorf(diff)
+			}
+			if want := fmt.Sprintf(`{"entity_id":2,"item_id":41,"amount":%d,"position":"Backpack"}`, test.amount); body != "" && body != want {
+				t.Errorf("got create body: <%s>, want create body: <%s>", body, want)
+			}
+		})
+	}
+}
+
+func TestEntityInventoryService_TransferPages(t *testing.T) {
+	var calls []string
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, r.Method+" "+r.URL.RequestURI())
+
+		switch {
+		case r.Method == http.MethodPost:
+			w.Write([]byte(`{"data":{"id":20,"entity_id":2,"item_id":41,"amount":3}}`))
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Query().Get("page") == "2":
+			w.Write([]byte(`{"data":[{"id":10,"entity_id":1,"item_id":41,"amount":3}],"links":{"next":null}}`))
+		default:
+			fmt.Fprintf(w, `{"data":[{"id":"nine","entity_id":1,"item_id":40,"amount":1}],"links":{"next":"%s/campaigns/5272/entities/1/inventory?page=2"}}`, ts.URL)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	if _, err := c.EntityInventories.Transfer(5272, 1, 2, 10, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"GET /campaigns/5272/entities/1/inventory?related=1",
+		"GET /campaigns/5272/entities/1/inventory?page=2&related=1",
+		"POST /campaigns/5272/entities/2/inventory",
+		"DELETE /campaigns/5272/entities/1/inventory/10",
+	}
+	if diff := cmp.Diff(calls, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestEntityInventoryService_TransferMissing(t *testing.T) {
+	c, ts, _ := testRecordClient(t, http.StatusOK, testEntityInventoryIndex)
+	defer ts.Close()
+
+	_, err := c.EntityInventories.Transfer(5272, 1, 2, 999999, 1)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("got err: <%v>, want err: <%v>", err, ErrNotFound)
+	}
+}
@@ -3,10 +3,9 @@ package kanka
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // EntityNote contains information about a specific entity note.
@@ -24,18 +23,25 @@ type EntityNote struct {
 // SimpleEntityNote contains only the simple information about an entity note.
 // SimpleEntityNote is primarily used to create new entity notes for posting to Kanka.
 type SimpleEntityNote struct {
-	Name       string `json:"name"`
-	EntityID   int    `json:"entity_id"`
-	Entry      string `json:"entry,omitempty"`
-	IsPrivate  bool   `json:"is_private,omitempty"`
-	Visibility string `json:"visibility,omitempty"`
+	Name        string           `json:"name"`
+	EntityID    int              `json:"entity_id"`
+	Entry       string           `json:"entry,omitempty"`
+	IsPrivate   bool             `json:"is_private,omitempty"`
+	Visibility  Visibility       `json:"visibility,omitempty"`
+	Permissions []NotePermission `json:"permissions,omitempty"`
+}
+
+// NotePermission grants the campaign role associated with RoleID access to
+// view an entity note.
+type NotePermission struct {
+	RoleID int `json:"role_id"`
 }
 
 // MarshalJSON marshals the SimpleEntityNote into its JSON-encoded form if it
 // has the required populated fields.
 func (se SimpleEntityNote) MarshalJSON() ([]byte, error) {
-	if blank.Is(se.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleEntityNote into JSON with a missing Name")
+	if err := requireField("SimpleEntityNote", "Name", se.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleEntityNote
@@ -49,6 +55,26 @@ type EntityNotes struct {
 	Sync time.Time    `json:"sync"`
 }
 
+// UnmarshalJSON unmarshals the JSON-encoded data into the EntityNotes. A null value
+// or a null list is unmarshaled into an empty list rather than a nil one.
+func (es *EntityNotes) UnmarshalJSON(b []byte) error {
+	type alias EntityNotes
+	var al alias
+
+	if !isNull(b) {
+		if err := json.Unmarshal(b, &al); err != nil {
+			return err
+		}
+	}
+
+	if al.Data == nil {
+		al.Data = []EntityNote{}
+	}
+
+	*es = EntityNotes(al)
+	return nil
+}
+
 // EntityNoteService handles communication with the EntityNote endpoint.
 type EntityNoteService service
 
@@ -56,6 +82,8 @@ type EntityNoteService service
 // entID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return EntityNotes that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityNotes that were decoded.
 func (es *EntityNoteService) Index(campID int, entID int, sync *time.Time) ([]*EntityNote, error) {
 	var err error
 	end := EndpointCampaign
@@ -74,15 +102,57 @@ func (es *EntityNoteService) Index(campID int, entID int, sync *time.Time) ([]*E
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*EntityNote `json:"data"`
-	}
+	var wrap response[[]json.RawMessage]
 
 	if err = es.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get EntityNote Index from Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	var list []*EntityNote
+	if err = decodeList(wrap.Data, &list, es.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode EntityNote Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// IndexVisible returns the list of EntityNotes for the entity associated with
+// entID in the Campaign associated with campID that can be viewed by a viewer
+// at the provided visibility level. For example, a viewer level of
+// VisibilityMembers returns the notes visible to all campaign members, but not
+// those restricted to admins. Private notes and notes restricted to roles are
+// only returned to admin viewers, as with CanView; use CanView to filter the
+// notes for a specific role.
+// If a non-nil time is provided, IndexVisible will only return EntityNotes
+// that have been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the visible EntityNotes that were decoded.
+func (es *EntityNoteService) IndexVisible(campID int, entID int, viewer Visibility, sync *time.Time) ([]*EntityNote, error) {
+	notes, err := es.Index(campID, entID, sync)
+	var recErrs RecordErrors
+	if err != nil && !errors.As(err, &recErrs) {
+		return nil, err
+	}
+
+	var vis []*EntityNote
+	for _, n := range notes {
+		if noteVisibleTo(viewer, 0, n) {
+			vis = append(vis, n)
+		}
+	}
+
+	return vis, err
+}
+
+// IndexAll returns the list of all EntityNotes for the entity associated with
+// entID in the Campaign associated with campID from every page of the list.
+// Each page is a separate request subject to the rate limit of the Client.
+// If a non-nil time is provided, IndexAll will only return EntityNotes that
+// have been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityNotes that were decoded.
+func (es *EntityNoteService) IndexAll(campID int, entID int, sync *time.Time) ([]*EntityNote, error) {
+	return entityIndexAll[EntityNote](es.client, campID, entID, es.end, "EntityNote", sync)
 }
 
 // Get returns the EntityNote associated with evtID for the entity associated
@@ -105,9 +175,7 @@ func (es *EntityNoteService) Get(campID int, entID int, evtID int) (*EntityNote,
 		return nil, fmt.Errorf("invalid EntityNote ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *EntityNote `json:"data"`
-	}
+	var wrap response[*EntityNote]
 
 	if err = es.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get EntityNote (ID: %d) from Campaign (ID: %d): %w", evtID, campID, err)
@@ -138,9 +206,7 @@ func (es *EntityNoteService) Create(campID int, entID int, note SimpleEntityNote
 		return nil, fmt.Errorf("cannot marshal SimpleEntityNote (Name: %s): %w", note.Name, err)
 	}
 
-	var wrap struct {
-		Data *EntityNote `json:"data"`
-	}
+	var wrap response[*EntityNote]
 
 	if err = es.client.post(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot create EntityNote (Name: %s) for Campaign (ID: %d): %w", note.Name, campID, err)
@@ -149,6 +215,25 @@ func (es *EntityNoteService) Create(campID int, entID int, note SimpleEntityNote
 	return wrap.Data, nil
 }
 
+// CreateWithVisibility creates a new EntityNote for the entity associated
+// with entID in the Campaign associated with campID using the provided
+// SimpleEntityNote data, visible only to the provided audience. The audience
+// replaces any Visibility and Permissions set on the SimpleEntityNote.
+// CreateWithVisibility returns the newly created EntityNote.
+func (es *EntityNoteService) CreateWithVisibility(campID int, entID int, note SimpleEntityNote, vis NoteVisibility) (*EntityNote, error) {
+	if err := vis.validate(); err != nil {
+		return nil, fmt.Errorf("invalid visibility for EntityNote (Name: %s): %w", note.Name, err)
+	}
+
+	note.Visibility = vis.level
+	note.Permissions = nil
+	for _, id := range vis.roles {
+		note.Permissions = append(note.Permissions, NotePermission{RoleID: id})
+	}
+
+	return es.Create(campID, entID, note)
+}
+
 // Update updates an existing EntityNote associated with noteID for the entity
 // associated with entID from the Campaign associated with campID using the
 // provided SimpleEntityNote data.
@@ -176,9 +261,7 @@ func (es *EntityNoteService) Update(campID int, entID int, noteID int, note Simp
 		return nil, fmt.Errorf("cannot marshal SimpleEntityNote (Name: %s): %w", note.Name, err)
 	}
 
-	var wrap struct {
-		Data *EntityNote `json:"data"`
-	}
+	var wrap response[*EntityNote]
 
 	if err = es.client.put(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot update EntityNote (Name: %s) for Campaign (ID: %d): '%w'", note.Name, campID, err)
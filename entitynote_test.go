@@ -10,10 +10,12 @@ import (
 )
 
 const (
-	testEntityNoteIndex  string = "test_data/entitynote_index.json"
-	testEntityNoteGet    string = "test_data/entitynote_get.json"
-	testEntityNoteCreate string = "test_data/entitynote_create.json"
-	testEntityNoteUpdate string = "test_data/entitynote_update.json"
+	testEntityNoteIndex          string = "test_data/entitynote_index.json"
+	testEntityNoteGet            string = "test_data/entitynote_get.json"
+	testEntityNoteCreate         string = "test_data/entitynote_create.json"
+	testEntityNoteUpdate         string = "test_data/entitynote_update.json"
+	testEntityNoteVisible        string = "test_data/entitynote_visible.json"
+	testEntityNoteVisiblePartial string = "test_data/entitynote_visible_partial.json"
 )
 
 func TestEntityNoteService_Index(t *testing.T) {
@@ -603,3 +605,193 @@ func TestEntityNoteService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestEntityNoteService_IndexVisible(t *testing.T) {
+	note := func(name string, vis Visibility) *EntityNote {
+		return &EntityNote{SimpleEntityNote: SimpleEntityNote{Name: name, EntityID: 111, Visibility: vis}}
+	}
+	memories := note("Memories", VisibilityAll)
+	secrets := note("Secrets", VisibilityAdmin)
+	rumors := note("Rumors", VisibilityMembers)
+	legends := note("Legends", "")
+	plots := note("Plots", VisibilityAll)
+	plots.IsPrivate = true
+	pacts := note("Pacts", "")
+	pacts.Permissions = []NotePermission{{RoleID: 12}}
+	ledger := note("Ledger", VisibilityAll)
+
+	type args struct {
+		campID int
+		entID  int
+		viewer Visibility
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*EntityNote
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, public viewer",
+			status:  http.StatusOK,
+			file:    testEntityNoteVisible,
+			args:    args{campID: 5272, entID: 111, viewer: VisibilityAll},
+			want:    []*EntityNote{memories, legends, ledger},
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, valid response, member viewer",
+			status:  http.StatusOK,
+			file:    testEntityNoteVisible,
+			args:    args{campID: 5272, entID: 111, viewer: VisibilityMembers},
+			want:    []*EntityNote{memories, rumors, legends, ledger},
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, valid response, admin viewer",
+			status:  http.StatusOK,
+			file:    testEntityNoteVisible,
+			args:    args{campID: 5272, entID: 111, viewer: VisibilityAdmin},
+			want:    []*EntityNote{memories, secrets, rumors, legends, plots, pacts, ledger},
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, valid response, unknown viewer",
+			status:  http.StatusOK,
+			file:    testEntityNoteVisible,
+			args:    args{campID: 5272, entID: 111, viewer: "nobody"},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, partially malformed response, member viewer",
+			status:  http.StatusOK,
+			file:    testEntityNoteVisiblePartial,
+			args:    args{campID: 5272, entID: 111, viewer: VisibilityMembers},
+			want:    []*EntityNote{memories},
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testEntityNoteVisible,
+			args:    args{campID: -123, entID: 111, viewer: VisibilityAll},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 111, viewer: VisibilityAll},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.EntityNotes.IndexVisible(test.args.campID, test.args.entID, test.args.viewer, nil)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntityNoteService_CreateWithVisibility(t *testing.T) {
+	note := SimpleEntityNote{
+		Name:       "Secret Plans",
+		EntityID:   430214,
+		Visibility: VisibilityAll,
+	}
+
+	type args struct {
+		campID int
+		entID  int
+		vis    NoteVisibility
+	}
+	tests := []struct {
+		name     string
+		status   int
+		args     args
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, named level",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entID: 430214, vis: VisibleAt(VisibilityMembers)},
+			wantBody: `{"name":"Secret Plans","entity_id":430214,"visibility":"members"}`,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, role IDs",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entID: 430214, vis: VisibleToRoles(12, 34)},
+			wantBody: `{"name":"Secret Plans","entity_id":430214,"visibility":"admin","permissions":[{"role_id":12},{"role_id":34}]}`,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, no role IDs",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entID: 430214, vis: VisibleToRoles()},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusOK, invalid role ID",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entID: 430214, vis: VisibleToRoles(12, -34)},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusOK, unknown level",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entID: 430214, vis: VisibleAt("everyone")},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusOK, zero visibility",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entID: 430214, vis: NoteVisibility{}},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusOK, invalid campID",
+			status:   http.StatusOK,
+			args:     args{campID: -123, entID: 430214, vis: VisibleAt(VisibilityMembers)},
+			wantBody: "",
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, testEntityNoteCreate)
+			defer ts.Close()
+
+			_, err := c.EntityNotes.CreateWithVisibility(test.args.campID, test.args.entID, note, test.args.vis)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+		})
+	}
+}
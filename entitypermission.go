@@ -0,0 +1,130 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// PermissionAction identifies the action an entity permission grants or
+// denies.
+type PermissionAction int
+
+// Available entity permission actions.
+const (
+	PermissionRead        PermissionAction = 1
+	PermissionEdit        PermissionAction = 2
+	PermissionAdd         PermissionAction = 3
+	PermissionDelete      PermissionAction = 4
+	PermissionPosts       PermissionAction = 5
+	PermissionPermissions PermissionAction = 6
+)
+
+// EntityPermission contains information about a specific entity permission.
+// EntityPermission grants or denies a campaign role or a campaign member an
+// action on the parent entity, overriding the permissions of the campaign.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/entity-permissions
+type EntityPermission struct {
+	SimpleEntityPermission
+	ID       int `json:"id"`
+	EntityID int `json:"entity_id"`
+}
+
+// SimpleEntityPermission contains only the simple information about an entity
+// permission. Exactly one of UserID or RoleID must be set.
+// SimpleEntityPermission is primarily used to set entity permissions for
+// posting to Kanka.
+type SimpleEntityPermission struct {
+	UserID int              `json:"user_id,omitempty"`
+	RoleID int              `json:"campaign_role_id,omitempty"`
+	Action PermissionAction `json:"action"`
+	Access bool             `json:"access"`
+}
+
+// MarshalJSON marshals the SimpleEntityPermission into its JSON-encoded form
+// if it names exactly one of a user or a role and a valid action.
+func (sp SimpleEntityPermission) MarshalJSON() ([]byte, error) {
+	if (sp.UserID > 0) == (sp.RoleID > 0) {
+		return nil, fmt.Errorf("cannot marshal SimpleEntityPermission into JSON without exactly one of a UserID or a RoleID")
+	}
+
+	if sp.Action < PermissionRead || sp.Action > PermissionPermissions {
+		return nil, fmt.Errorf("cannot marshal SimpleEntityPermission into JSON with an invalid Action (%d)", sp.Action)
+	}
+
+	type alias SimpleEntityPermission
+	return json.Marshal(alias(sp))
+}
+
+// EntityPermissionService handles communication with the EntityPermission
+// endpoint.
+type EntityPermissionService service
+
+// Index returns the list of all EntityPermissions for the entity associated
+// with entID in the Campaign associated with campID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityPermissions that were decoded.
+func (es *EntityPermissionService) Index(campID int, entID int) ([]*EntityPermission, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(endpointEntity)
+
+	if end, err = end.id(entID); err != nil {
+		return nil, fmt.Errorf("invalid Entity ID: %w", err)
+	}
+	end = end.concat(es.end)
+
+	var wrap response[[]json.RawMessage]
+
+	if err = es.client.get(end, &wrap); err != nil {
+		return nil, fmt.Errorf("cannot get EntityPermission Index for Entity (ID: %d) from Campaign (ID: %d): %w", entID, campID, err)
+	}
+
+	var list []*EntityPermission
+	if err = decodeList(wrap.Data, &list, es.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode EntityPermission Index for Entity (ID: %d) from Campaign (ID: %d): %w", entID, campID, err)
+	}
+
+	return list, nil
+}
+
+// Set sets each of the provided SimpleEntityPermissions for the entity
+// associated with entID in the Campaign associated with campID in a single
+// request. Permissions of the entity that are not provided are left as they
+// are.
+// Set returns the newly set EntityPermissions.
+func (es *EntityPermissionService) Set(campID int, entID int, perms ...SimpleEntityPermission) ([]*EntityPermission, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(endpointEntity)
+
+	if end, err = end.id(entID); err != nil {
+		return nil, fmt.Errorf("invalid Entity ID: %w", err)
+	}
+	end = end.concat(es.end)
+
+	if len(perms) == 0 {
+		return nil, fmt.Errorf("cannot set EntityPermissions for Entity (ID: %d) without any permissions", entID)
+	}
+
+	b, err := json.Marshal(perms)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal SimpleEntityPermissions: %w", err)
+	}
+
+	var wrap response[[]*EntityPermission]
+
+	if err = es.client.post(end, bytes.NewReader(b), &wrap); err != nil {
+		return nil, fmt.Errorf("cannot set EntityPermissions for Entity (ID: %d) in Campaign (ID: %d): %w", entID, campID, err)
+	}
+
+	return wrap.Data, nil
+}
@@ -0,0 +1,243 @@
+package kanka
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testEntityPermissionIndex string = "test_data/entitypermission_index.json"
+	testEntityPermissionSet   string = "test_data/entitypermission_set.json"
+)
+
+func TestEntityPermissionService_Index(t *testing.T) {
+	perms := []*EntityPermission{
+		{
+			SimpleEntityPermission: SimpleEntityPermission{
+				RoleID: 4,
+				Action: PermissionRead,
+				Access: true,
+			},
+			ID:       111,
+			EntityID: 430214,
+		},
+		{
+			SimpleEntityPermission: SimpleEntityPermission{
+				UserID: 5600,
+				Action: PermissionEdit,
+				Access: false,
+			},
+			ID:       222,
+			EntityID: 430214,
+		},
+	}
+
+	type args struct {
+		campID int
+		entID  int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*EntityPermission
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testEntityPermissionIndex,
+			args:    args{campID: 5272, entID: 430214},
+			want:    perms,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testEntityPermissionIndex,
+			args:    args{campID: -123, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid entID",
+			status:  http.StatusOK,
+			file:    testEntityPermissionIndex,
+			args:    args{campID: 5272, entID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 430214},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.EntityPermissions.Index(test.args.campID, test.args.entID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntityPermissionService_Set(t *testing.T) {
+	perm := SimpleEntityPermission{
+		RoleID: 4,
+		Action: PermissionEdit,
+		Access: true,
+	}
+
+	type args struct {
+		campID int
+		entID  int
+		perms  []SimpleEntityPermission
+	}
+	tests := []struct {
+		name     string
+		status   int
+		file     string
+		args     args
+		want     []*EntityPermission
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, valid response, valid args",
+			status:   http.StatusOK,
+			file:     testEntityPermissionSet,
+			args:     args{campID: 5272, entID: 430214, perms: []SimpleEntityPermission{perm}},
+			want:     []*EntityPermission{{SimpleEntityPermission: perm, ID: 333, EntityID: 430214}},
+			wantBody: `[{"campaign_role_id":4,"action":2,"access":true}]`,
+			wantErr:  false,
+		},
+		{
+			name:   "StatusOK, valid response, denied access for user",
+			status: http.StatusOK,
+			file:   testEntityPermissionSet,
+			args: args{campID: 5272, entID: 430214, perms: []SimpleEntityPermission{
+				{UserID: 5600, Action: PermissionDelete, Access: false},
+			}},
+			want:     []*EntityPermission{{SimpleEntityPermission: perm, ID: 333, EntityID: 430214}},
+			wantBody: `[{"user_id":5600,"action":4,"access":false}]`,
+			wantErr:  false,
+		},
+		{
+			name:     "Status OK, valid response, invalid campID",
+			status:   http.StatusOK,
+			file:     testEntityPermissionSet,
+			args:     args{campID: -123, entID: 430214, perms: []SimpleEntityPermission{perm}},
+			want:     nil,
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Status OK, valid response, invalid entID",
+			status:   http.StatusOK,
+			file:     testEntityPermissionSet,
+			args:     args{campID: 5272, entID: -123, perms: []SimpleEntityPermission{perm}},
+			want:     nil,
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Status OK, valid response, no permissions",
+			status:   http.StatusOK,
+			file:     testEntityPermissionSet,
+			args:     args{campID: 5272, entID: 430214, perms: nil},
+			want:     nil,
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:   "Status OK, valid response, both user and role",
+			status: http.StatusOK,
+			file:   testEntityPermissionSet,
+			args: args{campID: 5272, entID: 430214, perms: []SimpleEntityPermission{
+				{UserID: 5600, RoleID: 4, Action: PermissionRead, Access: true},
+			}},
+			want:     nil,
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:   "Status OK, valid response, neither user nor role",
+			status: http.StatusOK,
+			file:   testEntityPermissionSet,
+			args: args{campID: 5272, entID: 430214, perms: []SimpleEntityPermission{
+				{Action: PermissionRead, Access: true},
+			}},
+			want:     nil,
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:   "Status OK, valid response, invalid action",
+			status: http.StatusOK,
+			file:   testEntityPermissionSet,
+			args: args{campID: 5272, entID: 430214, perms: []SimpleEntityPermission{
+				{RoleID: 4, Action: 9, Access: true},
+			}},
+			want:     nil,
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusForbidden, valid args",
+			status:   http.StatusForbidden,
+			file:     testFileEmpty,
+			args:     args{campID: 5272, entID: 430214, perms: []SimpleEntityPermission{perm}},
+			want:     nil,
+			wantBody: `[{"campaign_role_id":4,"action":2,"access":true}]`,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, test.file)
+			defer ts.Close()
+
+			got, err := c.EntityPermissions.Set(test.args.campID, test.args.entID, test.args.perms...)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+			if test.wantBody != "" && rec.url != "/campaigns/5272/entities/430214/entity_permissions" {
+				t.Errorf("got url: <%s>, want url: </campaigns/5272/entities/430214/entity_permissions>", rec.url)
+			}
+		})
+	}
+}
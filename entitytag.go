@@ -29,6 +29,8 @@ type EntityTagService service
 // entID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return EntityTags that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityTags that were decoded.
 func (es *EntityTagService) Index(campID int, entID int, sync *time.Time) ([]*EntityTag, error) {
 	var err error
 	end := EndpointCampaign
@@ -47,15 +49,29 @@ func (es *EntityTagService) Index(campID int, entID int, sync *time.Time) ([]*En
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*EntityTag `json:"data"`
-	}
+	var wrap response[[]json.RawMessage]
 
 	if err = es.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get EntityTag Index from Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	var list []*EntityTag
+	if err = decodeList(wrap.Data, &list, es.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode EntityTag Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// IndexAll returns the list of all EntityTags for the entity associated with
+// entID in the Campaign associated with campID from every page of the list.
+// Each page is a separate request subject to the rate limit of the Client.
+// If a non-nil time is provided, IndexAll will only return EntityTags that
+// have been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the EntityTags that were decoded.
+func (es *EntityTagService) IndexAll(campID int, entID int, sync *time.Time) ([]*EntityTag, error) {
+	return entityIndexAll[EntityTag](es.client, campID, entID, es.end, "EntityTag", sync)
 }
 
 // Get returns the EntityTag associated with tagID for the entity associated
@@ -78,9 +94,7 @@ func (es *EntityTagService) Get(campID int, entID int, tagID int) (*EntityTag, e
 		return nil, fmt.Errorf("invalid EntityTag ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *EntityTag `json:"data"`
-	}
+	var wrap response[*EntityTag]
 
 	if err = es.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get EntityTag (ID: %d) from Campaign (ID: %d): %w", tagID, campID, err)
@@ -111,9 +125,7 @@ func (es *EntityTagService) Create(campID int, entID int, tag SimpleEntityTag) (
 		return nil, fmt.Errorf("cannot marshal SimpleEntityTag: %w", err)
 	}
 
-	var wrap struct {
-		Data *EntityTag `json:"data"`
-	}
+	var wrap response[*EntityTag]
 
 	if err = es.client.post(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot create EntityTag for Campaign (ID: %d): %w", campID, err)
@@ -149,9 +161,7 @@ func (es *EntityTagService) Update(campID int, entID int, tagID int, tag SimpleE
 		return nil, fmt.Errorf("cannot marshal SimpleEntityTag: %w", err)
 	}
 
-	var wrap struct {
-		Data *EntityTag `json:"data"`
-	}
+	var wrap response[*EntityTag]
 
 	if err = es.client.put(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot update EntityTag for Campaign (ID: %d): '%w'", campID, err)
@@ -186,3 +196,65 @@ func (es *EntityTagService) Delete(campID int, entID int, tagID int) error {
 
 	return nil
 }
+
+// AddTags adds each of the Tags associated with the provided tagIDs to the
+// entity associated with entID in the Campaign associated with campID, one
+// EntityTag at a time, without replacing the Tags the entity already has.
+// Tags the entity already has on any page of its EntityTags are skipped.
+// AddTags returns the newly created EntityTags, including those created
+// before an error occurred.
+func (es *EntityTagService) AddTags(campID int, entID int, tagIDs []int) ([]*EntityTag, error) {
+	current, err := es.IndexAll(campID, entID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("cannot add Tags to Entity (ID: %d): %w", entID, err)
+	}
+
+	has := make(map[int]bool, len(current))
+	for _, et := range current {
+		has[et.TagID] = true
+	}
+
+	var added []*EntityTag
+	for _, id := range tagIDs {
+		if has[id] {
+			continue
+		}
+		has[id] = true
+
+		et, err := es.Create(campID, entID, SimpleEntityTag{EntityID: entID, TagID: id})
+		if err != nil {
+			return added, fmt.Errorf("cannot add Tag (ID: %d) to Entity (ID: %d): %w", id, entID, err)
+		}
+		added = append(added, et)
+	}
+
+	return added, nil
+}
+
+// RemoveTags removes each of the Tags associated with the provided tagIDs from
+// the entity associated with entID in the Campaign associated with campID by
+// deleting their EntityTags, leaving the other Tags of the entity in place.
+// Tags the entity does not have are skipped.
+func (es *EntityTagService) RemoveTags(campID int, entID int, tagIDs []int) error {
+	current, err := es.IndexAll(campID, entID, nil)
+	if err != nil {
+		return fmt.Errorf("cannot remove Tags from Entity (ID: %d): %w", entID, err)
+	}
+
+	remove := make(map[int]bool, len(tagIDs))
+	for _, id := range tagIDs {
+		remove[id] = true
+	}
+
+	for _, et := range current {
+		if !remove[et.TagID] {
+			continue
+		}
+
+		if err := es.Delete(campID, entID, et.ID); err != nil {
+			return fmt.Errorf("cannot remove Tag (ID: %d) from Entity (ID: %d): %w", et.TagID, entID, err)
+		}
+	}
+
+	return nil
+}
@@ -1,8 +1,12 @@
 package kanka
 
 import (
+	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -598,3 +602,76 @@ func TestEntityTagService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func testEntityTagsClient(t *testing.T, calls *[]string) (*Client, *httptest.Server) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*calls = append(*calls, r.Method+" "+r.URL.Path)
+
+		switch r.Method {
+		case http.MethodGet:
+			if r.URL.Query().Get("page") == "2" {
+				w.Write([]byte(`{"data":[{"id":2,"entity_id":430214,"tag_id":20}],"links":{"next":null}}`))
+				return
+			}
+			fmt.Fprintf(w, `{"data":[{"id":1,"entity_id":430214,"tag_id":10}],"links":{"next":"%s/campaigns/5272/entities/430214/entity_tags?page=2"}}`, ts.URL)
+		case http.MethodPost:
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Error(err)
+			}
+			w.Write([]byte(`{"data":` + strings.TrimSuffix(string(b), "}") + `,"id":3}}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	return c, ts
+}
+
+func TestEntityTagService_AddTags(t *testing.T) {
+	var calls []string
+	c, ts := testEntityTagsClient(t, &calls)
+	defer ts.Close()
+
+	added, err := c.EntityTags.AddTags(5272, 430214, []int{20, 30, 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantCalls := []string{
+		"GET /campaigns/5272/entities/430214/entity_tags",
+		"GET /campaigns/5272/entities/430214/entity_tags",
+		"POST /campaigns/5272/entities/430214/entity_tags",
+	}
+	if diff := cmp.Diff(calls, wantCalls); diff != "" {
+		t.Errorf(diff)
+	}
+
+	want := []*EntityTag{{SimpleEntityTag: SimpleEntityTag{EntityID: 430214, TagID: 30}, ID: 3}}
+	if diff := cmp.Diff(added, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestEntityTagService_RemoveTags(t *testing.T) {
+	var calls []string
+	c, ts := testEntityTagsClient(t, &calls)
+	defer ts.Close()
+
+	if err := c.EntityTags.RemoveTags(5272, 430214, []int{20, 30}); err != nil {
+		t.Fatal(err)
+	}
+
+	wantCalls := []string{
+		"GET /campaigns/5272/entities/430214/entity_tags",
+		"GET /campaigns/5272/entities/430214/entity_tags",
+		"DELETE /campaigns/5272/entities/430214/entity_tags/2",
+	}
+	if diff := cmp.Diff(calls, wantCalls); diff != "" {
+		t.Errorf(diff)
+	}
+}
@@ -0,0 +1,32 @@
+package kanka
+
+import "fmt"
+
+// EntityType contains information about a type of entity supported by Kanka,
+// such as characters or locations.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/entity-types
+type EntityType struct {
+	ID        int    `json:"id"`
+	Code      string `json:"code"`
+	Name      string `json:"name"`
+	IsSpecial bool   `json:"is_special"`
+}
+
+// Resolvable returns true if an Entity of the EntityType can be resolved into
+// its concrete object with Resolve.
+func (et *EntityType) Resolvable() bool {
+	_, ok := entityTypes[et.Code]
+	return ok
+}
+
+// EntityTypes returns the list of every type of entity supported by Kanka.
+// The Code of each EntityType matches the Type of an Entity of that type.
+func (c *Client) EntityTypes() ([]*EntityType, error) {
+	var wrap response[[]*EntityType]
+
+	if err := c.get(EndpointEntityType, &wrap); err != nil {
+		return nil, fmt.Errorf("cannot get EntityTypes: %w", err)
+	}
+
+	return wrap.Data, nil
+}
@@ -0,0 +1,88 @@
+package kanka
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testEntityTypeIndex string = "test_data/entitytype_index.json"
+
+func TestClient_EntityTypes(t *testing.T) {
+	types := []*EntityType{
+		{ID: 1, Code: "character", Name: "Character", IsSpecial: false},
+		{ID: 12, Code: "calendar", Name: "Calendar", IsSpecial: false},
+		{ID: 19, Code: "bookmark", Name: "Bookmark", IsSpecial: true},
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		want    []*EntityType
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response",
+			status:  http.StatusOK,
+			file:    testEntityTypeIndex,
+			want:    types,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, empty response",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.EntityTypes()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEntityType_Resolvable(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{code: "character", want: true},
+		{code: "organisation", want: true},
+		{code: "calendar", want: true},
+		{code: "bookmark", want: false},
+		{code: "", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.code, func(t *testing.T) {
+			et := &EntityType{Code: test.code}
+			if got := et.Resolvable(); got != test.want {
+				t.Errorf("got: <%t>, want: <%t>", got, test.want)
+			}
+		})
+	}
+}
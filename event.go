@@ -1,27 +1,26 @@
 package kanka
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // Event contains information about a specific event.
 // For more information, visit: https://kanka.io/en-US/docs/1.0/events
 type Event struct {
 	SimpleEvent
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -34,160 +33,191 @@ type Event struct {
 // SimpleEvent contains only the simple information about an event.
 // SimpleEvent is primarily used to create new events for posting to Kanka.
 type SimpleEvent struct {
-	Name       string `json:"name"`
-	Entry      string `json:"entry,omitempty"`
-	Type       string `json:"type,omitempty"`
-	Date       string `json:"date,omitempty"`
-	LocationID int    `json:"location_id,omitempty"`
-	Tags       []int  `json:"tags,omitempty"`
-	IsPrivate  bool   `json:"is_private,omitempty"`
-	Image      string `json:"image,omitempty"`
-	ImageURL   string `json:"image_url,omitempty"`
+	Name           string   `json:"name"`
+	Entry          string   `json:"entry,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	Date           string   `json:"date,omitempty"`
+	LocationID     int      `json:"location_id,omitempty"`
+	Tags           []int    `json:"tags,omitempty"`
+	IsPrivate      bool     `json:"is_private,omitempty"`
+	IsTemplate     *bool    `json:"is_template,omitempty"`
+	Image          string   `json:"image,omitempty"`
+	ImageURL       string   `json:"image_url,omitempty"`
+	ImageUUID      string   `json:"image_uuid,omitempty"`
+	FocusX         *float64 `json:"focus_x,omitempty"`
+	FocusY         *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL string   `json:"header_image_url,omitempty"`
+	HeaderUUID     string   `json:"entity_header_uuid,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleEvent into its JSON-encoded form if it
 // has the required populated fields.
 func (se SimpleEvent) MarshalJSON() ([]byte, error) {
-	if blank.Is(se.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleEvent into JSON with a missing Name")
+	if err := requireField("SimpleEvent", "Name", se.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleEvent
 	return json.Marshal(alias(se))
 }
 
+// Simple returns a copy of the SimpleEvent data of the Event, ready to be
+// modified and passed to Update. The path of the current image is left out and
+// the slices of the copy do not share memory with the Event.
+func (e *Event) Simple() SimpleEvent {
+	s := e.SimpleEvent
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+
+	return s
+}
+
 // EventService handles communication with the Event endpoint.
 type EventService service
 
+// base returns the baseService implementing the common operations of the
+// EventService.
+func (es *EventService) base() baseService[Event, SimpleEvent] {
+	return baseService[Event, SimpleEvent]{
+		service: (*service)(es),
+		kind:    "Event",
+		entity:  "event",
+		label:   func(evt SimpleEvent) string { return evt.Name },
+	}
+}
+
 // Index returns the list of all Events in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Events that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Events that were decoded.
 func (es *EventService) Index(campID int, sync *time.Time) ([]*Event, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(es.end)
-
-	if sync != nil {
-		end = end.sync(*sync)
-	}
-
-	var wrap struct {
-		Data []*Event `json:"data"`
-	}
+	return es.base().Index(campID, sync)
+}
 
-	err = es.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Event Index from Campaign (ID: %d): %w", campID, err)
-	}
+// IndexAll returns the list of all Events in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Events that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Events that were decoded.
+func (es *EventService) IndexAll(campID int, sync *time.Time) ([]*Event, error) {
+	return es.base().IndexAll(campID, sync)
+}
 
-	return wrap.Data, nil
+// GetMany returns the Events associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Events, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Events that were
+// found.
+func (es *EventService) GetMany(campID int, ids []int) (map[int]*Event, error) {
+	return es.base().GetMany(campID, ids)
 }
 
-// Get returns the Event associated with evtID from the Campaign
-// associated with campID.
-func (es *EventService) Get(campID int, evtID int) (*Event, error) {
+// Count returns the number of Events in the Campaign associated with campID
+// without retrieving them.
+func (es *EventService) Count(campID int) (int, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(es.end)
 
-	end, err = end.id(evtID)
+	n, err := es.client.count(end)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Event ID: %w", err)
+		return 0, fmt.Errorf("cannot count Events in Campaign (ID: %d): %w", campID, err)
 	}
 
-	var wrap struct {
-		Data *Event `json:"data"`
-	}
+	return n, nil
+}
 
-	err = es.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Event (ID: %d) from Campaign (ID: %d): %w", evtID, campID, err)
-	}
+// Get returns the Event associated with evtID from the Campaign
+// associated with campID.
+func (es *EventService) Get(campID int, evtID int) (*Event, error) {
+	return es.base().Get(campID, evtID)
+}
 
-	return wrap.Data, nil
+// GetByName returns the Event with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Event has the name and ErrAmbiguous if more than one does.
+func (es *EventService) GetByName(campID int, name string) (*Event, error) {
+	return es.base().GetByName(campID, name)
 }
 
 // Create creates a new Event in the Campaign associated with campID using
 // the provided SimpleEvent data.
 // Create returns the newly created Event.
-func (es *EventService) Create(campID int, evt SimpleEvent) (*Event, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(es.end)
-
-	b, err := json.Marshal(evt)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleEvent (Name: %s): %w", evt.Name, err)
-	}
-
-	var wrap struct {
-		Data *Event `json:"data"`
-	}
-
-	err = es.client.post(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot create Event (Name: %s) for Campaign (ID: %d): %w", evt.Name, campID, err)
-	}
+// The provided CreateOptions, such as Public, apply to this call only.
+func (es *EventService) Create(campID int, evt SimpleEvent, opts ...CreateOption) (*Event, error) {
+	return es.base().Create(campID, evt, opts...)
+}
 
-	return wrap.Data, nil
+// CreateWithResult creates a new Event in the Campaign associated with campID
+// using the provided SimpleEvent data.
+// CreateWithResult returns the newly created Event along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (es *EventService) CreateWithResult(campID int, evt SimpleEvent, opts ...CreateOption) (*Event, *WriteResult, error) {
+	return es.base().CreateWithResult(campID, evt, opts...)
 }
 
 // Update updates an existing Event associated with evtID from the
 // Campaign associated with campID using the provided SimpleEvent data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Event returned by Kanka, provide its SimpleEvent.
 // Update returns the newly updated Event.
 func (es *EventService) Update(campID int, evtID int, evt SimpleEvent) (*Event, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(es.end)
-
-	end, err = end.id(evtID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Event ID: %w", err)
-	}
+	return es.base().Update(campID, evtID, evt)
+}
 
-	b, err := json.Marshal(evt)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleEvent (Name: %s): %w", evt.Name, err)
-	}
+// UpdateWithResult updates an existing Event associated with evtID from the
+// Campaign associated with campID using the provided SimpleEvent data, as
+// Update does.
+// UpdateWithResult returns the newly updated Event along with the metadata of
+// Kanka's response, such as its status code.
+func (es *EventService) UpdateWithResult(campID int, evtID int, evt SimpleEvent) (*Event, *WriteResult, error) {
+	return es.base().UpdateWithResult(campID, evtID, evt)
+}
 
-	var wrap struct {
-		Data *Event `json:"data"`
-	}
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Event associated with evtID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Event is left unchanged.
+// Clear returns the newly updated Event.
+func (es *EventService) Clear(campID int, evtID int, fields ...string) (*Event, error) {
+	return es.base().Clear(campID, evtID, fields...)
+}
 
-	err = es.client.put(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot update Event (Name: %s) for Campaign (ID: %d): '%w'", evt.Name, campID, err)
-	}
+// SetHeader sets the header image of an existing Event associated with evtID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Event on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Event.
+func (es *EventService) SetHeader(campID int, evtID int, uuid string) (*Event, error) {
+	return es.base().SetHeader(campID, evtID, uuid)
+}
 
-	return wrap.Data, nil
+// SetHeaderURL sets the header image of an existing Event associated with
+// evtID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Event.
+func (es *EventService) SetHeaderURL(campID int, evtID int, url string) (*Event, error) {
+	return es.base().SetHeaderURL(campID, evtID, url)
 }
 
 // Delete deletes an existing Event associated with evtID from the
 // Campaign associated with campID.
 func (es *EventService) Delete(campID int, evtID int) error {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(es.end)
-
-	end, err = end.id(evtID)
-	if err != nil {
-		return fmt.Errorf("invalid Event ID: %w", err)
-	}
-
-	err = es.client.delete(end)
-	if err != nil {
-		return fmt.Errorf("cannot delete Event (ID: %d) for Campaign (ID: %d): %w", evtID, campID, err)
-	}
-
-	return nil
+	return es.base().Delete(campID, evtID)
 }
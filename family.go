@@ -1,28 +1,27 @@
 package kanka
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // Family contains information about a family.
 // For more information, visit: https://kanka.io/en-US/dofs/1.0/families
 type Family struct {
 	SimpleFamily
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
-	Members        []int     `json:"members"`
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
+	Members         []int     `json:"members"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -36,160 +35,210 @@ type Family struct {
 // SimpleFamily is primarily used to create new families for posting to
 // Kanka.
 type SimpleFamily struct {
-	Name       string `json:"name"`
-	Entry      string `json:"entry,omitempty"`
-	Type       string `json:"type,omitempty"`
-	LocationID int    `json:"location_id,omitempty"`
-	FamilyID   int    `json:"family_id,omitempty"`
-	Tags       []int  `json:"tags,omitempty"`
-	IsPrivate  bool   `json:"is_private,omitempty"`
-	Image      string `json:"image,omitempty"`
-	ImageURL   string `json:"image_url,omitempty"`
+	Name           string   `json:"name"`
+	Entry          string   `json:"entry,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	LocationID     int      `json:"location_id,omitempty"`
+	FamilyID       int      `json:"family_id,omitempty"`
+	Tags           []int    `json:"tags,omitempty"`
+	IsPrivate      bool     `json:"is_private,omitempty"`
+	IsTemplate     *bool    `json:"is_template,omitempty"`
+	Image          string   `json:"image,omitempty"`
+	ImageURL       string   `json:"image_url,omitempty"`
+	ImageUUID      string   `json:"image_uuid,omitempty"`
+	FocusX         *float64 `json:"focus_x,omitempty"`
+	FocusY         *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL string   `json:"header_image_url,omitempty"`
+	HeaderUUID     string   `json:"entity_header_uuid,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleFamily into its JSON-encoded form if it
 // has the required populated fields.
 func (sf SimpleFamily) MarshalJSON() ([]byte, error) {
-	if blank.Is(sf.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleFamily into JSON with a missing Name")
+	if err := requireField("SimpleFamily", "Name", sf.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleFamily
 	return json.Marshal(alias(sf))
 }
 
+// Simple returns a copy of the SimpleFamily data of the Family, ready to be
+// modified and passed to Update. The path of the current image is left out and
+// the slices of the copy do not share memory with the Family.
+func (f *Family) Simple() SimpleFamily {
+	s := f.SimpleFamily
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+
+	return s
+}
+
 // FamilyService handles communication with the Family endpoint.
 type FamilyService service
 
+// base returns the baseService implementing the common operations of the
+// FamilyService.
+func (fs *FamilyService) base() baseService[Family, SimpleFamily] {
+	return baseService[Family, SimpleFamily]{
+		service: (*service)(fs),
+		kind:    "Family",
+		entity:  "family",
+		label:   func(fam SimpleFamily) string { return fam.Name },
+	}
+}
+
 // Index returns the list of all Families in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Families that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Families that were decoded.
 func (fs *FamilyService) Index(campID int, sync *time.Time) ([]*Family, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(fs.end)
-
-	if sync != nil {
-		end = end.sync(*sync)
-	}
-
-	var wrap struct {
-		Data []*Family `json:"data"`
-	}
+	return fs.base().Index(campID, sync)
+}
 
-	err = fs.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Family Index from Campaign (ID: %d): %w", campID, err)
-	}
+// IndexAll returns the list of all Families in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Families that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Families that were decoded.
+func (fs *FamilyService) IndexAll(campID int, sync *time.Time) ([]*Family, error) {
+	return fs.base().IndexAll(campID, sync)
+}
 
-	return wrap.Data, nil
+// GetMany returns the Families associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Families, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Families that were
+// found.
+func (fs *FamilyService) GetMany(campID int, ids []int) (map[int]*Family, error) {
+	return fs.base().GetMany(campID, ids)
 }
 
-// Get returns the Family associated with famID from the Campaign
-// associated with campID.
-func (fs *FamilyService) Get(campID int, famID int) (*Family, error) {
+// Count returns the number of Families in the Campaign associated with campID
+// without retrieving them.
+func (fs *FamilyService) Count(campID int) (int, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(fs.end)
 
-	end, err = end.id(famID)
+	n, err := fs.client.count(end)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Family ID: %w", err)
+		return 0, fmt.Errorf("cannot count Families in Campaign (ID: %d): %w", campID, err)
 	}
 
-	var wrap struct {
-		Data *Family `json:"data"`
-	}
+	return n, nil
+}
 
-	err = fs.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Family (ID: %d) from Campaign (ID: %d): %w", famID, campID, err)
-	}
+// Get returns the Family associated with famID from the Campaign
+// associated with campID.
+func (fs *FamilyService) Get(campID int, famID int) (*Family, error) {
+	return fs.base().Get(campID, famID)
+}
 
-	return wrap.Data, nil
+// GetByName returns the Family with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Family has the name and ErrAmbiguous if more than one does.
+func (fs *FamilyService) GetByName(campID int, name string) (*Family, error) {
+	return fs.base().GetByName(campID, name)
 }
 
 // Create creates a new Family in the Campaign associated with campID using
 // the provided SimpleFamily data.
 // Create returns the newly created Family.
-func (fs *FamilyService) Create(campID int, fam SimpleFamily) (*Family, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(fs.end)
-
-	b, err := json.Marshal(fam)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleFamily (Name: %s): %w", fam.Name, err)
-	}
-
-	var wrap struct {
-		Data *Family `json:"data"`
-	}
-
-	err = fs.client.post(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot create Family (Name: %s) for Campaign (ID: %d): %w", fam.Name, campID, err)
-	}
+// The provided CreateOptions, such as Public, apply to this call only.
+func (fs *FamilyService) Create(campID int, fam SimpleFamily, opts ...CreateOption) (*Family, error) {
+	return fs.base().Create(campID, fam, opts...)
+}
 
-	return wrap.Data, nil
+// CreateWithResult creates a new Family in the Campaign associated with campID
+// using the provided SimpleFamily data.
+// CreateWithResult returns the newly created Family along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (fs *FamilyService) CreateWithResult(campID int, fam SimpleFamily, opts ...CreateOption) (*Family, *WriteResult, error) {
+	return fs.base().CreateWithResult(campID, fam, opts...)
 }
 
 // Update updates an existing Family associated with famID from the
 // Campaign associated with campID using the provided SimpleFamily data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Family returned by Kanka, provide its SimpleFamily.
 // Update returns the newly updated Family.
 func (fs *FamilyService) Update(campID int, famID int, fam SimpleFamily) (*Family, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(fs.end)
-
-	end, err = end.id(famID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Family ID: %w", err)
-	}
+	return fs.base().Update(campID, famID, fam)
+}
 
-	b, err := json.Marshal(fam)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleFamily (Name: %s): %w", fam.Name, err)
-	}
+// UpdateWithResult updates an existing Family associated with famID from the
+// Campaign associated with campID using the provided SimpleFamily data, as
+// Update does.
+// UpdateWithResult returns the newly updated Family along with the metadata of
+// Kanka's response, such as its status code.
+func (fs *FamilyService) UpdateWithResult(campID int, famID int, fam SimpleFamily) (*Family, *WriteResult, error) {
+	return fs.base().UpdateWithResult(campID, famID, fam)
+}
 
-	var wrap struct {
-		Data *Family `json:"data"`
-	}
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Family associated with famID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Family is left unchanged.
+// Clear returns the newly updated Family.
+func (fs *FamilyService) Clear(campID int, famID int, fields ...string) (*Family, error) {
+	return fs.base().Clear(campID, famID, fields...)
+}
 
-	err = fs.client.put(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot update Family (Name: %s) for Campaign (ID: %d): '%w'", fam.Name, campID, err)
-	}
+// SetHeader sets the header image of an existing Family associated with famID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Family on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Family.
+func (fs *FamilyService) SetHeader(campID int, famID int, uuid string) (*Family, error) {
+	return fs.base().SetHeader(campID, famID, uuid)
+}
 
-	return wrap.Data, nil
+// SetHeaderURL sets the header image of an existing Family associated with
+// famID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Family.
+func (fs *FamilyService) SetHeaderURL(campID int, famID int, url string) (*Family, error) {
+	return fs.base().SetHeaderURL(campID, famID, url)
 }
 
 // Delete deletes an existing Family associated with famID from the
 // Campaign associated with campID.
 func (fs *FamilyService) Delete(campID int, famID int) error {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(fs.end)
+	return fs.base().Delete(campID, famID)
+}
 
-	end, err = end.id(famID)
+// Path returns the chain of Families from the root of the family tree to the
+// Family associated with famID in the Campaign associated with campID, such as
+// the breadcrumbs "House > Branch". The chain ends with the Family itself.
+func (fs *FamilyService) Path(campID int, famID int) ([]*Family, error) {
+	paths, err := fs.Paths(campID, []int{famID})
 	if err != nil {
-		return fmt.Errorf("invalid Family ID: %w", err)
+		return nil, err
 	}
 
-	err = fs.client.delete(end)
-	if err != nil {
-		return fmt.Errorf("cannot delete Family (ID: %d) for Campaign (ID: %d): %w", famID, campID, err)
-	}
+	return paths[famID], nil
+}
 
-	return nil
+// Paths returns the Path of each Family associated with the provided famIDs
+// in the Campaign associated with campID, keyed by Family ID. Each Family is
+// fetched at most once, even if it is an ancestor shared by several Families.
+func (fs *FamilyService) Paths(campID int, famIDs []int) (map[int][]*Family, error) {
+	return fs.base().paths(campID, famIDs, func(x *Family) int { return x.FamilyID })
 }
@@ -0,0 +1,66 @@
+package kanka
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidateFormula checks the syntax of a calculated attribute formula. A
+// formula references the values of other attributes of the same entity by
+// name, such as "{Level} * 2". Every reference must be closed, must not be
+// nested, must not be empty, and must name one of the provided known
+// attributes.
+func ValidateFormula(formula string, known []string) error {
+	names := make(map[string]bool, len(known))
+	for _, k := range known {
+		names[k] = true
+	}
+
+	refs, err := formulaRefs(formula)
+	if err != nil {
+		return err
+	}
+
+	for _, ref := range refs {
+		if !names[ref] {
+			return fmt.Errorf("formula references unknown attribute '%s'", ref)
+		}
+	}
+
+	return nil
+}
+
+// formulaRefs returns the attribute names referenced by the provided formula
+// in order of appearance. Returns an error if the braces of the formula are
+// unbalanced or a reference is empty.
+func formulaRefs(formula string) ([]string, error) {
+	var refs []string
+	start := -1
+
+	for i, r := range formula {
+		switch r {
+		case '{':
+			if start >= 0 {
+				return nil, fmt.Errorf("formula has a nested '{' at position %d", i)
+			}
+			start = i
+		case '}':
+			if start < 0 {
+				return nil, fmt.Errorf("formula has an unopened '}' at position %d", i)
+			}
+
+			ref := strings.TrimSpace(formula[start+1 : i])
+			if ref == "" {
+				return nil, fmt.Errorf("formula has an empty reference at position %d", start)
+			}
+			refs = append(refs, ref)
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		return nil, fmt.Errorf("formula has an unclosed '{' at position %d", start)
+	}
+
+	return refs, nil
+}
@@ -0,0 +1,62 @@
+package kanka
+
+import "testing"
+
+func TestValidateFormula(t *testing.T) {
+	known := []string{"Level", "Strength", "Hit Points"}
+
+	tests := []struct {
+		name    string
+		formula string
+		wantErr bool
+	}{
+		{
+			name:    "Single reference",
+			formula: "{Level} * 2",
+			wantErr: false,
+		},
+		{
+			name:    "Multiple references with spaces",
+			formula: "{ Hit Points } + {Strength}",
+			wantErr: false,
+		},
+		{
+			name:    "No references",
+			formula: "10",
+			wantErr: false,
+		},
+		{
+			name:    "Unknown reference",
+			formula: "{Dexterity} + 1",
+			wantErr: true,
+		},
+		{
+			name:    "Unclosed reference",
+			formula: "{Level * 2",
+			wantErr: true,
+		},
+		{
+			name:    "Unopened reference",
+			formula: "Level} * 2",
+			wantErr: true,
+		},
+		{
+			name:    "Nested reference",
+			formula: "{Lev{Level}el}",
+			wantErr: true,
+		},
+		{
+			name:    "Empty reference",
+			formula: "{ } + 1",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := ValidateFormula(test.formula, known)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package kanka
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// GalleryImage contains information about an image uploaded to a campaign's
+// gallery. A GalleryImage can be attached to an entity by providing its ID as
+// the entity's ImageUUID.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/gallery
+type GalleryImage struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Ext       string    `json:"ext"`
+	Size      int       `json:"size"`
+	Path      string    `json:"path"`
+	IsFolder  bool      `json:"is_folder"`
+	FolderID  string    `json:"folder_id"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy int       `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// GalleryService handles communication with the Gallery endpoint.
+type GalleryService service
+
+// Index returns the list of all GalleryImages in the Campaign associated with
+// campID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the GalleryImages that were decoded.
+func (gs *GalleryService) Index(campID int) ([]*GalleryImage, error) {
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(gs.end)
+
+	var wrap response[[]json.RawMessage]
+
+	if err = gs.client.get(end, &wrap); err != nil {
+		return nil, fmt.Errorf("cannot get GalleryImage Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	var list []*GalleryImage
+	if err = decodeList(wrap.Data, &list, gs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode GalleryImage Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,115 @@
+package kanka
+
+import (
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testGalleryIndex   string = "test_data/gallery_index.json"
+	testGalleryPartial string = "test_data/gallery_index_partial.json"
+)
+
+func TestGalleryService_Index(t *testing.T) {
+	imgs := []*GalleryImage{
+		{
+			ID:        "9a1d8e2c-3b4f-4c1e-8d2a-6f5e4b3c2a10",
+			Name:      "Penny Portrait",
+			Ext:       "png",
+			Size:      512,
+			Path:      "https://kanka-user-assets.s3.eu-central-1.amazonaws.com/gallery/penny.png",
+			IsFolder:  false,
+			FolderID:  "4e7f6d5c-2b1a-4f3e-9d8c-7b6a5f4e3d20",
+			CreatedBy: 5600,
+		},
+		{
+			ID:        "4e7f6d5c-2b1a-4f3e-9d8c-7b6a5f4e3d20",
+			Name:      "Portraits",
+			IsFolder:  true,
+			CreatedBy: 5600,
+		},
+	}
+
+	type args struct {
+		campID int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*GalleryImage
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testGalleryIndex,
+			args:    args{campID: 5272},
+			want:    imgs,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, partially malformed response, valid args",
+			status:  http.StatusOK,
+			file:    testGalleryPartial,
+			args:    args{campID: 5272},
+			want:    imgs,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testGalleryIndex,
+			args:    args{campID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Gallery.Index(test.args.campID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
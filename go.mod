@@ -1,6 +1,6 @@
 module github.com/Henry-Sarabia/kanka
 
-go 1.13
+go 1.18
 
 require (
 	github.com/Henry-Sarabia/blank v3.0.0+incompatible
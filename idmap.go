@@ -0,0 +1,68 @@
+package kanka
+
+// IDMap maps the IDs of deleted objects, and of their entities, to the IDs of
+// the objects re-created in their place from locally stored copies. Pass the
+// same IDMap to each re-creation of a bulk restore so that references between
+// the restored objects, such as relations and inventory items, are re-pointed
+// to the new IDs.
+type IDMap struct {
+	// Entities maps old Entity IDs to new Entity IDs.
+	Entities map[int]int
+	// Objects maps the old object IDs of each Entity type, such as
+	// "character", to new object IDs.
+	Objects map[string]map[int]int
+}
+
+// NewIDMap returns an empty IDMap.
+func NewIDMap() *IDMap {
+	return &IDMap{
+		Entities: make(map[int]int),
+		Objects:  make(map[string]map[int]int),
+	}
+}
+
+// Entity returns the new ID of the Entity associated with the old entID, or
+// entID itself if the Entity was not re-created.
+func (m *IDMap) Entity(entID int) int {
+	if id, ok := m.Entities[entID]; ok {
+		return id
+	}
+
+	return entID
+}
+
+// Object returns the new ID of the object of the provided Entity type
+// associated with the old id, or id itself if the object was not re-created.
+func (m *IDMap) Object(typ string, id int) int {
+	if newID, ok := m.Objects[typ][id]; ok {
+		return newID
+	}
+
+	return id
+}
+
+// objects returns the new IDs of the objects of the provided Entity type
+// associated with the old ids. The provided slice is not modified.
+func (m *IDMap) objects(typ string, ids []int) []int {
+	if ids == nil {
+		return nil
+	}
+
+	mapped := make([]int, len(ids))
+	for i, id := range ids {
+		mapped[i] = m.Object(typ, id)
+	}
+
+	return mapped
+}
+
+// add records that the object of the provided Entity type associated with
+// oldID, and its Entity associated with oldEntID, were re-created as newID
+// and newEntID.
+func (m *IDMap) add(typ string, oldID int, newID int, oldEntID int, newEntID int) {
+	if m.Objects[typ] == nil {
+		m.Objects[typ] = make(map[int]int)
+	}
+	m.Objects[typ][oldID] = newID
+	m.Entities[oldEntID] = newEntID
+}
@@ -1,6 +1,9 @@
 package kanka
 
-import "time"
+import (
+	"encoding/json"
+	"time"
+)
 
 // Inventories wraps a list of inventories.
 // Inventories exists to satisfy the API's JSON structure.
@@ -9,6 +12,26 @@ type Inventories struct {
 	Sync time.Time    `json:"sync"`
 }
 
+// UnmarshalJSON unmarshals the JSON-encoded data into the Inventories. A null value
+// or a null list is unmarshaled into an empty list rather than a nil one.
+func (is *Inventories) UnmarshalJSON(b []byte) error {
+	type alias Inventories
+	var al alias
+
+	if !isNull(b) {
+		if err := json.Unmarshal(b, &al); err != nil {
+			return err
+		}
+	}
+
+	if al.Data == nil {
+		al.Data = []*Inventory{}
+	}
+
+	*is = Inventories(al)
+	return nil
+}
+
 // Inventory represents a single inventory belonging to the parent entity.
 type Inventory struct {
 	Amount     int       `json:"amount"`
@@ -1,27 +1,26 @@
 package kanka
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // Item contains information about a specific item.
 // For more information, visit: https://kanka.io/en-US/docs/1.0/items
 type Item struct {
 	SimpleItem
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -34,162 +33,193 @@ type Item struct {
 // SimpleItem contains only the simple information about an item.
 // SimpleItem is primarily used to create new items for posting to Kanka.
 type SimpleItem struct {
-	Name        string `json:"name"`
-	Entry       string `json:"entry,omitempty"`
-	Type        string `json:"type,omitempty"`
-	Price       string `json:"price,omitempty"`
-	Size        string `json:"size,omitempty"`
-	LocationID  int    `json:"location_id,omitempty"`
-	CharacterID int    `json:"character_id,omitempty"`
-	Tags        []int  `json:"tags,omitempty"`
-	IsPrivate   bool   `json:"is_private,omitempty"`
-	Image       string `json:"image,omitempty"`
-	ImageURL    string `json:"image_url,omitempty"`
+	Name           string   `json:"name"`
+	Entry          string   `json:"entry,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	Price          string   `json:"price,omitempty"`
+	Size           string   `json:"size,omitempty"`
+	LocationID     int      `json:"location_id,omitempty"`
+	CharacterID    int      `json:"character_id,omitempty"`
+	Tags           []int    `json:"tags,omitempty"`
+	IsPrivate      bool     `json:"is_private,omitempty"`
+	IsTemplate     *bool    `json:"is_template,omitempty"`
+	Image          string   `json:"image,omitempty"`
+	ImageURL       string   `json:"image_url,omitempty"`
+	ImageUUID      string   `json:"image_uuid,omitempty"`
+	FocusX         *float64 `json:"focus_x,omitempty"`
+	FocusY         *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL string   `json:"header_image_url,omitempty"`
+	HeaderUUID     string   `json:"entity_header_uuid,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleItem into its JSON-encoded form if it
 // has the required populated fields.
 func (si SimpleItem) MarshalJSON() ([]byte, error) {
-	if blank.Is(si.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleItem into JSON with a missing Name")
+	if err := requireField("SimpleItem", "Name", si.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleItem
 	return json.Marshal(alias(si))
 }
 
+// Simple returns a copy of the SimpleItem data of the Item, ready to be
+// modified and passed to Update. The path of the current image is left out and
+// the slices of the copy do not share memory with the Item.
+func (i *Item) Simple() SimpleItem {
+	s := i.SimpleItem
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+
+	return s
+}
+
 // ItemService handles communication with the Item endpoint.
 type ItemService service
 
+// base returns the baseService implementing the common operations of the
+// ItemService.
+func (is *ItemService) base() baseService[Item, SimpleItem] {
+	return baseService[Item, SimpleItem]{
+		service: (*service)(is),
+		kind:    "Item",
+		entity:  "item",
+		label:   func(item SimpleItem) string { return item.Name },
+	}
+}
+
 // Index returns the list of all Items in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Items that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Items that were decoded.
 func (is *ItemService) Index(campID int, sync *time.Time) ([]*Item, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(is.end)
-
-	if sync != nil {
-		end = end.sync(*sync)
-	}
-
-	var wrap struct {
-		Data []*Item `json:"data"`
-	}
+	return is.base().Index(campID, sync)
+}
 
-	err = is.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Item Index from Campaign (ID: %d): %w", campID, err)
-	}
+// IndexAll returns the list of all Items in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Items that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Items that were decoded.
+func (is *ItemService) IndexAll(campID int, sync *time.Time) ([]*Item, error) {
+	return is.base().IndexAll(campID, sync)
+}
 
-	return wrap.Data, nil
+// GetMany returns the Items associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Items, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Items that were
+// found.
+func (is *ItemService) GetMany(campID int, ids []int) (map[int]*Item, error) {
+	return is.base().GetMany(campID, ids)
 }
 
-// Get returns the Item associated with itemID from the Campaign
-// associated with campID.
-func (is *ItemService) Get(campID int, itemID int) (*Item, error) {
+// Count returns the number of Items in the Campaign associated with campID
+// without retrieving them.
+func (is *ItemService) Count(campID int) (int, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(is.end)
 
-	end, err = end.id(itemID)
+	n, err := is.client.count(end)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Item ID: %w", err)
+		return 0, fmt.Errorf("cannot count Items in Campaign (ID: %d): %w", campID, err)
 	}
 
-	var wrap struct {
-		Data *Item `json:"data"`
-	}
+	return n, nil
+}
 
-	err = is.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Item (ID: %d) from Campaign (ID: %d): %w", itemID, campID, err)
-	}
+// Get returns the Item associated with itemID from the Campaign
+// associated with campID.
+func (is *ItemService) Get(campID int, itemID int) (*Item, error) {
+	return is.base().Get(campID, itemID)
+}
 
-	return wrap.Data, nil
+// GetByName returns the Item with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Item has the name and ErrAmbiguous if more than one does.
+func (is *ItemService) GetByName(campID int, name string) (*Item, error) {
+	return is.base().GetByName(campID, name)
 }
 
 // Create creates a new Item in the Campaign associated with campID using
 // the provided SimpleItem data.
 // Create returns the newly created Item.
-func (is *ItemService) Create(campID int, item SimpleItem) (*Item, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(is.end)
-
-	b, err := json.Marshal(item)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleItem (Name: %s): %w", item.Name, err)
-	}
-
-	var wrap struct {
-		Data *Item `json:"data"`
-	}
-
-	err = is.client.post(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot create Item (Name: %s) for Campaign (ID: %d): %w", item.Name, campID, err)
-	}
+// The provided CreateOptions, such as Public, apply to this call only.
+func (is *ItemService) Create(campID int, item SimpleItem, opts ...CreateOption) (*Item, error) {
+	return is.base().Create(campID, item, opts...)
+}
 
-	return wrap.Data, nil
+// CreateWithResult creates a new Item in the Campaign associated with campID
+// using the provided SimpleItem data.
+// CreateWithResult returns the newly created Item along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (is *ItemService) CreateWithResult(campID int, item SimpleItem, opts ...CreateOption) (*Item, *WriteResult, error) {
+	return is.base().CreateWithResult(campID, item, opts...)
 }
 
 // Update updates an existing Item associated with itemID from the
 // Campaign associated with campID using the provided SimpleItem data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Item returned by Kanka, provide its SimpleItem.
 // Update returns the newly updated Item.
 func (is *ItemService) Update(campID int, itemID int, item SimpleItem) (*Item, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(is.end)
-
-	end, err = end.id(itemID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Item ID: %w", err)
-	}
+	return is.base().Update(campID, itemID, item)
+}
 
-	b, err := json.Marshal(item)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleItem (Name: %s): %w", item.Name, err)
-	}
+// UpdateWithResult updates an existing Item associated with itemID from the
+// Campaign associated with campID using the provided SimpleItem data, as
+// Update does.
+// UpdateWithResult returns the newly updated Item along with the metadata of
+// Kanka's response, such as its status code.
+func (is *ItemService) UpdateWithResult(campID int, itemID int, item SimpleItem) (*Item, *WriteResult, error) {
+	return is.base().UpdateWithResult(campID, itemID, item)
+}
 
-	var wrap struct {
-		Data *Item `json:"data"`
-	}
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Item associated with itemID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Item is left unchanged.
+// Clear returns the newly updated Item.
+func (is *ItemService) Clear(campID int, itemID int, fields ...string) (*Item, error) {
+	return is.base().Clear(campID, itemID, fields...)
+}
 
-	err = is.client.put(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot update Item (Name: %s) for Campaign (ID: %d): '%w'", item.Name, campID, err)
-	}
+// SetHeader sets the header image of an existing Item associated with itemID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Item on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Item.
+func (is *ItemService) SetHeader(campID int, itemID int, uuid string) (*Item, error) {
+	return is.base().SetHeader(campID, itemID, uuid)
+}
 
-	return wrap.Data, nil
+// SetHeaderURL sets the header image of an existing Item associated with
+// itemID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Item.
+func (is *ItemService) SetHeaderURL(campID int, itemID int, url string) (*Item, error) {
+	return is.base().SetHeaderURL(campID, itemID, url)
 }
 
 // Delete deletes an existing Item associated with itemID from the
 // Campaign associated with campID.
 func (is *ItemService) Delete(campID int, itemID int) error {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(is.end)
-
-	end, err = end.id(itemID)
-	if err != nil {
-		return fmt.Errorf("invalid Item ID: %w", err)
-	}
-
-	err = is.client.delete(end)
-	if err != nil {
-		return fmt.Errorf("cannot delete Item (ID: %d) for Campaign (ID: %d): %w", itemID, campID, err)
-	}
-
-	return nil
+	return is.base().Delete(campID, itemID)
 }
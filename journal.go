@@ -1,27 +1,26 @@
 package kanka
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // Journal contains information about a specific journal.
 // For more information, visit: https://kanka.io/en-US/docs/1.0/journals
 type Journal struct {
 	SimpleJournal
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -34,161 +33,192 @@ type Journal struct {
 // SimpleJournal contains only the simple information about a journal.
 // SimpleJournal is primarily used to create new journals for posting to Kanka.
 type SimpleJournal struct {
-	Name        string `json:"name"`
-	Entry       string `json:"entry,omitempty"`
-	Type        string `json:"type,omitempty"`
-	Date        string `json:"date,omitempty"`
-	LocationID  int    `json:"location_id,omitempty"`
-	CharacterID int    `json:"character_id,omitempty"`
-	Tags        []int  `json:"tags,omitempty"`
-	IsPrivate   bool   `json:"is_private,omitempty"`
-	Image       string `json:"image,omitempty"`
-	ImageURL    string `json:"image_url,omitempty"`
+	Name           string   `json:"name"`
+	Entry          string   `json:"entry,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	Date           string   `json:"date,omitempty"`
+	LocationID     int      `json:"location_id,omitempty"`
+	CharacterID    int      `json:"character_id,omitempty"`
+	Tags           []int    `json:"tags,omitempty"`
+	IsPrivate      bool     `json:"is_private,omitempty"`
+	IsTemplate     *bool    `json:"is_template,omitempty"`
+	Image          string   `json:"image,omitempty"`
+	ImageURL       string   `json:"image_url,omitempty"`
+	ImageUUID      string   `json:"image_uuid,omitempty"`
+	FocusX         *float64 `json:"focus_x,omitempty"`
+	FocusY         *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL string   `json:"header_image_url,omitempty"`
+	HeaderUUID     string   `json:"entity_header_uuid,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleJournal into its JSON-encoded form if it
 // has the required populated fields.
 func (sj SimpleJournal) MarshalJSON() ([]byte, error) {
-	if blank.Is(sj.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleJournal into JSON with a missing Name")
+	if err := requireField("SimpleJournal", "Name", sj.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleJournal
 	return json.Marshal(alias(sj))
 }
 
+// Simple returns a copy of the SimpleJournal data of the Journal, ready to be
+// modified and passed to Update. The path of the current image is left out and
+// the slices of the copy do not share memory with the Journal.
+func (j *Journal) Simple() SimpleJournal {
+	s := j.SimpleJournal
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+
+	return s
+}
+
 // JournalService handles communication with the Journal endpoint.
 type JournalService service
 
+// base returns the baseService implementing the common operations of the
+// JournalService.
+func (js *JournalService) base() baseService[Journal, SimpleJournal] {
+	return baseService[Journal, SimpleJournal]{
+		service: (*service)(js),
+		kind:    "Journal",
+		entity:  "journal",
+		label:   func(jrn SimpleJournal) string { return jrn.Name },
+	}
+}
+
 // Index returns the list of all Journals in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Journals that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Journals that were decoded.
 func (js *JournalService) Index(campID int, sync *time.Time) ([]*Journal, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(js.end)
-
-	if sync != nil {
-		end = end.sync(*sync)
-	}
-
-	var wrap struct {
-		Data []*Journal `json:"data"`
-	}
+	return js.base().Index(campID, sync)
+}
 
-	err = js.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Journal Index from Campaign (ID: %d): %w", campID, err)
-	}
+// IndexAll returns the list of all Journals in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Journals that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Journals that were decoded.
+func (js *JournalService) IndexAll(campID int, sync *time.Time) ([]*Journal, error) {
+	return js.base().IndexAll(campID, sync)
+}
 
-	return wrap.Data, nil
+// GetMany returns the Journals associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Journals, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Journals that were
+// found.
+func (js *JournalService) GetMany(campID int, ids []int) (map[int]*Journal, error) {
+	return js.base().GetMany(campID, ids)
 }
 
-// Get returns the Journal associated with jrnID from the Campaign
-// associated with campID.
-func (js *JournalService) Get(campID int, jrnID int) (*Journal, error) {
+// Count returns the number of Journals in the Campaign associated with campID
+// without retrieving them.
+func (js *JournalService) Count(campID int) (int, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(js.end)
 
-	end, err = end.id(jrnID)
+	n, err := js.client.count(end)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Journal ID: %w", err)
+		return 0, fmt.Errorf("cannot count Journals in Campaign (ID: %d): %w", campID, err)
 	}
 
-	var wrap struct {
-		Data *Journal `json:"data"`
-	}
+	return n, nil
+}
 
-	err = js.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Journal (ID: %d) from Campaign (ID: %d): %w", jrnID, campID, err)
-	}
+// Get returns the Journal associated with jrnID from the Campaign
+// associated with campID.
+func (js *JournalService) Get(campID int, jrnID int) (*Journal, error) {
+	return js.base().Get(campID, jrnID)
+}
 
-	return wrap.Data, nil
+// GetByName returns the Journal with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Journal has the name and ErrAmbiguous if more than one does.
+func (js *JournalService) GetByName(campID int, name string) (*Journal, error) {
+	return js.base().GetByName(campID, name)
 }
 
 // Create creates a new Journal in the Campaign associated with campID using
 // the provided SimpleJournal data.
 // Create returns the newly created Journal.
-func (js *JournalService) Create(campID int, jrn SimpleJournal) (*Journal, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(js.end)
-
-	b, err := json.Marshal(jrn)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleJournal (Name: %s): %w", jrn.Name, err)
-	}
-
-	var wrap struct {
-		Data *Journal `json:"data"`
-	}
-
-	err = js.client.post(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot create Journal (Name: %s) for Campaign (ID: %d): %w", jrn.Name, campID, err)
-	}
+// The provided CreateOptions, such as Public, apply to this call only.
+func (js *JournalService) Create(campID int, jrn SimpleJournal, opts ...CreateOption) (*Journal, error) {
+	return js.base().Create(campID, jrn, opts...)
+}
 
-	return wrap.Data, nil
+// CreateWithResult creates a new Journal in the Campaign associated with campID
+// using the provided SimpleJournal data.
+// CreateWithResult returns the newly created Journal along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (js *JournalService) CreateWithResult(campID int, jrn SimpleJournal, opts ...CreateOption) (*Journal, *WriteResult, error) {
+	return js.base().CreateWithResult(campID, jrn, opts...)
 }
 
 // Update updates an existing Journal associated with jrnID from the
 // Campaign associated with campID using the provided SimpleJournal data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Journal returned by Kanka, provide its SimpleJournal.
 // Update returns the newly updated Journal.
 func (js *JournalService) Update(campID int, jrnID int, jrn SimpleJournal) (*Journal, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(js.end)
-
-	end, err = end.id(jrnID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Journal ID: %w", err)
-	}
+	return js.base().Update(campID, jrnID, jrn)
+}
 
-	b, err := json.Marshal(jrn)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleJournal (Name: %s): %w", jrn.Name, err)
-	}
+// UpdateWithResult updates an existing Journal associated with jrnID from the
+// Campaign associated with campID using the provided SimpleJournal data, as
+// Update does.
+// UpdateWithResult returns the newly updated Journal along with the metadata of
+// Kanka's response, such as its status code.
+func (js *JournalService) UpdateWithResult(campID int, jrnID int, jrn SimpleJournal) (*Journal, *WriteResult, error) {
+	return js.base().UpdateWithResult(campID, jrnID, jrn)
+}
 
-	var wrap struct {
-		Data *Journal `json:"data"`
-	}
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Journal associated with jrnID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Journal is left unchanged.
+// Clear returns the newly updated Journal.
+func (js *JournalService) Clear(campID int, jrnID int, fields ...string) (*Journal, error) {
+	return js.base().Clear(campID, jrnID, fields...)
+}
 
-	err = js.client.put(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot update Journal (Name: %s) for Campaign (ID: %d): '%w'", jrn.Name, campID, err)
-	}
+// SetHeader sets the header image of an existing Journal associated with jrnID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Journal on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Journal.
+func (js *JournalService) SetHeader(campID int, jrnID int, uuid string) (*Journal, error) {
+	return js.base().SetHeader(campID, jrnID, uuid)
+}
 
-	return wrap.Data, nil
+// SetHeaderURL sets the header image of an existing Journal associated with
+// jrnID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Journal.
+func (js *JournalService) SetHeaderURL(campID int, jrnID int, url string) (*Journal, error) {
+	return js.base().SetHeaderURL(campID, jrnID, url)
 }
 
 // Delete deletes an existing Journal associated with jrnID from the
 // Campaign associated with campID.
 func (js *JournalService) Delete(campID int, jrnID int) error {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(js.end)
-
-	end, err = end.id(jrnID)
-	if err != nil {
-		return fmt.Errorf("invalid Journal ID: %w", err)
-	}
-
-	err = js.client.delete(end)
-	if err != nil {
-		return fmt.Errorf("cannot delete Journal (ID: %d) for Campaign (ID: %d): %w", jrnID, campID, err)
-	}
-
-	return nil
+	return js.base().Delete(campID, jrnID)
 }
@@ -1,14 +1,38 @@
 package kanka
 
 import (
+	"compress/gzip"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"log"
 	"net/http"
+	"strings"
+	"time"
 )
 
-const kankaURL string = "https://kanka.io/api/1.0/"
+// kankaWebURL is the root of the Kanka website, where entity pages are served.
+const kankaWebURL string = "https://kanka.io/"
+
+// TestedAPIVersion is the version of the Kanka API this package is built and
+// tested against. Every request is sent to this version of the API. Kanka
+// exposes no endpoint or header reporting the version or revision of the API
+// it serves, so a breaking change deployed by Kanka under the same version
+// cannot be detected ahead of time; it surfaces as request or decode errors,
+// which WithStrictDecode makes stricter.
+const TestedAPIVersion string = "1.0"
+
+// kankaAPIPath is the path of the Kanka API relative to the website root.
+const kankaAPIPath string = "api/" + TestedAPIVersion + "/"
+
+const kankaURL string = kankaWebURL + kankaAPIPath
+
+// ErrDryRun is returned by every write request made by a Client in dry-run
+// mode in place of sending the request to Kanka.
+var ErrDryRun = errors.New("request not sent in dry-run mode")
 
 // service handles communication with a specific endpoint.
 type service struct {
@@ -22,7 +46,23 @@ type service struct {
 type Client struct {
 	http    *http.Client
 	rootURL string
+	webURL  string
 	token   string
+	timeout time.Duration
+	limiter *limiter
+	dryRun  bool
+	dryLog  *log.Logger
+	strict  bool
+	header  http.Header
+	// idempotentDelete makes delete treat 404 Not Found as success.
+	idempotentDelete bool
+	// defaultPrivate makes objects of a Campaign private when created.
+	defaultPrivate bool
+	// retries is the number of times a failed request is retried, waiting
+	// retryBase before the first retry and twice as long before each next.
+	retries     int
+	retryBase   time.Duration
+	retryPolicy RetryPolicy
 
 	// Services
 	Profiles            *ProfileService
@@ -36,27 +76,39 @@ type Client struct {
 	Items               *ItemService
 	Notes               *NoteService
 	Events              *EventService
+	Calendars           *CalendarService
 	Races               *RaceService
 	Quests              *QuestService
 	QuestCharacters     *QuestCharacterService
 	QuestLocations      *QuestLocationService
 	QuestItems          *QuestItemService
 	QuestOrganizations  *QuestOrganizationService
+	QuestElements       *QuestElementService
 	Journals            *JournalService
 	Tags                *TagService
+	Entities            *EntityService
+	Gallery             *GalleryService
+	Abilities           *AbilityService
+	Conversations       *ConversationService
 
 	Attributes        *AttributeService
 	EntityEvents      *EntityEventService
 	EntityInventories *EntityInventoryService
 	EntityNotes       *EntityNoteService
+	EntityPermissions *EntityPermissionService
 	EntityTags        *EntityTagService
+	EntityAssets      *EntityAssetService
+	EntityAbilities   *EntityAbilityService
 	Relations         *RelationService
+
+	ConversationParticipants *ConversationParticipantService
 }
 
 // NewClient returns an appropriately configured Client using the provided
 // OAuth token. A provided custom HTTP client can be used to make the API
 // requests otherwise a default HTTP client will be used instead.
-func NewClient(token string, custom *http.Client) *Client {
+// Any provided Options are applied to the Client in order.
+func NewClient(token string, custom *http.Client, opts ...Option) *Client {
 	if custom == nil {
 		custom = http.DefaultClient
 	}
@@ -64,6 +116,7 @@ func NewClient(token string, custom *http.Client) *Client {
 	c := &Client{
 		http:    custom,
 		rootURL: kankaURL,
+		webURL:  kankaWebURL,
 		token:   token,
 	}
 
@@ -78,25 +131,81 @@ func NewClient(token string, custom *http.Client) *Client {
 	c.Items = &ItemService{client: c, end: EndpointItem}
 	c.Notes = &NoteService{client: c, end: EndpointNote}
 	c.Events = &EventService{client: c, end: EndpointEvent}
+	c.Calendars = &CalendarService{client: c, end: EndpointCalendar}
 	c.Races = &RaceService{client: c, end: EndpointRace}
 	c.Quests = &QuestService{client: c, end: EndpointQuest}
 	c.QuestCharacters = &QuestCharacterService{client: c, end: EndpointQuestCharacters}
 	c.QuestLocations = &QuestLocationService{client: c, end: EndpointQuestLocation}
 	c.QuestItems = &QuestItemService{client: c, end: EndpointQuestItem}
 	c.QuestOrganizations = &QuestOrganizationService{client: c, end: EndpointQuestOrganization}
+	c.QuestElements = &QuestElementService{client: c, end: EndpointQuestElement}
 	c.Journals = &JournalService{client: c, end: EndpointJournal}
 	c.Tags = &TagService{client: c, end: EndpointTag}
+	c.Entities = &EntityService{client: c, end: endpointEntity}
+	c.Gallery = &GalleryService{client: c, end: EndpointGallery}
+	c.Abilities = &AbilityService{client: c, end: EndpointAbility}
+	c.Conversations = &ConversationService{client: c, end: EndpointConversation}
 
 	c.Attributes = &AttributeService{client: c, end: EndpointAttribute}
 	c.EntityEvents = &EntityEventService{client: c, end: EndpointEntityEvent}
 	c.EntityInventories = &EntityInventoryService{client: c, end: EndpointEntityInventory}
 	c.EntityNotes = &EntityNoteService{client: c, end: EndpointEntityNote}
+	c.EntityPermissions = &EntityPermissionService{client: c, end: EndpointEntityPermission}
 	c.EntityTags = &EntityTagService{client: c, end: EndpointEntityTag}
+	c.EntityAssets = &EntityAssetService{client: c, end: EndpointEntityAsset}
+	c.EntityAbilities = &EntityAbilityService{client: c, end: EndpointEntityAbility}
 	c.Relations = &RelationService{client: c, end: EndpointRelation}
 
+	c.ConversationParticipants = &ConversationParticipantService{client: c, end: EndpointConversationParticipant}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
 	return c
 }
 
+// services returns every service of the Client.
+func (c *Client) services() []*service {
+	return []*service{
+		(*service)(c.Profiles),
+		(*service)(c.Campaigns),
+		(*service)(c.Characters),
+		(*service)(c.Locations),
+		(*service)(c.MapPoints),
+		(*service)(c.Families),
+		(*service)(c.Organizations),
+		(*service)(c.OrganizationMembers),
+		(*service)(c.Items),
+		(*service)(c.Notes),
+		(*service)(c.Events),
+		(*service)(c.Calendars),
+		(*service)(c.Races),
+		(*service)(c.Quests),
+		(*service)(c.QuestCharacters),
+		(*service)(c.QuestLocations),
+		(*service)(c.QuestItems),
+		(*service)(c.QuestOrganizations),
+		(*service)(c.QuestElements),
+		(*service)(c.Journals),
+		(*service)(c.Tags),
+		(*service)(c.Entities),
+		(*service)(c.Gallery),
+		(*service)(c.Abilities),
+		(*service)(c.Conversations),
+		(*service)(c.Attributes),
+		(*service)(c.EntityEvents),
+		(*service)(c.EntityInventories),
+		(*service)(c.EntityNotes),
+		(*service)(c.EntityPermissions),
+		(*service)(c.EntityTags),
+		(*service)(c.EntityAssets),
+		(*service)(c.EntityAbilities),
+		(*service)(c.Relations),
+		(*service)(c.ConversationParticipants),
+	}
+}
+
 // request returns an appropriately configured HTTP request with the provided
 // method, endpoint, and body.
 func (c *Client) request(method string, end endpoint, body io.Reader) (*http.Request, error) {
@@ -107,44 +216,121 @@ func (c *Client) request(method string, end endpoint, body io.Reader) (*http.Req
 		return nil, fmt.Errorf("cannot create request with method '%s' for url '%s': %w", method, url, err)
 	}
 
-	req.Header.Add("Authorization", "Bearer "+c.token)
-	req.Header.Add("Accept", "application/json")
+	for key, vals := range c.header {
+		req.Header[key] = append([]string(nil), vals...)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	if req.Header.Get("Accept") == "" {
+		req.Header.Set("Accept", "application/json")
+	}
 
 	return req, nil
 }
 
-// send executes the provided request and stores the unmarshaled JSON result in
-// the provided empty interface.
+// send executes the provided request and stores the JSON result, decoded
+// directly from the response body, in the provided empty interface. If the
+// provided result is nil, the response body is discarded.
 func (c *Client) send(req *http.Request, result interface{}) error {
-	resp, err := c.http.Do(req)
+	if c.dryRun && req.Method != "GET" {
+		return c.skip(req)
+	}
+
+	if c.timeout > 0 {
+		ctx, cancel := context.WithTimeout(req.Context(), c.timeout)
+		defer cancel()
+		req = req.WithContext(ctx)
+	}
+
+	resp, err := c.do(req)
 	if err != nil {
-		return fmt.Errorf("http client cannot send request with method '%s' to url '%s': %w", req.Method, req.URL.String(), err)
+		return err
 	}
 	defer resp.Body.Close()
 
+	if err := decompress(resp); err != nil {
+		return fmt.Errorf("cannot decompress body data: %w", err)
+	}
+
+	if resp.StatusCode == http.StatusServiceUnavailable {
+		return newMaintenanceError(resp)
+	}
+
 	if !isSuccess(resp.StatusCode) {
-		return &serverError{code: resp.StatusCode, status: resp.Status, temporary: isTemporary(resp.StatusCode)}
+		return newServerError(resp)
 	}
 
-	b, err := ioutil.ReadAll(resp.Body)
+	if rec, ok := result.(recorder); ok {
+		rec.record(resp)
+	}
+
+	if result == nil {
+		return nil
+	}
+
+	// Numbers decoded into untyped values are kept as json.Number so that
+	// large IDs never lose precision by passing through a float64.
+	dec := json.NewDecoder(resp.Body)
+	dec.UseNumber()
+	if c.strict {
+		dec.DisallowUnknownFields()
+	}
+
+	err = dec.Decode(result)
 	if err != nil {
-		return fmt.Errorf("cannot read response body: %w", err)
+		return fmt.Errorf("cannot decode body data: %w", err)
 	}
 
-	err = json.Unmarshal(b, &result)
+	return nil
+}
+
+// decompress replaces the body of the provided response with a decompressing
+// reader if the body is still compressed with gzip. The default transport of
+// an HTTP client requests gzip and decompresses the response on its own, but
+// leaves the body compressed if the Accept-Encoding header was set by the
+// caller, such as through WithHeader. An empty body is left as is.
+func decompress(resp *http.Response) error {
+	if resp.Uncompressed || !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	if err == io.EOF {
+		return nil
+	}
 	if err != nil {
-		return fmt.Errorf("cannot unmarshal body data: %w", err)
+		return err
 	}
+	resp.Body = zr
 
 	return nil
 }
 
-const paramRelated string = "?related=1"
+// skip logs the provided request without sending it and returns ErrDryRun.
+func (c *Client) skip(req *http.Request) error {
+	if c.dryLog == nil {
+		return ErrDryRun
+	}
+
+	var body []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		if err != nil {
+			return fmt.Errorf("cannot read request body: %w", err)
+		}
+		body = b
+	}
+
+	c.dryLog.Printf("dry run: %s %s %s", req.Method, req.URL.String(), body)
+	return ErrDryRun
+}
+
+const paramRelated string = "related"
 
 // get executes a GET request to the provided endpoint and stores the
 // unmarshaled JSON result in the provided empty interface.
 func (c *Client) get(end endpoint, result interface{}) error {
-	end = end.append(paramRelated)
+	end = end.query(paramRelated, "1")
 
 	req, err := c.request("GET", end, nil)
 	if err != nil {
@@ -161,6 +347,8 @@ func (c *Client) get(end endpoint, result interface{}) error {
 
 // post executes a POST request to the provided endpoint with the provided body
 // and stores the unmarshaled JSON result in the provided empty interface.
+// post is never retried because Kanka does not support idempotent creation and
+// a lost response could otherwise result in a duplicate object.
 func (c *Client) post(end endpoint, body io.Reader, result interface{}) error {
 	req, err := c.request("POST", end, body)
 	if err != nil {
@@ -195,21 +383,40 @@ func (c *Client) put(end endpoint, body io.Reader, result interface{}) error {
 	return nil
 }
 
-// delete executes a DELETE request to the provided endpoint.
-func (c *Client) delete(end endpoint) error {
-	req, err := c.request("DELETE", end, nil)
+// patch executes a PATCH request to the provided endpoint with the provided
+// body and stores the unmarshaled JSON result in the provided empty interface.
+// Unlike put, patch only updates the fields present in the body.
+func (c *Client) patch(end endpoint, body io.Reader, result interface{}) error {
+	req, err := c.request("PATCH", end, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("Content-Type", "application/json")
+
+	err = c.send(req, result)
 	if err != nil {
 		return err
 	}
 
-	resp, err := c.http.Do(req)
+	return nil
+}
+
+// delete executes a DELETE request to the provided endpoint. If the Client
+// has idempotent deletes enabled, a 404 Not Found response is not an error.
+func (c *Client) delete(end endpoint) error {
+	req, err := c.request("DELETE", end, nil)
 	if err != nil {
-		return fmt.Errorf("http client cannot send request with method '%s' to url '%s': %w", req.Method, req.URL.String(), err)
+		return err
 	}
-	defer resp.Body.Close()
 
-	if !isSuccess(resp.StatusCode) {
-		return &serverError{code: resp.StatusCode, status: resp.Status, temporary: isTemporary(resp.StatusCode)}
+	err = c.send(req, nil)
+	if err != nil {
+		var se *serverError
+		if c.idempotentDelete && errors.As(err, &se) && se.code == http.StatusNotFound {
+			return nil
+		}
+		return err
 	}
 
 	return nil
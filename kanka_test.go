@@ -1,8 +1,11 @@
 package kanka
 
 import (
+	"compress/gzip"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -56,3 +59,119 @@ func TestClient_request(t *testing.T) {
 		})
 	}
 }
+
+// testMethodClient returns a Client connected to a test server that responds
+// to each request method with the provided status and the contents of the
+// file associated with that method. The returned map records the number of
+// requests received for each method.
+func testMethodClient(t *testing.T, status int, files map[string]string) (*Client, *httptest.Server, map[string]int) {
+	counts := make(map[string]int)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counts[r.Method]++
+		w.WriteHeader(status)
+
+		file, ok := files[r.Method]
+		if !ok {
+			return
+		}
+
+		b, err := ioutil.ReadFile(file)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		w.Write(b)
+	}))
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	return c, ts, counts
+}
+
+// testRequest records the method, URL, and body of the last request received
+// by a test server.
+type testRequest struct {
+	method string
+	url    string
+	body   string
+}
+
+// testRecordClient returns a Client connected to a test server that responds
+// to every request with the provided status and the contents of the provided
+// file. The returned testRequest records the last request the server received.
+func testRecordClient(t *testing.T, status int, file string) (*Client, *httptest.Server, *testRequest) {
+	rec := &testRequest{}
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		rec.method = r.Method
+		rec.url = r.URL.String()
+		rec.body = string(b)
+
+		w.WriteHeader(status)
+		if file == "" {
+			return
+		}
+
+		b, err = ioutil.ReadFile(file)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		w.Write(b)
+	}))
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	return c, ts, rec
+}
+
+func TestClient_sendGzip(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{
+			name:   "Transport requests gzip",
+			header: "",
+		},
+		{
+			name:   "Caller requests gzip",
+			header: "gzip",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.Contains(r.Header.Get("Accept-Encoding"), "gzip") {
+					t.Errorf("got Accept-Encoding: <%s>, want gzip", r.Header.Get("Accept-Encoding"))
+				}
+
+				w.Header().Set("Content-Encoding", "gzip")
+				zw := gzip.NewWriter(w)
+				zw.Write([]byte(`{"data":{"id":111,"name":"Jon Snow"}}`))
+				zw.Close()
+			}))
+			defer ts.Close()
+
+			var opts []Option
+			if test.header != "" {
+				opts = append(opts, WithHeader("Accept-Encoding", test.header))
+			}
+			c := NewClient(testToken, ts.Client(), opts...)
+			c.rootURL = ts.URL + "/"
+
+			ch, err := c.Characters.Get(5272, 111)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if ch.Name != "Jon Snow" {
+				t.Errorf("got name: <%s>, want name: <%s>", ch.Name, "Jon Snow")
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package kanka
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// limiter spaces out requests so that no more than a fixed number of
+// requests are sent in each period.
+type limiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// newLimiter returns a limiter allowing n requests in each period.
+func newLimiter(n int, per time.Duration) *limiter {
+	return &limiter{
+		interval: per / time.Duration(n),
+	}
+}
+
+// Wait blocks until the next request may be sent or the provided context is
+// done, whichever happens first. If the context is done first, Wait returns
+// the context's error and gives up its reserved slot. If the context's
+// deadline falls before the reserved slot, Wait returns immediately with an
+// error wrapping context.DeadlineExceeded rather than blocking in vain.
+func (l *limiter) Wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	slot := l.next
+	if slot.Before(now) {
+		slot = now
+	}
+
+	if dl, ok := ctx.Deadline(); ok && dl.Before(slot) {
+		l.mu.Unlock()
+		return fmt.Errorf("rate limit would delay request past its deadline: %w", context.DeadlineExceeded)
+	}
+
+	l.next = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	d := slot.Sub(now)
+	if d <= 0 {
+		return nil
+	}
+
+	t := time.NewTimer(d)
+	defer t.Stop()
+
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		l.release(slot)
+		return ctx.Err()
+	}
+}
+
+// release gives up the slot reserved at the provided time if no later slot
+// has been reserved since.
+func (l *limiter) release(slot time.Time) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.next.Equal(slot.Add(l.interval)) {
+		l.next = slot
+	}
+}
@@ -0,0 +1,81 @@
+package kanka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestLimiter_Wait(t *testing.T) {
+	l := newLimiter(10, time.Second)
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := l.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("got elapsed: <%v>, want at least: <%v>", elapsed, 200*time.Millisecond)
+	}
+}
+
+func TestLimiter_Wait_Context(t *testing.T) {
+	tests := []struct {
+		name    string
+		ctx     func() (context.Context, context.CancelFunc)
+		wantErr error
+	}{
+		{
+			name: "Cancelled while waiting",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				time.AfterFunc(20*time.Millisecond, cancel)
+				return ctx, cancel
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name: "Already cancelled",
+			ctx: func() (context.Context, context.CancelFunc) {
+				ctx, cancel := context.WithCancel(context.Background())
+				cancel()
+				return ctx, cancel
+			},
+			wantErr: context.Canceled,
+		},
+		{
+			name: "Deadline before slot",
+			ctx: func() (context.Context, context.CancelFunc) {
+				return context.WithTimeout(context.Background(), time.Second)
+			},
+			wantErr: context.DeadlineExceeded,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			l := newLimiter(1, time.Hour)
+			if err := l.Wait(context.Background()); err != nil {
+				t.Fatal(err)
+			}
+			want := l.next
+
+			ctx, cancel := test.ctx()
+			defer cancel()
+
+			start := time.Now()
+			err := l.Wait(ctx)
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("got err: <%v>, want err: <%v>", err, test.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed > time.Second {
+				t.Errorf("got elapsed: <%v>, want Wait to return promptly", elapsed)
+			}
+			if !l.next.Equal(want) {
+				t.Errorf("got next slot: <%v>, want released slot: <%v>", l.next, want)
+			}
+		})
+	}
+}
@@ -13,16 +13,18 @@ import (
 // For more information, visit: https://kanka.io/en-US/docs/1.0/locations
 type Location struct {
 	SimpleLocation
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	IsMapPrivate   int       `json:"is_map_private"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	MapPrivacy      int       `json:"is_map_private"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -36,115 +38,215 @@ type Location struct {
 // SimpleLocation is primarily used to create new Locations for posting to
 // Kanka.
 type SimpleLocation struct {
-	Name             string `json:"name"`
-	Entry            string `json:"entry,omitempty"`
-	Type             string `json:"type,omitempty"`
-	ParentLocationID int    `json:"parent_location_id,omitempty"`
-	Tags             []int  `json:"tags,omitempty"`
-	IsPrivate        bool   `json:"is_private,omitempty"`
-	Image            string `json:"image,omitempty"`
-	ImageURL         string `json:"image_url,omitempty"`
-	Map              string `json:"map,omitempty"`
-	MapURL           string `json:"map_url,omitempty"`
+	Name             string   `json:"name"`
+	Entry            string   `json:"entry,omitempty"`
+	Type             string   `json:"type,omitempty"`
+	ParentLocationID int      `json:"parent_location_id,omitempty"`
+	Tags             []int    `json:"tags,omitempty"`
+	IsPrivate        bool     `json:"is_private,omitempty"`
+	IsTemplate       *bool    `json:"is_template,omitempty"`
+	Image            string   `json:"image,omitempty"`
+	ImageURL         string   `json:"image_url,omitempty"`
+	ImageUUID        string   `json:"image_uuid,omitempty"`
+	FocusX           *float64 `json:"focus_x,omitempty"`
+	FocusY           *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL   string   `json:"header_image_url,omitempty"`
+	HeaderUUID       string   `json:"entity_header_uuid,omitempty"`
+	Map              string   `json:"map,omitempty"`
+	MapURL           string   `json:"map_url,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
+	// IsMapPrivate hides the map from non-admins; Kanka reports it as MapPrivacy.
+	IsMapPrivate *bool `json:"is_map_private,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleLocation into its JSON-encoded form if it has
 // the required populated fields.
 func (sl SimpleLocation) MarshalJSON() ([]byte, error) {
-	if blank.Is(sl.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleLocation into JSON with a missing Name")
+	if err := requireField("SimpleLocation", "Name", sl.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleLocation
 	return json.Marshal(alias(sl))
 }
 
+// Simple returns a copy of the SimpleLocation data of the Location, ready to be
+// modified and passed to Update. The path of the current image is left out and
+// the slices of the copy do not share memory with the Location. Kanka reports
+// the privacy of the map as a number, so its MapPrivacy is carried over into
+// the IsMapPrivate flag of the copy.
+func (l *Location) Simple() SimpleLocation {
+	s := l.SimpleLocation
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+
+	mapPrivate := l.MapPrivacy != 0
+	s.IsMapPrivate = &mapPrivate
+
+	return s
+}
+
 // LocationService handles communication with the Location endpoint.
 type LocationService service
 
+// base returns the baseService implementing the common operations of the
+// LocationService.
+func (ls *LocationService) base() baseService[Location, SimpleLocation] {
+	return baseService[Location, SimpleLocation]{
+		service: (*service)(ls),
+		kind:    "Location",
+		entity:  "location",
+		label:   func(loc SimpleLocation) string { return loc.Name },
+	}
+}
+
 // Index returns the list of all Locations in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Locations that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Locations that were decoded.
 func (ls *LocationService) Index(campID int, sync *time.Time) ([]*Location, error) {
+	return ls.base().Index(campID, sync)
+}
+
+// IndexAll returns the list of all Locations in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Locations that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Locations that were decoded.
+func (ls *LocationService) IndexAll(campID int, sync *time.Time) ([]*Location, error) {
+	return ls.base().IndexAll(campID, sync)
+}
+
+// GetMany returns the Locations associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Locations, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Locations that were
+// found.
+func (ls *LocationService) GetMany(campID int, ids []int) (map[int]*Location, error) {
+	return ls.base().GetMany(campID, ids)
+}
+
+// Count returns the number of Locations in the Campaign associated with campID
+// without retrieving them.
+func (ls *LocationService) Count(campID int) (int, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(ls.end)
 
-	if sync != nil {
-		end = end.sync(*sync)
-	}
-
-	var wrap struct {
-		Data []*Location `json:"data"`
-	}
-
-	err = ls.client.get(end, &wrap)
+	n, err := ls.client.count(end)
 	if err != nil {
-		return nil, fmt.Errorf("cannot get Location Index from Campaign (ID: %d): %w", campID, err)
+		return 0, fmt.Errorf("cannot count Locations in Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	return n, nil
 }
 
 // Get returns the Location associated with locID from the Campaign
 // associated with campID.
 func (ls *LocationService) Get(campID int, locID int) (*Location, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(ls.end)
-
-	end, err = end.id(locID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Location ID: %w", err)
-	}
-
-	var wrap struct {
-		Data *Location `json:"data"`
-	}
+	return ls.base().Get(campID, locID)
+}
 
-	err = ls.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Location (ID: %d) from Campaign (ID: %d): %w", locID, campID, err)
-	}
+// GetByName returns the Location with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Location has the name and ErrAmbiguous if more than one does.
+func (ls *LocationService) GetByName(campID int, name string) (*Location, error) {
+	return ls.base().GetByName(campID, name)
+}
 
-	return wrap.Data, nil
+// Children returns the list of all Locations in the Campaign associated with
+// campID whose parent is the Location associated with locID. Only the direct
+// children are returned. Children follows every page of the list, each a
+// separate request subject to the rate limit of the Client.
+// If a non-nil time is provided, Children will only return Locations that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Locations that were decoded.
+func (ls *LocationService) Children(campID int, locID int, sync *time.Time) ([]*Location, error) {
+	return ls.base().children(campID, locID, "parent_location_id", func(x *Location) int { return x.ParentLocationID }, sync)
 }
 
 // Create creates a new Location in the Campaign associated with campID using
 // the provided SimpleLocation data.
 // Create returns the newly created Location.
-func (ls *LocationService) Create(campID int, loc SimpleLocation) (*Location, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(ls.end)
-
-	b, err := json.Marshal(loc)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleLocation (Name: %s): %w", loc.Name, err)
-	}
-
-	var wrap struct {
-		Data *Location `json:"data"`
-	}
-
-	err = ls.client.post(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot create Location (Name: %s) for Campaign (ID: %d): %w", loc.Name, campID, err)
-	}
+// The provided CreateOptions, such as Public, apply to this call only.
+func (ls *LocationService) Create(campID int, loc SimpleLocation, opts ...CreateOption) (*Location, error) {
+	return ls.base().Create(campID, loc, opts...)
+}
 
-	return wrap.Data, nil
+// CreateWithResult creates a new Location in the Campaign associated with campID
+// using the provided SimpleLocation data.
+// CreateWithResult returns the newly created Location along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (ls *LocationService) CreateWithResult(campID int, loc SimpleLocation, opts ...CreateOption) (*Location, *WriteResult, error) {
+	return ls.base().CreateWithResult(campID, loc, opts...)
 }
 
 // Update updates an existing Location associated with locID from the
 // Campaign associated with campID using the provided SimpleLocation data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Location returned by Kanka, provide its SimpleLocation.
 // Update returns the newly updated Location.
 func (ls *LocationService) Update(campID int, locID int, loc SimpleLocation) (*Location, error) {
+	return ls.base().Update(campID, locID, loc)
+}
+
+// UpdateWithResult updates an existing Location associated with locID from the
+// Campaign associated with campID using the provided SimpleLocation data, as
+// Update does.
+// UpdateWithResult returns the newly updated Location along with the metadata of
+// Kanka's response, such as its status code.
+func (ls *LocationService) UpdateWithResult(campID int, locID int, loc SimpleLocation) (*Location, *WriteResult, error) {
+	return ls.base().UpdateWithResult(campID, locID, loc)
+}
+
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Location associated with locID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Location is left unchanged.
+// Clear returns the newly updated Location.
+func (ls *LocationService) Clear(campID int, locID int, fields ...string) (*Location, error) {
+	return ls.base().Clear(campID, locID, fields...)
+}
+
+// SetHeader sets the header image of an existing Location associated with locID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Location on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Location.
+func (ls *LocationService) SetHeader(campID int, locID int, uuid string) (*Location, error) {
+	return ls.base().SetHeader(campID, locID, uuid)
+}
+
+// SetHeaderURL sets the header image of an existing Location associated with
+// locID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Location.
+func (ls *LocationService) SetHeaderURL(campID int, locID int, url string) (*Location, error) {
+	return ls.base().SetHeaderURL(campID, locID, url)
+}
+
+// SetParent sets the parent Location of the Location associated with locID in the
+// Campaign associated with campID to the Location associated with parentID.
+// A parentID of 0 removes the parent Location. Only the parent is updated; every
+// other field of the Location is left unchanged.
+// SetParent returns the newly updated Location.
+func (ls *LocationService) SetParent(campID int, locID int, parentID int) (*Location, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
@@ -156,18 +258,21 @@ func (ls *LocationService) Update(campID int, locID int, loc SimpleLocation) (*L
 		return nil, fmt.Errorf("invalid Location ID: %w", err)
 	}
 
-	b, err := json.Marshal(loc)
+	fields, err := parentFields("parent_location_id", parentID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleLocation (Name: %s): %w", loc.Name, err)
+		return nil, fmt.Errorf("invalid parent Location ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *Location `json:"data"`
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal parent Location (ID: %d): %w", parentID, err)
 	}
 
-	err = ls.client.put(end, bytes.NewReader(b), &wrap)
+	var wrap response[*Location]
+
+	err = ls.client.patch(end, bytes.NewReader(b), &wrap)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update Location (Name: %s) for Campaign (ID: %d): '%w'", loc.Name, campID, err)
+		return nil, fmt.Errorf("cannot set parent of Location (ID: %d) for Campaign (ID: %d): %w", locID, campID, err)
 	}
 
 	return wrap.Data, nil
@@ -176,21 +281,57 @@ func (ls *LocationService) Update(campID int, locID int, loc SimpleLocation) (*L
 // Delete deletes an existing Location associated with locID from the
 // Campaign associated with campID.
 func (ls *LocationService) Delete(campID int, locID int) error {
-	end, err := EndpointCampaign.id(campID)
+	return ls.base().Delete(campID, locID)
+}
+
+// Path returns the chain of Locations from the root of the location tree to the
+// Location associated with locID in the Campaign associated with campID, such as
+// the breadcrumbs "Continent > Kingdom > City". The chain ends with the Location itself.
+func (ls *LocationService) Path(campID int, locID int) ([]*Location, error) {
+	paths, err := ls.Paths(campID, []int{locID})
 	if err != nil {
-		return fmt.Errorf("invalid Campaign ID: %w", err)
+		return nil, err
 	}
-	end = end.concat(ls.end)
 
-	end, err = end.id(locID)
+	return paths[locID], nil
+}
+
+// Paths returns the Path of each Location associated with the provided locIDs
+// in the Campaign associated with campID, keyed by Location ID. Each Location is
+// fetched at most once, even if it is an ancestor shared by several Locations.
+func (ls *LocationService) Paths(campID int, locIDs []int) (map[int][]*Location, error) {
+	return ls.base().paths(campID, locIDs, func(x *Location) int { return x.ParentLocationID })
+}
+
+// LocationMap contains a Location along with its map and the MapPoints placed
+// on the map.
+type LocationMap struct {
+	Location *Location
+	// Map is the path of the image of the map, as returned by Kanka.
+	Map    string
+	Points []*MapPoint
+}
+
+// GetMap returns the Location associated with locID from the Campaign
+// associated with campID along with its map and the MapPoints placed on the
+// map. The Location and its MapPoints are retrieved with separate requests,
+// each subject to the rate limit of the Client, if any.
+// If no map is linked to the Location, GetMap returns an error wrapping
+// ErrNotFound without requesting the MapPoints.
+func (ls *LocationService) GetMap(campID int, locID int) (*LocationMap, error) {
+	loc, err := ls.Get(campID, locID)
 	if err != nil {
-		return fmt.Errorf("invalid Location ID: %w", err)
+		return nil, err
+	}
+
+	if blank.Is(loc.Map) {
+		return nil, fmt.Errorf("cannot get map of Location (ID: %d) from Campaign (ID: %d): %w", locID, campID, ErrNotFound)
 	}
 
-	err = ls.client.delete(end)
+	pts, err := ls.client.MapPoints.Index(campID, locID, nil)
 	if err != nil {
-		return fmt.Errorf("cannot delete Location (ID: %d) for Campaign (ID: %d): %w", locID, campID, err)
+		return nil, fmt.Errorf("cannot get map points of Location (ID: %d) from Campaign (ID: %d): %w", locID, campID, err)
 	}
 
-	return nil
+	return &LocationMap{Location: loc, Map: loc.Map, Points: pts}, nil
 }
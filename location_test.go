@@ -1,8 +1,13 @@
 package kanka
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
+	"strings"
 	"testing"
 	"time"
 
@@ -10,10 +15,11 @@ import (
 )
 
 const (
-	testLocationIndex  string = "test_data/location_index.json"
-	testLocationGet    string = "test_data/location_get.json"
-	testLocationCreate string = "test_data/location_create.json"
-	testLocationUpdate string = "test_data/location_update.json"
+	testLocationIndex    string = "test_data/location_index.json"
+	testLocationGet      string = "test_data/location_get.json"
+	testLocationCreate   string = "test_data/location_create.json"
+	testLocationUpdate   string = "test_data/location_update.json"
+	testLocationChildren string = "test_data/location_children.json"
 )
 
 func TestLocationService_Index(t *testing.T) {
@@ -130,7 +136,140 @@ func TestLocationService_Index(t *testing.T) {
 	}
 }
 
+func TestLocationService_Children(t *testing.T) {
+	locs := []*Location{
+		{
+			SimpleLocation: SimpleLocation{
+				Name:             "Winterfell",
+				Type:             "Castle",
+				ParentLocationID: 115366,
+			},
+			ID: 115368,
+		},
+		{
+			SimpleLocation: SimpleLocation{
+				Name:             "Wintertown",
+				Type:             "Town",
+				ParentLocationID: 115366,
+			},
+			ID: 115369,
+		},
+	}
+
+	type args struct {
+		campID int
+		locID  int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*Location
+		wantURL string
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testLocationChildren,
+			args:    args{campID: 5272, locID: 115366},
+			want:    locs,
+			wantURL: "/campaigns/5272/locations?parent_location_id=115366&related=1",
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, valid response, no children",
+			status:  http.StatusOK,
+			file:    testLocationChildren,
+			args:    args{campID: 5272, locID: 999},
+			want:    nil,
+			wantURL: "/campaigns/5272/locations?parent_location_id=999&related=1",
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testLocationChildren,
+			args:    args{campID: -123, locID: 115366},
+			want:    nil,
+			wantURL: "",
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid locID",
+			status:  http.StatusOK,
+			file:    testLocationChildren,
+			args:    args{campID: 5272, locID: 0},
+			want:    nil,
+			wantURL: "",
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, locID: 115366},
+			want:    nil,
+			wantURL: "/campaigns/5272/locations?parent_location_id=115366&related=1",
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, test.file)
+			defer ts.Close()
+
+			got, err := c.Locations.Children(test.args.campID, test.args.locID, nil)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+			if rec.url != test.wantURL {
+				t.Errorf("got url: <%s>, want url: <%s>", rec.url, test.wantURL)
+			}
+		})
+	}
+}
+
+func TestLocationService_ChildrenPages(t *testing.T) {
+	var pages int
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"data":[{"id":115369,"name":"Wintertown","parent_location_id":115366}],"links":{"next":null}}`))
+			return
+		}
+		fmt.Fprintf(w, `{"data":[{"id":115368,"name":"Winterfell","parent_location_id":115366},{"id":115370,"name":"Dreadfort","parent_location_id":115367}],"links":{"next":"%s/campaigns/5272/locations?page=2"}}`, ts.URL)
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	locs, err := c.Locations.Children(5272, 115366, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int
+	for _, loc := range locs {
+		got = append(got, loc.ID)
+	}
+	if diff := cmp.Diff(got, []int{115368, 115369}); diff != "" {
+		t.Errorf(diff)
+	}
+	if pages != 2 {
+		t.Errorf("got pages: <%d>, want pages: <2>", pages)
+	}
+}
+
 func TestLocationService_Get(t *testing.T) {
+	attrPrivate := true
 	loc := &Location{
 		SimpleLocation: SimpleLocation{
 			Name:             "Winterfell",
@@ -141,6 +280,8 @@ func TestLocationService_Get(t *testing.T) {
 			Type:             "Castle",
 			Map:              "locations/7MHptkcOx4MpyAxPokfZCB4bGVDLEXq9nCHe2Tex.jpeg",
 			ParentLocationID: 115366,
+
+			IsAttributesPrivate: &attrPrivate,
 		},
 		ID:             115368,
 		ImageFull:      "https://kanka-user-assets.s3.eu-central-1.amazonaws.com/locations/ox87nkFQWMn9tTpLuXNk56fq0Du2V3HjocFl9ROY.jpeg",
@@ -149,7 +290,7 @@ func TestLocationService_Get(t *testing.T) {
 		EntityID:       436726,
 		CreatedBy:      5600,
 		UpdatedBy:      5600,
-		IsMapPrivate:   0,
+		MapPrivacy:     0,
 	}
 
 	type args struct {
@@ -560,3 +701,229 @@ func TestLocationService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestLocationService_SetParent(t *testing.T) {
+	type args struct {
+		campID   int
+		locID    int
+		parentID int
+	}
+	tests := []struct {
+		name     string
+		status   int
+		file     string
+		args     args
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, valid response, valid args",
+			status:   http.StatusOK,
+			file:     testLocationUpdate,
+			args:     args{campID: 5272, locID: 111, parentID: 222},
+			wantBody: `{"parent_location_id":222}`,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, valid response, cleared parent",
+			status:   http.StatusOK,
+			file:     testLocationUpdate,
+			args:     args{campID: 5272, locID: 111, parentID: 0},
+			wantBody: `{"parent_location_id":null}`,
+			wantErr:  false,
+		},
+		{
+			name:     "Status OK, valid response, invalid campID",
+			status:   http.StatusOK,
+			file:     testLocationUpdate,
+			args:     args{campID: -123, locID: 111, parentID: 222},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Status OK, valid response, invalid locID",
+			status:   http.StatusOK,
+			file:     testLocationUpdate,
+			args:     args{campID: 5272, locID: -123, parentID: 222},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Status OK, valid response, invalid parentID",
+			status:   http.StatusOK,
+			file:     testLocationUpdate,
+			args:     args{campID: 5272, locID: 111, parentID: -123},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusNotFound, valid args",
+			status:   http.StatusNotFound,
+			file:     testFileEmpty,
+			args:     args{campID: 5272, locID: 111, parentID: 222},
+			wantBody: `{"parent_location_id":222}`,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, test.file)
+			defer ts.Close()
+
+			got, err := c.Locations.SetParent(test.args.campID, test.args.locID, test.args.parentID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+			if test.wantErr {
+				return
+			}
+			if rec.method != "PATCH" {
+				t.Errorf("got method: <%s>, want method: <%s>", rec.method, "PATCH")
+			}
+			if got == nil || got.ID != 111 {
+				t.Errorf("got: <%v>, want Location with ID 111", got)
+			}
+		})
+	}
+}
+
+func TestLocationService_GetMap(t *testing.T) {
+	tests := []struct {
+		name       string
+		location   string
+		failPath   string
+		wantPoints []string
+		wantCalls  []string
+		wantErr    error
+	}{
+		{
+			name:       "Location with map",
+			location:   `{"data":{"id":222,"entity_id":430215,"name":"Winterfell","map":"locations/winterfell.jpeg"}}`,
+			failPath:   "",
+			wantPoints: []string{"Great Keep", "Godswood"},
+			wantCalls: []string{
+				"/campaigns/5272/locations/222",
+				"/campaigns/5272/locations/222/map_points",
+			},
+			wantErr: nil,
+		},
+		{
+			name:       "Location without map",
+			location:   `{"data":{"id":222,"entity_id":430215,"name":"Winterfell","map":null}}`,
+			failPath:   "",
+			wantPoints: nil,
+			wantCalls:  []string{"/campaigns/5272/locations/222"},
+			wantErr:    ErrNotFound,
+		},
+		{
+			name:       "Map points failure",
+			location:   `{"data":{"id":222,"entity_id":430215,"name":"Winterfell","map":"locations/winterfell.jpeg"}}`,
+			failPath:   "/campaigns/5272/locations/222/map_points",
+			wantPoints: nil,
+			wantCalls: []string{
+				"/campaigns/5272/locations/222",
+				"/campaigns/5272/locations/222/map_points",
+			},
+			wantErr: &serverError{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls []string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, r.URL.Path)
+
+				if r.URL.Path == test.failPath {
+					w.WriteHeader(http.StatusInternalServerError)
+					return
+				}
+
+				switch {
+				case strings.HasSuffix(r.URL.Path, "/locations/222"):
+					w.Write([]byte(test.location))
+				case strings.HasSuffix(r.URL.Path, "/map_points"):
+					w.Write([]byte(`{"data":[{"location_id":222,"name":"Great Keep"},{"location_id":222,"name":"Godswood"}]}`))
+				}
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			lm, err := c.Locations.GetMap(5272, 222)
+			switch want := test.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Fatal(err)
+				}
+			case *serverError:
+				var se *serverError
+				if !errors.As(err, &se) {
+					t.Errorf("got err: <%v>, want serverError", err)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Errorf("got err: <%v>, want err: <%v>", err, want)
+				}
+			}
+			if diff := cmp.Diff(calls, test.wantCalls); diff != "" {
+				t.Errorf(diff)
+			}
+
+			var got []string
+			if lm != nil {
+				if lm.Location.Name != "Winterfell" || lm.Map != "locations/winterfell.jpeg" {
+					t.Errorf("got Location and map: <%s, %s>, want: <Winterfell, locations/winterfell.jpeg>", lm.Location.Name, lm.Map)
+				}
+				for _, p := range lm.Points {
+					got = append(got, p.Name)
+				}
+			}
+			if diff := cmp.Diff(got, test.wantPoints); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestLocation_Simple(t *testing.T) {
+	f, err := os.Open(testLocationGet)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var wrap struct {
+		Data *Location `json:"data"`
+	}
+	if err := json.NewDecoder(f).Decode(&wrap); err != nil {
+		t.Fatal(err)
+	}
+	loc := wrap.Data
+
+	s := loc.Simple()
+	if s.IsAttributesPrivate == nil || !*s.IsAttributesPrivate {
+		t.Errorf("got IsAttributesPrivate: <%v>, want IsAttributesPrivate: <true>", s.IsAttributesPrivate)
+	}
+	if s.IsMapPrivate == nil || *s.IsMapPrivate {
+		t.Errorf("got IsMapPrivate: <%v>, want IsMapPrivate: <false>", s.IsMapPrivate)
+	}
+
+	c, ts, rec := testRecordClient(t, http.StatusOK, testLocationUpdate)
+	defer ts.Close()
+
+	mapPrivate := true
+	s.IsMapPrivate = &mapPrivate
+	if _, err := c.Locations.Update(5272, loc.ID, s); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, want := range []string{`"is_attributes_private":true`, `"is_map_private":true`} {
+		if !strings.Contains(rec.body, want) {
+			t.Errorf("got body: <%s>, want body containing: <%s>", rec.body, want)
+		}
+	}
+}
@@ -5,8 +5,6 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // MapPoint contains information about a specific map point.
@@ -34,17 +32,17 @@ type SimpleMapPoint struct {
 // MarshalJSON marshals the SimpleMapPoint into its JSON-encoded form if it
 // has the required populated fields.
 func (sm SimpleMapPoint) MarshalJSON() ([]byte, error) {
-	if blank.Is(sm.Color) {
-		return nil, fmt.Errorf("cannot marshal SimpleMapPoint into JSON with a missing Color")
+	if err := requireField("SimpleMapPoint", "Color", sm.Color); err != nil {
+		return nil, err
 	}
-	if blank.Is(sm.Icon) {
-		return nil, fmt.Errorf("cannot marshal SimpleMapPoint into JSON with a missing Icon")
+	if err := requireField("SimpleMapPoint", "Icon", sm.Icon); err != nil {
+		return nil, err
 	}
-	if blank.Is(sm.Shape) {
-		return nil, fmt.Errorf("cannot marshal SimpleMapPoint into JSON with a missing Shape")
+	if err := requireField("SimpleMapPoint", "Shape", sm.Shape); err != nil {
+		return nil, err
 	}
-	if blank.Is(sm.Size) {
-		return nil, fmt.Errorf("cannot marshal SimpleMapPoint into JSON with a missing Size")
+	if err := requireField("SimpleMapPoint", "Size", sm.Size); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleMapPoint
@@ -58,6 +56,8 @@ type MapPointService service
 // locID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return MapPoints that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the MapPoints that were decoded.
 func (ms *MapPointService) Index(campID int, locID int, sync *time.Time) ([]*MapPoint, error) {
 	var err error
 	end := EndpointCampaign
@@ -76,15 +76,18 @@ func (ms *MapPointService) Index(campID int, locID int, sync *time.Time) ([]*Map
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*MapPoint `json:"data"`
-	}
+	var wrap response[[]json.RawMessage]
 
 	if err = ms.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get MapPoint Index from Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	var list []*MapPoint
+	if err = decodeList(wrap.Data, &list, ms.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode MapPoint Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
 }
 
 // Create creates a new MapPoint for the location associated with locID in the
@@ -109,9 +112,7 @@ func (ms *MapPointService) Create(campID int, locID int, mp SimpleMapPoint) (*Ma
 		return nil, fmt.Errorf("cannot marshal SimpleMapPoint (Name: %s, TargetEntityID: %d): %w", mp.Name, mp.TargetEntityID, err)
 	}
 
-	var wrap struct {
-		Data *MapPoint `json:"data"`
-	}
+	var wrap response[*MapPoint]
 
 	if err = ms.client.post(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot create MapPoint (Name: %s, TargetEntityID: %d) for Campaign (ID: %d): %w", mp.Name, mp.TargetEntityID, campID, err)
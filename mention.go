@@ -0,0 +1,127 @@
+package kanka
+
+import (
+	"errors"
+	"fmt"
+	"html"
+	"regexp"
+	"strconv"
+)
+
+// EntryFormat specifies the form in which a rendered entry is returned.
+type EntryFormat int
+
+// Available formats for rendering entries.
+const (
+	// EntryText replaces each mention with the name of the mentioned entity.
+	EntryText EntryFormat = iota
+	// EntryHTML replaces each mention with a link to the mentioned entity's
+	// page on Kanka.
+	EntryHTML
+)
+
+// mentionPattern matches the mention tags Kanka stores in entries, such as
+// [character:123] or [character:123|custom text].
+var mentionPattern = regexp.MustCompile(`\[([a-z_]+):(\d+)(?:\|([^\]]*))?\]`)
+
+// RenderEntry returns the provided entry from the Campaign associated with
+// campID with its mention tags replaced in the provided format. The ID of a
+// mention is the ID of the mentioned Entity. Mentions with custom text are
+// rendered using that text instead of the entity's name. The mentioned
+// Entities are fetched together, as by ResolveMentions, and mentions of
+// Entities that cannot be found, such as deleted ones, are left as they are.
+// Records that cannot be decoded are reported in an error wrapping
+// RecordErrors alongside the entry, in which their mentions are left as they
+// are.
+func (c *Client) RenderEntry(campID int, entry string, format EntryFormat) (string, error) {
+	names, err := c.ResolveMentions(campID, []string{entry})
+	if names == nil {
+		return "", err
+	}
+
+	return c.render(campID, entry, format, names), err
+}
+
+// RenderEntries returns each of the provided entries from the Campaign
+// associated with campID rendered as by RenderEntry, in order. The Entities
+// mentioned in any of the entries are fetched together.
+func (c *Client) RenderEntries(campID int, entries []string, format EntryFormat) ([]string, error) {
+	names, err := c.ResolveMentions(campID, entries)
+	if names == nil {
+		return nil, err
+	}
+
+	out := make([]string, len(entries))
+	for i, entry := range entries {
+		out[i] = c.render(campID, entry, format, names)
+	}
+
+	return out, err
+}
+
+// ResolveMentions returns the name of every Entity mentioned in any of the
+// provided entries from the Campaign associated with campID, keyed by the ID
+// of the Entity. The mentioned Entities are fetched together using GetMany
+// rather than one request each. Entities that cannot be found, such as
+// deleted ones, are left out of the names.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the names that were resolved.
+func (c *Client) ResolveMentions(campID int, entries []string) (map[int]string, error) {
+	var ids []int
+	seen := make(map[int]bool)
+	for _, entry := range entries {
+		for _, m := range mentionPattern.FindAllStringSubmatch(entry, -1) {
+			id, err := strconv.Atoi(m[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid mention '%s': %w", m[0], err)
+			}
+
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	ents, err := c.Entities.GetMany(campID, ids)
+	var recErrs RecordErrors
+	if err != nil && !errors.Is(err, ErrNotFound) && !errors.As(err, &recErrs) {
+		return nil, fmt.Errorf("cannot resolve mentions: %w", err)
+	}
+
+	names := make(map[int]string, len(ents))
+	for id, ent := range ents {
+		names[id] = ent.Name
+	}
+
+	if recErrs != nil {
+		return names, fmt.Errorf("cannot resolve mentions: %w", err)
+	}
+
+	return names, nil
+}
+
+// render returns the provided entry from the Campaign associated with campID
+// with its mention tags replaced in the provided format using the provided
+// names of the mentioned entities. Mentions of entities without a name are
+// left as they are.
+func (c *Client) render(campID int, entry string, format EntryFormat, names map[int]string) string {
+	return mentionPattern.ReplaceAllStringFunc(entry, func(s string) string {
+		m := mentionPattern.FindStringSubmatch(s)
+		id, _ := strconv.Atoi(m[2])
+
+		text, ok := names[id]
+		if !ok {
+			return s
+		}
+		if m[3] != "" {
+			text = m[3]
+		}
+
+		if format != EntryHTML {
+			return text
+		}
+
+		return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(c.entityURL(campID, id)), html.EscapeString(text))
+	})
+}
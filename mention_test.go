@@ -0,0 +1,193 @@
+package kanka
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_RenderEntry(t *testing.T) {
+	type args struct {
+		campID int
+		entry  string
+		format EntryFormat
+	}
+	tests := []struct {
+		name     string
+		status   int
+		args     args
+		want     string
+		wantGets int
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, text format",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entry: "<p>Ask [character:430214] about it.</p>", format: EntryText},
+			want:     "<p>Ask Penny Galvenrise about it.</p>",
+			wantGets: 1,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, HTML format",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entry: "<p>Ask [character:430214] about it.</p>", format: EntryHTML},
+			want:     `<p>Ask <a href="https://kanka.io/campaign/5272/entities/430214">Penny Galvenrise</a> about it.</p>`,
+			wantGets: 1,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, repeated mention with custom text",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entry: "[character:430214|Penny] & [character:430214]", format: EntryHTML},
+			want:     `<a href="https://kanka.io/campaign/5272/entities/430214">Penny</a> & <a href="https://kanka.io/campaign/5272/entities/430214">Penny Galvenrise</a>`,
+			wantGets: 1,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, several mentions",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entry: "[character:430214] at [location:80918]", format: EntryText},
+			want:     "Penny Galvenrise at The Rope Shop",
+			wantGets: 1,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, deleted mention",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entry: "[character:430214] and [character:999|Old Nan]", format: EntryHTML},
+			want:     `<a href="https://kanka.io/campaign/5272/entities/430214">Penny Galvenrise</a> and [character:999|Old Nan]`,
+			wantGets: 1,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, no mentions",
+			status:   http.StatusOK,
+			args:     args{campID: 5272, entry: "<p>Nothing to see [here].</p>", format: EntryText},
+			want:     "<p>Nothing to see [here].</p>",
+			wantGets: 0,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, invalid campID",
+			status:   http.StatusOK,
+			args:     args{campID: -123, entry: "[character:430214]", format: EntryText},
+			want:     "",
+			wantGets: 0,
+			wantErr:  true,
+		},
+		{
+			name:     "StatusNotFound, valid args",
+			status:   http.StatusNotFound,
+			args:     args{campID: 5272, entry: "[character:430214]", format: EntryText},
+			want:     "",
+			wantGets: 1,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, counts := testMethodClient(t, test.status, map[string]string{"GET": testEntityIndex})
+			defer ts.Close()
+
+			got, err := c.RenderEntry(test.args.campID, test.args.entry, test.args.format)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if got != test.want {
+				t.Errorf("got: <%s>, want: <%s>", got, test.want)
+			}
+			if counts["GET"] != test.wantGets {
+				t.Errorf("got GET requests: <%d>, want GET requests: <%d>", counts["GET"], test.wantGets)
+			}
+		})
+	}
+}
+
+func TestClient_ResolveMentions(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		entries  []string
+		want     map[int]string
+		wantGets int
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, shared mention",
+			status:   http.StatusOK,
+			entries:  []string{"Ask [character:430214].", "[character:430214|Penny] knows.", "Nobody else."},
+			want:     map[int]string{430214: "Penny Galvenrise"},
+			wantGets: 1,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, no entries",
+			status:   http.StatusOK,
+			entries:  nil,
+			want:     map[int]string{},
+			wantGets: 0,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, unknown mention",
+			status:   http.StatusOK,
+			entries:  []string{"Ask [character:430214].", "Ask [character:999]."},
+			want:     map[int]string{430214: "Penny Galvenrise"},
+			wantGets: 1,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, two mentions",
+			status:   http.StatusOK,
+			entries:  []string{"Ask [character:430214] at [location:80918]."},
+			want:     map[int]string{430214: "Penny Galvenrise", 80918: "The Rope Shop"},
+			wantGets: 1,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusNotFound, valid entries",
+			status:   http.StatusNotFound,
+			entries:  []string{"Ask [character:430214]."},
+			want:     nil,
+			wantGets: 1,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, counts := testMethodClient(t, test.status, map[string]string{"GET": testEntityIndex})
+			defer ts.Close()
+
+			got, err := c.ResolveMentions(5272, test.entries)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if counts["GET"] != test.wantGets {
+				t.Errorf("got GET requests: <%d>, want GET requests: <%d>", counts["GET"], test.wantGets)
+			}
+		})
+	}
+}
+
+func TestClient_RenderEntries(t *testing.T) {
+	c, ts, counts := testMethodClient(t, http.StatusOK, map[string]string{"GET": testEntityIndex})
+	defer ts.Close()
+
+	got, err := c.RenderEntries(5272, []string{"Ask [character:430214].", "[character:430214|Penny] knows.", "[character:999] is gone."}, EntryText)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"Ask Penny Galvenrise.", "Penny knows.", "[character:999] is gone."}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf(diff)
+	}
+	if counts["GET"] != 1 {
+		t.Errorf("got GET requests: <%d>, want GET requests: <%d>", counts["GET"], 1)
+	}
+}
@@ -3,25 +3,26 @@ package kanka
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // Note contains information about a specific note.
 // For more information, visit: https://kanka.io/en-US/docs/1.0/notes
 type Note struct {
 	SimpleNote
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -34,158 +35,255 @@ type Note struct {
 // SimpleNote contains only the simple information about a note.
 // SimpleNote is primarily used to create new notes for posting to Kanka.
 type SimpleNote struct {
-	Name      string `json:"name"`
-	Entry     string `json:"entry,omitempty"`
-	Type      string `json:"type,omitempty"`
-	Tags      []int  `json:"tags,omitempty"`
-	IsPrivate bool   `json:"is_private,omitempty"`
-	Image     string `json:"image,omitempty"`
-	ImageURL  string `json:"image_url,omitempty"`
+	Name           string   `json:"name"`
+	Entry          string   `json:"entry,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	Tags           []int    `json:"tags,omitempty"`
+	IsPrivate      bool     `json:"is_private,omitempty"`
+	IsTemplate     *bool    `json:"is_template,omitempty"`
+	IsPinned       *bool    `json:"is_pinned,omitempty"`
+	Image          string   `json:"image,omitempty"`
+	ImageURL       string   `json:"image_url,omitempty"`
+	ImageUUID      string   `json:"image_uuid,omitempty"`
+	FocusX         *float64 `json:"focus_x,omitempty"`
+	FocusY         *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL string   `json:"header_image_url,omitempty"`
+	HeaderUUID     string   `json:"entity_header_uuid,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleNote into its JSON-encoded form if it
 // has the required populated fields.
 func (sn SimpleNote) MarshalJSON() ([]byte, error) {
-	if blank.Is(sn.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleNote into JSON with a missing Name")
+	if err := requireField("SimpleNote", "Name", sn.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleNote
 	return json.Marshal(alias(sn))
 }
 
+// Simple returns a copy of the SimpleNote data of the Note, ready to be
+// modified and passed to Update. The path of the current image is left out and
+// the slices of the copy do not share memory with the Note.
+func (n *Note) Simple() SimpleNote {
+	s := n.SimpleNote
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+
+	return s
+}
+
 // NoteService handles communication with the Note endpoint.
 type NoteService service
 
+// base returns the baseService implementing the common operations of the
+// NoteService.
+func (ns *NoteService) base() baseService[Note, SimpleNote] {
+	return baseService[Note, SimpleNote]{
+		service: (*service)(ns),
+		kind:    "Note",
+		entity:  "note",
+		label:   func(note SimpleNote) string { return note.Name },
+	}
+}
+
 // Index returns the list of all Notes in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Notes that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Notes that were decoded.
 func (ns *NoteService) Index(campID int, sync *time.Time) ([]*Note, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(ns.end)
-
-	if sync != nil {
-		end = end.sync(*sync)
-	}
-
-	var wrap struct {
-		Data []*Note `json:"data"`
-	}
+	return ns.base().Index(campID, sync)
+}
 
-	err = ns.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Note Index from Campaign (ID: %d): %w", campID, err)
-	}
+// IndexAll returns the list of all Notes in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Notes that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Notes that were decoded.
+func (ns *NoteService) IndexAll(campID int, sync *time.Time) ([]*Note, error) {
+	return ns.base().IndexAll(campID, sync)
+}
 
-	return wrap.Data, nil
+// GetMany returns the Notes associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Notes, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Notes that were
+// found.
+func (ns *NoteService) GetMany(campID int, ids []int) (map[int]*Note, error) {
+	return ns.base().GetMany(campID, ids)
 }
 
-// Get returns the Note associated with noteID from the Campaign
-// associated with campID.
-func (ns *NoteService) Get(campID int, noteID int) (*Note, error) {
+// Count returns the number of Notes in the Campaign associated with campID
+// without retrieving them.
+func (ns *NoteService) Count(campID int) (int, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(ns.end)
 
-	end, err = end.id(noteID)
+	n, err := ns.client.count(end)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Note ID: %w", err)
+		return 0, fmt.Errorf("cannot count Notes in Campaign (ID: %d): %w", campID, err)
 	}
 
-	var wrap struct {
-		Data *Note `json:"data"`
-	}
+	return n, nil
+}
 
-	err = ns.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Note (ID: %d) from Campaign (ID: %d): %w", noteID, campID, err)
-	}
+// Get returns the Note associated with noteID from the Campaign
+// associated with campID.
+func (ns *NoteService) Get(campID int, noteID int) (*Note, error) {
+	return ns.base().Get(campID, noteID)
+}
 
-	return wrap.Data, nil
+// GetByName returns the Note with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Note has the name and ErrAmbiguous if more than one does.
+func (ns *NoteService) GetByName(campID int, name string) (*Note, error) {
+	return ns.base().GetByName(campID, name)
 }
 
 // Create creates a new Note in the Campaign associated with campID using
 // the provided SimpleNote data.
 // Create returns the newly created Note.
-func (ns *NoteService) Create(campID int, note SimpleNote) (*Note, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(ns.end)
-
-	b, err := json.Marshal(note)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleNote (Name: %s): %w", note.Name, err)
-	}
-
-	var wrap struct {
-		Data *Note `json:"data"`
-	}
-
-	err = ns.client.post(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot create Note (Name: %s) for Campaign (ID: %d): %w", note.Name, campID, err)
-	}
+// The provided CreateOptions, such as Public, apply to this call only.
+func (ns *NoteService) Create(campID int, note SimpleNote, opts ...CreateOption) (*Note, error) {
+	return ns.base().Create(campID, note, opts...)
+}
 
-	return wrap.Data, nil
+// CreateWithResult creates a new Note in the Campaign associated with campID
+// using the provided SimpleNote data.
+// CreateWithResult returns the newly created Note along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (ns *NoteService) CreateWithResult(campID int, note SimpleNote, opts ...CreateOption) (*Note, *WriteResult, error) {
+	return ns.base().CreateWithResult(campID, note, opts...)
 }
 
 // Update updates an existing Note associated with noteID from the
 // Campaign associated with campID using the provided SimpleNote data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Note returned by Kanka, provide its SimpleNote.
 // Update returns the newly updated Note.
 func (ns *NoteService) Update(campID int, noteID int, note SimpleNote) (*Note, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(ns.end)
-
-	end, err = end.id(noteID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Note ID: %w", err)
-	}
+	return ns.base().Update(campID, noteID, note)
+}
 
-	b, err := json.Marshal(note)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleNote (Name: %s): %w", note.Name, err)
-	}
+// UpdateWithResult updates an existing Note associated with noteID from the
+// Campaign associated with campID using the provided SimpleNote data, as
+// Update does.
+// UpdateWithResult returns the newly updated Note along with the metadata of
+// Kanka's response, such as its status code.
+func (ns *NoteService) UpdateWithResult(campID int, noteID int, note SimpleNote) (*Note, *WriteResult, error) {
+	return ns.base().UpdateWithResult(campID, noteID, note)
+}
 
-	var wrap struct {
-		Data *Note `json:"data"`
-	}
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Note associated with noteID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Note is left unchanged.
+// Clear returns the newly updated Note.
+func (ns *NoteService) Clear(campID int, noteID int, fields ...string) (*Note, error) {
+	return ns.base().Clear(campID, noteID, fields...)
+}
 
-	err = ns.client.put(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot update Note (Name: %s) for Campaign (ID: %d): '%w'", note.Name, campID, err)
-	}
+// SetHeader sets the header image of an existing Note associated with noteID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Note on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Note.
+func (ns *NoteService) SetHeader(campID int, noteID int, uuid string) (*Note, error) {
+	return ns.base().SetHeader(campID, noteID, uuid)
+}
 
-	return wrap.Data, nil
+// SetHeaderURL sets the header image of an existing Note associated with
+// noteID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Note.
+func (ns *NoteService) SetHeaderURL(campID int, noteID int, url string) (*Note, error) {
+	return ns.base().SetHeaderURL(campID, noteID, url)
 }
 
 // Delete deletes an existing Note associated with noteID from the
 // Campaign associated with campID.
 func (ns *NoteService) Delete(campID int, noteID int) error {
+	return ns.base().Delete(campID, noteID)
+}
+
+// IndexPinned returns the list of pinned Notes in the Campaign associated
+// with campID from every page of the list.
+// If a non-nil time is provided, IndexPinned will only return Notes that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the pinned Notes that were decoded.
+func (ns *NoteService) IndexPinned(campID int, sync *time.Time) ([]*Note, error) {
+	notes, err := ns.IndexAll(campID, sync)
+	var recErrs RecordErrors
+	if err != nil && !errors.As(err, &recErrs) {
+		return nil, err
+	}
+
+	var pinned []*Note
+	for _, n := range notes {
+		if n.IsPinned != nil && *n.IsPinned {
+			pinned = append(pinned, n)
+		}
+	}
+
+	return pinned, err
+}
+
+// Pin pins the Note associated with noteID from the Campaign associated with
+// campID. Returns the updated Note if successful.
+func (ns *NoteService) Pin(campID int, noteID int) (*Note, error) {
+	return ns.setPinned(campID, noteID, true)
+}
+
+// Unpin unpins the Note associated with noteID from the Campaign associated
+// with campID. Returns the updated Note if successful.
+func (ns *NoteService) Unpin(campID int, noteID int) (*Note, error) {
+	return ns.setPinned(campID, noteID, false)
+}
+
+// setPinned partially updates the pinned state of the Note associated with
+// noteID. Only the pinned field is sent so that a false value is not dropped
+// by SimpleNote's omitempty tags.
+func (ns *NoteService) setPinned(campID int, noteID int, pinned bool) (*Note, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
-		return fmt.Errorf("invalid Campaign ID: %w", err)
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(ns.end)
 
 	end, err = end.id(noteID)
 	if err != nil {
-		return fmt.Errorf("invalid Note ID: %w", err)
+		return nil, fmt.Errorf("invalid Note ID: %w", err)
 	}
 
-	err = ns.client.delete(end)
+	b, err := json.Marshal(map[string]bool{"is_pinned": pinned})
 	if err != nil {
-		return fmt.Errorf("cannot delete Note (ID: %d) for Campaign (ID: %d): %w", noteID, campID, err)
+		return nil, fmt.Errorf("cannot marshal pinned state of Note (ID: %d): %w", noteID, err)
 	}
 
-	return nil
+	var wrap response[*Note]
+
+	err = ns.client.patch(end, bytes.NewReader(b), &wrap)
+	if err != nil {
+		return nil, fmt.Errorf("cannot set pinned state of Note (ID: %d) for Campaign (ID: %d): %w", noteID, campID, err)
+	}
+
+	return wrap.Data, nil
 }
@@ -10,10 +10,12 @@ import (
 )
 
 const (
-	testNoteIndex  string = "test_data/note_index.json"
-	testNoteGet    string = "test_data/note_get.json"
-	testNoteCreate string = "test_data/note_create.json"
-	testNoteUpdate string = "test_data/note_update.json"
+	testNoteIndex         string = "test_data/note_index.json"
+	testNoteGet           string = "test_data/note_get.json"
+	testNoteCreate        string = "test_data/note_create.json"
+	testNoteUpdate        string = "test_data/note_update.json"
+	testNotePinned        string = "test_data/note_pinned.json"
+	testNotePinnedPartial string = "test_data/note_pinned_partial.json"
 )
 
 func TestNoteService_Index(t *testing.T) {
@@ -557,3 +559,175 @@ func TestNoteService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestNoteService_IndexPinned(t *testing.T) {
+	pinned := true
+	notes := []*Note{
+		{
+			SimpleNote: SimpleNote{
+				Name:     "To Emilia",
+				Type:     "Letter",
+				IsPinned: &pinned,
+			},
+		},
+	}
+
+	type args struct {
+		campID int
+		sync   *time.Time
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*Note
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testNotePinned,
+			args:    args{campID: 5272, sync: nil},
+			want:    notes,
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, no pinned notes, valid args",
+			status:  http.StatusOK,
+			file:    testNoteIndex,
+			args:    args{campID: 5272, sync: nil},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, partially malformed response, valid args",
+			status:  http.StatusOK,
+			file:    testNotePinnedPartial,
+			args:    args{campID: 5272, sync: nil},
+			want:    notes,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testNotePinned,
+			args:    args{campID: -123, sync: nil},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, sync: nil},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Notes.IndexPinned(test.args.campID, test.args.sync)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestNoteService_Pin(t *testing.T) {
+	type args struct {
+		campID int
+		noteID int
+		pin    bool
+	}
+	tests := []struct {
+		name     string
+		status   int
+		file     string
+		args     args
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, valid response, pin",
+			status:   http.StatusOK,
+			file:     testNoteUpdate,
+			args:     args{campID: 5272, noteID: 111, pin: true},
+			wantBody: `{"is_pinned":true}`,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, valid response, unpin",
+			status:   http.StatusOK,
+			file:     testNoteUpdate,
+			args:     args{campID: 5272, noteID: 111, pin: false},
+			wantBody: `{"is_pinned":false}`,
+			wantErr:  false,
+		},
+		{
+			name:     "Status OK, valid response, invalid campID",
+			status:   http.StatusOK,
+			file:     testNoteUpdate,
+			args:     args{campID: -123, noteID: 111, pin: true},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Status OK, valid response, invalid noteID",
+			status:   http.StatusOK,
+			file:     testNoteUpdate,
+			args:     args{campID: 5272, noteID: -123, pin: true},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusNotFound, valid args",
+			status:   http.StatusNotFound,
+			file:     testFileEmpty,
+			args:     args{campID: 5272, noteID: 111, pin: false},
+			wantBody: `{"is_pinned":false}`,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, test.file)
+			defer ts.Close()
+
+			var got *Note
+			var err error
+			if test.args.pin {
+				got, err = c.Notes.Pin(test.args.campID, test.args.noteID)
+			} else {
+				got, err = c.Notes.Unpin(test.args.campID, test.args.noteID)
+			}
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+			if test.wantErr {
+				return
+			}
+			if rec.method != "PATCH" {
+				t.Errorf("got method: <%s>, want method: <%s>", rec.method, "PATCH")
+			}
+			if got == nil || got.ID != 111 {
+				t.Errorf("got: <%v>, want Note with ID 111", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package kanka
+
+import (
+	"log"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Option configures an optional setting of a Client.
+// Options are provided to NewClient.
+type Option func(*Client)
+
+// WithTimeout returns an Option that limits each request made by the Client
+// to the provided duration, including the time spent reading the response.
+// A non-positive duration disables the timeout.
+func WithTimeout(d time.Duration) Option {
+	return func(c *Client) {
+		c.timeout = d
+	}
+}
+
+// WithRateLimit returns an Option that limits the Client to sending at most n
+// requests in each period, spacing them out evenly. A request waiting for the
+// rate limit gives up as soon as its timeout, if any, expires. A non-positive
+// n or period disables the rate limit.
+// For more information about Kanka's rate limits, visit:
+// https://kanka.io/en-US/docs/1.0/setup#endpoints
+func WithRateLimit(n int, per time.Duration) Option {
+	return func(c *Client) {
+		if n <= 0 || per <= 0 {
+			c.limiter = nil
+			return
+		}
+		c.limiter = newLimiter(n, per)
+	}
+}
+
+// WithBaseURL returns an Option that points the Client at the Kanka instance
+// hosted at the provided base URL, such as "https://kanka.example.com/",
+// instead of kanka.io. The API is expected at the "api/1.0/" path of the
+// base URL.
+func WithBaseURL(base string) Option {
+	return func(c *Client) {
+		if !strings.HasSuffix(base, "/") {
+			base += "/"
+		}
+		c.webURL = base
+		c.rootURL = base + kankaAPIPath
+	}
+}
+
+// WithHeader returns an Option that adds the provided header to every request
+// made by the Client, such as the access token required by a proxy in front of
+// a self-hosted Kanka instance. Providing the same key more than once adds
+// each value. The Authorization header is always set to the Client's token
+// and cannot be replaced.
+func WithHeader(key string, value string) Option {
+	return func(c *Client) {
+		if c.header == nil {
+			c.header = make(http.Header)
+		}
+		c.header.Add(key, value)
+	}
+}
+
+// WithDryRun returns an Option that puts the Client in dry-run mode.
+// In dry-run mode, write requests are marshaled and validated as usual but are
+// never sent to Kanka. Instead, each write request is logged to the provided
+// logger, if not nil, and ErrDryRun is returned. Read requests are sent as
+// usual.
+func WithDryRun(l *log.Logger) Option {
+	return func(c *Client) {
+		c.dryRun = true
+		c.dryLog = l
+	}
+}
+
+// WithStrictDecode returns an Option that makes the Client reject responses
+// containing fields that the corresponding types do not model. The returned
+// error names the unknown field. This helps catch changes to Kanka's schema
+// early during development; by default, unknown fields are ignored.
+// Fields nested inside types with their own UnmarshalJSON method, such as
+// EntityNotes, are not checked.
+func WithStrictDecode() Option {
+	return func(c *Client) {
+		c.strict = true
+	}
+}
+
+// WithIdempotentDelete returns an Option that makes every Delete of the Client
+// treat a 404 Not Found response as success. Deleting an object that no longer
+// exists, such as when retrying a Delete whose response was lost, is then a
+// no-op instead of an error. By default, a 404 Not Found response is an error.
+func WithIdempotentDelete() Option {
+	return func(c *Client) {
+		c.idempotentDelete = true
+	}
+}
+
+// WithRetry returns an Option that retries a failed request up to n times,
+// waiting about base before the first retry and twice as long before each
+// next one. Each wait is randomized so that many workers failing at once do
+// not retry in lockstep, and a longer wait asked for by Kanka with the
+// Retry-After header is honored. Which failures are retried is decided by
+// the RetryPolicy set with WithRetryPolicy, or DefaultRetryPolicy otherwise.
+// Every attempt waits for the rate limit of the Client, if any, and all
+// attempts of a request share its timeout, if any. POST requests are never
+// retried, because retrying a Create whose response was lost would create a
+// duplicate. A non-positive n disables retries.
+func WithRetry(n int, base time.Duration) Option {
+	return func(c *Client) {
+		c.retries = n
+		c.retryBase = base
+	}
+}
+
+// WithRetryPolicy returns an Option that makes the Client decide with the
+// provided RetryPolicy which failed requests to retry, instead of using
+// DefaultRetryPolicy. The policy only applies to requests retried by WithRetry.
+func WithRetryPolicy(p RetryPolicy) Option {
+	return func(c *Client) {
+		c.retryPolicy = p
+	}
+}
+
+// WithDefaultPrivate returns an Option that makes every object created through
+// the services of campaign objects, such as Characters or Locations, private
+// so that a bulk import never exposes its objects by accident. The Public
+// CreateOption overrides the default for a single call.
+func WithDefaultPrivate() Option {
+	return func(c *Client) {
+		c.defaultPrivate = true
+	}
+}
+
+// CreateOption configures a single call to the Create or CreateWithResult
+// function of a service of campaign objects, such as Characters.
+type CreateOption func(*createSettings)
+
+// createSettings holds the settings configured by CreateOptions.
+type createSettings struct {
+	public     bool
+	duplicates bool
+}
+
+// Public returns a CreateOption that creates the object as provided, without
+// making it private, even if the Client was created WithDefaultPrivate.
+func Public() CreateOption {
+	return func(s *createSettings) {
+		s.public = true
+	}
+}
+
+// CheckDuplicates returns a CreateOption that searches the Campaign for
+// objects of the same type with the same name, ignoring case, before creating
+// the object. The object is still created, and any duplicates found are
+// reported as a Warning with the WarningDuplicateName Code in the Warnings of
+// the WriteResult returned by CreateWithResult. Every page of the search is a
+// separate request subject to the rate limit of the Client, if any.
+func CheckDuplicates() CreateOption {
+	return func(s *createSettings) {
+		s.duplicates = true
+	}
+}
+
+// WithEndpoint returns an Option that replaces the default endpoint of every
+// service using the provided endpoint with the provided path. This allows the
+// Client to keep working if Kanka renames an endpoint. For example, passing
+// EndpointCharacter and "npcs" makes the Characters service send its requests
+// to the "npcs" path instead of the "characters" path.
+// Only the path segment belonging to the service is replaced; the paths of
+// parent objects, such as campaigns or entities, are left unchanged.
+func WithEndpoint(def endpoint, path string) Option {
+	return func(c *Client) {
+		for _, s := range c.services() {
+			if s.end == def {
+				s.end = endpoint(path)
+			}
+		}
+	}
+}
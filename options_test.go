@@ -0,0 +1,482 @@
+package kanka
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWithTimeout(t *testing.T) {
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		delay   time.Duration
+		wantErr bool
+	}{
+		{
+			name:    "No timeout",
+			timeout: 0,
+			delay:   10 * time.Millisecond,
+			wantErr: false,
+		},
+		{
+			name:    "Timeout longer than response",
+			timeout: time.Second,
+			delay:   10 * time.Millisecond,
+			wantErr: false,
+		},
+		{
+			name:    "Timeout shorter than response",
+			timeout: 10 * time.Millisecond,
+			delay:   time.Second,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(test.delay):
+				case <-r.Context().Done():
+				}
+				w.Write([]byte(`{"data":{"id":123}}`))
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client(), WithTimeout(test.timeout))
+			c.rootURL = ts.URL + "/"
+
+			_, err := c.Profiles.Get()
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWithRateLimit(t *testing.T) {
+	tests := []struct {
+		name     string
+		n        int
+		per      time.Duration
+		timeout  time.Duration
+		requests int
+		wantErr  error
+	}{
+		{
+			name:     "No rate limit",
+			n:        0,
+			per:      time.Hour,
+			timeout:  time.Second,
+			requests: 3,
+			wantErr:  nil,
+		},
+		{
+			name:     "Requests within rate limit",
+			n:        100,
+			per:      time.Second,
+			timeout:  time.Second,
+			requests: 3,
+			wantErr:  nil,
+		},
+		{
+			name:     "Rate limit exceeds timeout",
+			n:        1,
+			per:      time.Hour,
+			timeout:  time.Second,
+			requests: 2,
+			wantErr:  context.DeadlineExceeded,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(`{"data":{"id":123}}`))
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client(), WithTimeout(test.timeout), WithRateLimit(test.n, test.per))
+			c.rootURL = ts.URL + "/"
+
+			var err error
+			start := time.Now()
+			for i := 0; i < test.requests && err == nil; i++ {
+				_, err = c.Profiles.Get()
+			}
+			if !errors.Is(err, test.wantErr) {
+				t.Fatalf("got err: <%v>, want err: <%v>", err, test.wantErr)
+			}
+			if elapsed := time.Since(start); elapsed > test.timeout {
+				t.Errorf("got elapsed: <%v>, want at most: <%v>", elapsed, test.timeout)
+			}
+		})
+	}
+}
+
+func TestWithBaseURL(t *testing.T) {
+	tests := []struct {
+		name        string
+		base        string
+		wantRootURL string
+		wantWebURL  string
+	}{
+		{
+			name:        "Base URL with trailing slash",
+			base:        "https://kanka.example.com/",
+			wantRootURL: "https://kanka.example.com/api/1.0/",
+			wantWebURL:  "https://kanka.example.com/",
+		},
+		{
+			name:        "Base URL without trailing slash",
+			base:        "http://localhost:8080",
+			wantRootURL: "http://localhost:8080/api/1.0/",
+			wantWebURL:  "http://localhost:8080/",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewClient(testToken, nil, WithBaseURL(test.base))
+			if c.rootURL != test.wantRootURL {
+				t.Errorf("got root URL: <%s>, want root URL: <%s>", c.rootURL, test.wantRootURL)
+			}
+			if c.webURL != test.wantWebURL {
+				t.Errorf("got web URL: <%s>, want web URL: <%s>", c.webURL, test.wantWebURL)
+			}
+		})
+	}
+}
+
+func TestWithHeader(t *testing.T) {
+	tests := []struct {
+		name       string
+		opts       []Option
+		wantHeader http.Header
+	}{
+		{
+			name: "No custom headers",
+			opts: nil,
+			wantHeader: http.Header{
+				"Authorization": {"Bearer " + testToken},
+				"Accept":        {"application/json"},
+			},
+		},
+		{
+			name: "Custom header",
+			opts: []Option{WithHeader("CF-Access-Client-Id", "abc123")},
+			wantHeader: http.Header{
+				"Authorization":       {"Bearer " + testToken},
+				"Accept":              {"application/json"},
+				"Cf-Access-Client-Id": {"abc123"},
+			},
+		},
+		{
+			name: "Repeated custom header",
+			opts: []Option{WithHeader("X-Forwarded-For", "10.0.0.1"), WithHeader("X-Forwarded-For", "10.0.0.2")},
+			wantHeader: http.Header{
+				"Authorization":   {"Bearer " + testToken},
+				"Accept":          {"application/json"},
+				"X-Forwarded-For": {"10.0.0.1", "10.0.0.2"},
+			},
+		},
+		{
+			name: "Authorization header not replaced",
+			opts: []Option{WithHeader("Authorization", "Bearer other")},
+			wantHeader: http.Header{
+				"Authorization": {"Bearer " + testToken},
+				"Accept":        {"application/json"},
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := NewClient(testToken, nil, test.opts...)
+
+			req, err := c.request("GET", EndpointProfile, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(req.Header, test.wantHeader); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestWithDryRun(t *testing.T) {
+	tests := []struct {
+		name      string
+		write     bool
+		wantLog   string
+		wantCount int
+		wantErr   error
+	}{
+		{
+			name:      "Read request is sent",
+			write:     false,
+			wantLog:   "",
+			wantCount: 1,
+			wantErr:   nil,
+		},
+		{
+			name:      "Write request is skipped",
+			write:     true,
+			wantLog:   `dry run: POST /campaigns/5272/characters {"name":"Jon Snow"}`,
+			wantCount: 0,
+			wantErr:   ErrDryRun,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			count := 0
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				count++
+				w.Write([]byte(`{"data":{"id":123}}`))
+			}))
+			defer ts.Close()
+
+			var buf bytes.Buffer
+			c := NewClient(testToken, ts.Client(), WithDryRun(log.New(&buf, "", 0)))
+			c.rootURL = ts.URL + "/"
+
+			var err error
+			if test.write {
+				_, err = c.Characters.Create(5272, SimpleCharacter{Name: "Jon Snow"})
+			} else {
+				_, err = c.Profiles.Get()
+			}
+
+			if !errors.Is(err, test.wantErr) {
+				t.Errorf("got err: <%v>, want err: <%v>", err, test.wantErr)
+			}
+			if count != test.wantCount {
+				t.Errorf("got request count: <%d>, want: <%d>", count, test.wantCount)
+			}
+			if got := strings.Replace(buf.String(), ts.URL, "", 1); strings.TrimSpace(got) != test.wantLog {
+				t.Errorf("got log: <%s>, want: <%s>", got, test.wantLog)
+			}
+		})
+	}
+}
+
+func TestWithStrictDecode(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		body    string
+		list    bool
+		wantErr string
+	}{
+		{
+			name:    "Lenient decode with unknown field",
+			opts:    nil,
+			body:    `{"data":{"id":123,"unknown_field":true}}`,
+			list:    false,
+			wantErr: "",
+		},
+		{
+			name:    "Strict decode without unknown field",
+			opts:    []Option{WithStrictDecode()},
+			body:    `{"data":{"id":123}}`,
+			list:    false,
+			wantErr: "",
+		},
+		{
+			name:    "Strict decode with unknown field",
+			opts:    []Option{WithStrictDecode()},
+			body:    `{"data":{"id":123,"unknown_field":true}}`,
+			list:    false,
+			wantErr: `unknown field "unknown_field"`,
+		},
+		{
+			name:    "Strict decode with unknown field in list",
+			opts:    []Option{WithStrictDecode()},
+			body:    `{"data":[{"id":123,"unknown_field":true}]}`,
+			list:    true,
+			wantErr: `unknown field "unknown_field"`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Write([]byte(test.body))
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client(), test.opts...)
+			c.rootURL = ts.URL + "/"
+
+			var err error
+			if test.list {
+				_, err = c.Tags.Index(5272, nil)
+			} else {
+				_, err = c.Profiles.Get()
+			}
+
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("got err: <%v>, want err: <nil>", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+				t.Fatalf("got err: <%v>, want err containing: <%s>", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestWithIdempotentDelete(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []Option
+		status  int
+		wantErr bool
+	}{
+		{
+			name:    "Default, StatusNotFound",
+			opts:    nil,
+			status:  http.StatusNotFound,
+			wantErr: true,
+		},
+		{
+			name:    "Idempotent, StatusNotFound",
+			opts:    []Option{WithIdempotentDelete()},
+			status:  http.StatusNotFound,
+			wantErr: false,
+		},
+		{
+			name:    "Idempotent, StatusOK",
+			opts:    []Option{WithIdempotentDelete()},
+			status:  http.StatusOK,
+			wantErr: false,
+		},
+		{
+			name:    "Idempotent, StatusForbidden",
+			opts:    []Option{WithIdempotentDelete()},
+			status:  http.StatusForbidden,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(test.status)
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client(), test.opts...)
+			c.rootURL = ts.URL + "/"
+
+			err := c.Characters.Delete(5272, 111)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestWithEndpoint(t *testing.T) {
+	tests := []struct {
+		name    string
+		def     endpoint
+		path    string
+		wantURL string
+	}{
+		{
+			name:    "Replaced endpoint",
+			def:     EndpointCharacter,
+			path:    "npcs",
+			wantURL: "/campaigns/5272/npcs/111?related=1",
+		},
+		{
+			name:    "Other endpoint",
+			def:     EndpointLocation,
+			path:    "places",
+			wantURL: "/campaigns/5272/characters/111?related=1",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got string
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				got = r.URL.String()
+				w.Write([]byte(`{"data":{"id":111}}`))
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client(), WithEndpoint(test.def, test.path))
+			c.rootURL = ts.URL + "/"
+
+			if _, err := c.Characters.Get(5272, 111); err != nil {
+				t.Fatal(err)
+			}
+			if got != test.wantURL {
+				t.Errorf("got url: <%s>, want url: <%s>", got, test.wantURL)
+			}
+		})
+	}
+}
+
+func TestWithDefaultPrivate(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     []Option
+		ch       SimpleCharacter
+		create   []CreateOption
+		wantBody string
+	}{
+		{
+			name:     "Default",
+			opts:     nil,
+			ch:       SimpleCharacter{Name: "Jon Snow"},
+			create:   nil,
+			wantBody: `{"name":"Jon Snow"}`,
+		},
+		{
+			name:     "Default private",
+			opts:     []Option{WithDefaultPrivate()},
+			ch:       SimpleCharacter{Name: "Jon Snow"},
+			create:   nil,
+			wantBody: `{"is_private":true,"name":"Jon Snow"}`,
+		},
+		{
+			name:     "Default private, already private",
+			opts:     []Option{WithDefaultPrivate()},
+			ch:       SimpleCharacter{Name: "Jon Snow", IsPrivate: true},
+			create:   nil,
+			wantBody: `{"is_private":true,"name":"Jon Snow"}`,
+		},
+		{
+			name:     "Default private, public call",
+			opts:     []Option{WithDefaultPrivate()},
+			ch:       SimpleCharacter{Name: "Jon Snow"},
+			create:   []CreateOption{Public()},
+			wantBody: `{"name":"Jon Snow"}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, http.StatusOK, testCharacterCreate)
+			defer ts.Close()
+			for _, opt := range test.opts {
+				opt(c)
+			}
+
+			if _, err := c.Characters.Create(5272, test.ch, test.create...); err != nil {
+				t.Fatal(err)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+		})
+	}
+}
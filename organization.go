@@ -5,24 +5,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // Organization contains informations about an organization.
 // For more information. visit: https://kanka.io/en-US/docs/1.0/organisations
 type Organization struct {
 	SimpleOrganization
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
-	Members        int       `json:"members"`
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
+	Members         int       `json:"members"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -36,114 +36,208 @@ type Organization struct {
 // SimpleOrganization is primarily used to create new organizations for posting
 // to Kanka.
 type SimpleOrganization struct {
-	Name           string `json:"name"`
-	Entry          string `json:"entry,omitempty"`
-	Type           string `json:"type,omitempty"`
-	OrganizationID int    `json:"organisation_id,omitempty"`
-	LocationID     int    `json:"location_id,omitempty"`
-	Tags           []int  `json:"tags,omitempty"`
-	IsPrivate      bool   `json:"is_private,omitempty"`
-	Image          string `json:"image,omitempty"`
-	ImageURL       string `json:"image_url,omitempty"`
+	Name           string   `json:"name"`
+	Entry          string   `json:"entry,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	OrganizationID int      `json:"organisation_id,omitempty"`
+	LocationID     int      `json:"location_id,omitempty"`
+	Tags           []int    `json:"tags,omitempty"`
+	IsPrivate      bool     `json:"is_private,omitempty"`
+	IsTemplate     *bool    `json:"is_template,omitempty"`
+	Image          string   `json:"image,omitempty"`
+	ImageURL       string   `json:"image_url,omitempty"`
+	ImageUUID      string   `json:"image_uuid,omitempty"`
+	FocusX         *float64 `json:"focus_x,omitempty"`
+	FocusY         *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL string   `json:"header_image_url,omitempty"`
+	HeaderUUID     string   `json:"entity_header_uuid,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleOrganization into its JSON-encoded form if it
 // has the required populated fields.
 func (so SimpleOrganization) MarshalJSON() ([]byte, error) {
-	if blank.Is(so.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleOrganization into JSON with a missing Name")
+	if err := requireField("SimpleOrganization", "Name", so.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleOrganization
 	return json.Marshal(alias(so))
 }
 
+// Simple returns a copy of the SimpleOrganization data of the Organization,
+// ready to be modified and passed to Update. The path of the current image is
+// left out and the slices of the copy do not share memory with the
+// Organization.
+func (o *Organization) Simple() SimpleOrganization {
+	s := o.SimpleOrganization
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+
+	return s
+}
+
 // OrganizationService handles communication with the Organization endpoint.
 type OrganizationService service
 
+// base returns the baseService implementing the common operations of the
+// OrganizationService.
+func (os *OrganizationService) base() baseService[Organization, SimpleOrganization] {
+	return baseService[Organization, SimpleOrganization]{
+		service: (*service)(os),
+		kind:    "Organization",
+		entity:  "organisation",
+		label:   func(org SimpleOrganization) string { return org.Name },
+	}
+}
+
 // Index returns the list of all Organizations in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Organizations that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Organizations that were decoded.
 func (os *OrganizationService) Index(campID int, sync *time.Time) ([]*Organization, error) {
+	return os.base().Index(campID, sync)
+}
+
+// IndexAll returns the list of all Organizations in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Organizations that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Organizations that were decoded.
+func (os *OrganizationService) IndexAll(campID int, sync *time.Time) ([]*Organization, error) {
+	return os.base().IndexAll(campID, sync)
+}
+
+// GetMany returns the Organizations associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Organizations, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Organizations that were
+// found.
+func (os *OrganizationService) GetMany(campID int, ids []int) (map[int]*Organization, error) {
+	return os.base().GetMany(campID, ids)
+}
+
+// Count returns the number of Organizations in the Campaign associated with campID
+// without retrieving them.
+func (os *OrganizationService) Count(campID int) (int, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(os.end)
 
-	if sync != nil {
-		end = end.sync(*sync)
-	}
-
-	var wrap struct {
-		Data []*Organization `json:"data"`
-	}
-
-	err = os.client.get(end, &wrap)
+	n, err := os.client.count(end)
 	if err != nil {
-		return nil, fmt.Errorf("cannot get Organization Index from Campaign (ID: %d): %w", campID, err)
+		return 0, fmt.Errorf("cannot count Organizations in Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	return n, nil
 }
 
 // Get returns the Organization associated with orgID from the Campaign
 // associated with campID.
 func (os *OrganizationService) Get(campID int, orgID int) (*Organization, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(os.end)
-
-	end, err = end.id(orgID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Organization ID: %w", err)
-	}
-
-	var wrap struct {
-		Data *Organization `json:"data"`
-	}
+	return os.base().Get(campID, orgID)
+}
 
-	err = os.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Organization (ID: %d) from Campaign (ID: %d): %w", orgID, campID, err)
-	}
+// GetByName returns the Organization with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Organization has the name and ErrAmbiguous if more than one does.
+func (os *OrganizationService) GetByName(campID int, name string) (*Organization, error) {
+	return os.base().GetByName(campID, name)
+}
 
-	return wrap.Data, nil
+// Children returns the list of all Organizations in the Campaign associated with
+// campID whose parent is the Organization associated with orgID. Only the direct
+// children are returned. Children follows every page of the list, each a
+// separate request subject to the rate limit of the Client.
+// If a non-nil time is provided, Children will only return Organizations that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Organizations that were decoded.
+func (os *OrganizationService) Children(campID int, orgID int, sync *time.Time) ([]*Organization, error) {
+	return os.base().children(campID, orgID, "organisation_id", func(x *Organization) int { return x.OrganizationID }, sync)
 }
 
 // Create creates a new Organization in the Campaign associated with campID using
 // the provided SimpleOrganization data.
 // Create returns the newly created Organization.
-func (os *OrganizationService) Create(campID int, org SimpleOrganization) (*Organization, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(os.end)
-
-	b, err := json.Marshal(org)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleOrganization (Name: %s): %w", org.Name, err)
-	}
-
-	var wrap struct {
-		Data *Organization `json:"data"`
-	}
-
-	err = os.client.post(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot create Organization (Name: %s) for Campaign (ID: %d): %w", org.Name, campID, err)
-	}
+// The provided CreateOptions, such as Public, apply to this call only.
+func (os *OrganizationService) Create(campID int, org SimpleOrganization, opts ...CreateOption) (*Organization, error) {
+	return os.base().Create(campID, org, opts...)
+}
 
-	return wrap.Data, nil
+// CreateWithResult creates a new Organization in the Campaign associated with campID
+// using the provided SimpleOrganization data.
+// CreateWithResult returns the newly created Organization along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (os *OrganizationService) CreateWithResult(campID int, org SimpleOrganization, opts ...CreateOption) (*Organization, *WriteResult, error) {
+	return os.base().CreateWithResult(campID, org, opts...)
 }
 
 // Update updates an existing Organization associated with orgID from the
 // Campaign associated with campID using the provided SimpleOrganization data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Organization returned by Kanka, provide its SimpleOrganization.
 // Update returns the newly updated Organization.
 func (os *OrganizationService) Update(campID int, orgID int, org SimpleOrganization) (*Organization, error) {
+	return os.base().Update(campID, orgID, org)
+}
+
+// UpdateWithResult updates an existing Organization associated with orgID from the
+// Campaign associated with campID using the provided SimpleOrganization data, as
+// Update does.
+// UpdateWithResult returns the newly updated Organization along with the metadata of
+// Kanka's response, such as its status code.
+func (os *OrganizationService) UpdateWithResult(campID int, orgID int, org SimpleOrganization) (*Organization, *WriteResult, error) {
+	return os.base().UpdateWithResult(campID, orgID, org)
+}
+
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Organization associated with orgID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Organization is left unchanged.
+// Clear returns the newly updated Organization.
+func (os *OrganizationService) Clear(campID int, orgID int, fields ...string) (*Organization, error) {
+	return os.base().Clear(campID, orgID, fields...)
+}
+
+// SetHeader sets the header image of an existing Organization associated with orgID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Organization on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Organization.
+func (os *OrganizationService) SetHeader(campID int, orgID int, uuid string) (*Organization, error) {
+	return os.base().SetHeader(campID, orgID, uuid)
+}
+
+// SetHeaderURL sets the header image of an existing Organization associated with
+// orgID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Organization.
+func (os *OrganizationService) SetHeaderURL(campID int, orgID int, url string) (*Organization, error) {
+	return os.base().SetHeaderURL(campID, orgID, url)
+}
+
+// SetParent sets the parent Organization of the Organization associated with orgID in the
+// Campaign associated with campID to the Organization associated with parentID.
+// A parentID of 0 removes the parent Organization. Only the parent is updated; every
+// other field of the Organization is left unchanged.
+// SetParent returns the newly updated Organization.
+func (os *OrganizationService) SetParent(campID int, orgID int, parentID int) (*Organization, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
@@ -155,18 +249,21 @@ func (os *OrganizationService) Update(campID int, orgID int, org SimpleOrganizat
 		return nil, fmt.Errorf("invalid Organization ID: %w", err)
 	}
 
-	b, err := json.Marshal(org)
+	fields, err := parentFields("organisation_id", parentID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleOrganization (Name: %s): %w", org.Name, err)
+		return nil, fmt.Errorf("invalid parent Organization ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *Organization `json:"data"`
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal parent Organization (ID: %d): %w", parentID, err)
 	}
 
-	err = os.client.put(end, bytes.NewReader(b), &wrap)
+	var wrap response[*Organization]
+
+	err = os.client.patch(end, bytes.NewReader(b), &wrap)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update Organization (Name: %s) for Campaign (ID: %d): '%w'", org.Name, campID, err)
+		return nil, fmt.Errorf("cannot set parent of Organization (ID: %d) for Campaign (ID: %d): %w", orgID, campID, err)
 	}
 
 	return wrap.Data, nil
@@ -175,21 +272,24 @@ func (os *OrganizationService) Update(campID int, orgID int, org SimpleOrganizat
 // Delete deletes an existing Organization associated with orgID from the
 // Campaign associated with campID.
 func (os *OrganizationService) Delete(campID int, orgID int) error {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(os.end)
+	return os.base().Delete(campID, orgID)
+}
 
-	end, err = end.id(orgID)
+// Path returns the chain of Organizations from the root of the organization tree to the
+// Organization associated with orgID in the Campaign associated with campID, such as
+// the breadcrumbs "Guild > Chapter". The chain ends with the Organization itself.
+func (os *OrganizationService) Path(campID int, orgID int) ([]*Organization, error) {
+	paths, err := os.Paths(campID, []int{orgID})
 	if err != nil {
-		return fmt.Errorf("invalid Organization ID: %w", err)
+		return nil, err
 	}
 
-	err = os.client.delete(end)
-	if err != nil {
-		return fmt.Errorf("cannot delete Organization (ID: %d) for Campaign (ID: %d): %w", orgID, campID, err)
-	}
+	return paths[orgID], nil
+}
 
-	return nil
+// Paths returns the Path of each Organization associated with the provided orgIDs
+// in the Campaign associated with campID, keyed by Organization ID. Each Organization is
+// fetched at most once, even if it is an ancestor shared by several Organizations.
+func (os *OrganizationService) Paths(campID int, orgIDs []int) (map[int][]*Organization, error) {
+	return os.base().paths(campID, orgIDs, func(x *Organization) int { return x.OrganizationID })
 }
@@ -560,3 +560,91 @@ func TestOrganizationService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestOrganizationService_SetParent(t *testing.T) {
+	type args struct {
+		campID   int
+		orgID    int
+		parentID int
+	}
+	tests := []struct {
+		name     string
+		status   int
+		file     string
+		args     args
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, valid response, valid args",
+			status:   http.StatusOK,
+			file:     testOrganizationUpdate,
+			args:     args{campID: 5272, orgID: 111, parentID: 222},
+			wantBody: `{"organisation_id":222}`,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, valid response, cleared parent",
+			status:   http.StatusOK,
+			file:     testOrganizationUpdate,
+			args:     args{campID: 5272, orgID: 111, parentID: 0},
+			wantBody: `{"organisation_id":null}`,
+			wantErr:  false,
+		},
+		{
+			name:     "Status OK, valid response, invalid campID",
+			status:   http.StatusOK,
+			file:     testOrganizationUpdate,
+			args:     args{campID: -123, orgID: 111, parentID: 222},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Status OK, valid response, invalid orgID",
+			status:   http.StatusOK,
+			file:     testOrganizationUpdate,
+			args:     args{campID: 5272, orgID: -123, parentID: 222},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Status OK, valid response, invalid parentID",
+			status:   http.StatusOK,
+			file:     testOrganizationUpdate,
+			args:     args{campID: 5272, orgID: 111, parentID: -123},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusNotFound, valid args",
+			status:   http.StatusNotFound,
+			file:     testFileEmpty,
+			args:     args{campID: 5272, orgID: 111, parentID: 222},
+			wantBody: `{"organisation_id":222}`,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, test.file)
+			defer ts.Close()
+
+			got, err := c.Organizations.SetParent(test.args.campID, test.args.orgID, test.args.parentID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+			if test.wantErr {
+				return
+			}
+			if rec.method != "PATCH" {
+				t.Errorf("got method: <%s>, want method: <%s>", rec.method, "PATCH")
+			}
+			if got == nil || got.ID != 111 {
+				t.Errorf("got: <%v>, want Organization with ID 111", got)
+			}
+		})
+	}
+}
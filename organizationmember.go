@@ -36,6 +36,8 @@ type OrganizationMemberService service
 // associated with orgID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return OrganizationMembers
 // that have been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the OrganizationMembers that were decoded.
 func (os *OrganizationMemberService) Index(campID int, orgID int, sync *time.Time) ([]*OrganizationMember, error) {
 	var err error
 	end := EndpointCampaign
@@ -54,15 +56,18 @@ func (os *OrganizationMemberService) Index(campID int, orgID int, sync *time.Tim
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*OrganizationMember `json:"data"`
-	}
+	var wrap response[[]json.RawMessage]
 
 	if err = os.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get OrganizationMember Index from Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	var list []*OrganizationMember
+	if err = decodeList(wrap.Data, &list, os.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode OrganizationMember Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
 }
 
 // Get returns the OrganizationMember associated with memID for the organization
@@ -85,9 +90,7 @@ func (os *OrganizationMemberService) Get(campID int, orgID int, memID int) (*Org
 		return nil, fmt.Errorf("invalid OrganizationMember ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *OrganizationMember `json:"data"`
-	}
+	var wrap response[*OrganizationMember]
 
 	if err = os.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get OrganizationMember (ID: %d) from Campaign (ID: %d): %w", memID, campID, err)
@@ -123,9 +126,7 @@ func (os *OrganizationMemberService) Update(campID int, orgID int, memID int, me
 		return nil, fmt.Errorf("cannot marshal SimpleOrganizationMember: %w", err)
 	}
 
-	var wrap struct {
-		Data *OrganizationMember `json:"data"`
-	}
+	var wrap response[*OrganizationMember]
 
 	if err = os.client.put(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot update OrganizationMember for Campaign (ID: %d): '%w'", campID, err)
@@ -0,0 +1,270 @@
+package kanka
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// paramPage is the query parameter Kanka reads the requested page from.
+// Every list endpoint of the Kanka API is paginated by page number, as
+// reported by the Links and Meta of a response; none offers cursor-based
+// pagination. The size of a page is chosen by Kanka; the API documents no
+// parameter to request larger pages.
+const paramPage string = "page"
+
+// count returns the total number of records listed by the provided endpoint.
+// Only the first page is requested, so count is cheap even for large lists.
+func (c *Client) count(end endpoint) (int, error) {
+	end = end.query(paramPage, "1")
+
+	var wrap response[json.RawMessage]
+
+	if err := c.get(end, &wrap); err != nil {
+		return 0, err
+	}
+
+	if wrap.Meta == nil {
+		return 0, fmt.Errorf("response is missing pagination meta")
+	}
+
+	return wrap.Meta.Total, nil
+}
+
+// next returns the link to the next page, or an empty string if there is no
+// next page.
+func (l *Links) next() string {
+	if l == nil {
+		return ""
+	}
+
+	s, _ := l.Next.(string)
+	return s
+}
+
+// follow executes a GET request to the provided pagination link, as returned
+// by Kanka in the Links of a response, and stores the unmarshaled JSON result
+// in the provided empty interface. Query parameters of the provided original
+// endpoint, such as the last sync time, are added to the link if Kanka left
+// them out so that every page is filtered the same way. Links outside of the
+// Client's API root are refused so that the token is never sent to another
+// host.
+func (c *Client) follow(link string, orig endpoint, result interface{}) error {
+	if !strings.HasPrefix(link, c.rootURL) {
+		return fmt.Errorf("pagination link '%s' is outside of the API root '%s'", link, c.rootURL)
+	}
+
+	u, err := url.Parse(strings.TrimPrefix(link, c.rootURL))
+	if err != nil {
+		return fmt.Errorf("invalid pagination link '%s': %w", link, err)
+	}
+
+	q := u.Query()
+	orig = orig.query(paramRelated, "1")
+	if i := strings.Index(string(orig), "?"); i >= 0 {
+		oq, err := url.ParseQuery(string(orig[i+1:]))
+		if err != nil {
+			return fmt.Errorf("invalid query of endpoint '%s': %w", orig, err)
+		}
+		for key, vals := range oq {
+			if _, ok := q[key]; !ok {
+				q[key] = vals
+			}
+		}
+	}
+	u.RawQuery = q.Encode()
+
+	req, err := c.request("GET", endpoint(u.String()), nil)
+	if err != nil {
+		return err
+	}
+
+	return c.send(req, result)
+}
+
+// indexAll retrieves the first page of the list at the provided endpoint and
+// follows the next link of each page until the last page, returning the raw
+// records of every page in order.
+func (c *Client) indexAll(end endpoint) ([]json.RawMessage, error) {
+	var raws []json.RawMessage
+
+	err := c.pages(end, func(page []json.RawMessage, left int) bool {
+		raws = append(raws, page...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return raws, nil
+}
+
+// pages retrieves the first page of the list at the provided endpoint and
+// follows the next link of each page until the last page, passing the raw
+// records of every page in order to the provided function along with the
+// number of pages left after it, as reported by the Meta of the page, or -1
+// if Kanka did not report it. pages stops early, without requesting the next
+// page, as soon as the function returns false.
+func (c *Client) pages(end endpoint, fn func(page []json.RawMessage, left int) bool) error {
+	var wrap response[[]json.RawMessage]
+
+	if err := c.get(end, &wrap); err != nil {
+		return err
+	}
+
+	seen := make(map[string]bool)
+	for fn(wrap.Data, wrap.Meta.left()) {
+		link := wrap.Links.next()
+		if link == "" {
+			return nil
+		}
+
+		if seen[link] {
+			return fmt.Errorf("pagination link '%s' was already followed", link)
+		}
+		seen[link] = true
+
+		wrap = response[[]json.RawMessage]{}
+		if err := c.follow(link, end, &wrap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// left returns the number of pages after the current page, or -1 if the Meta
+// does not report the last page.
+func (m *Meta) left() int {
+	if m == nil || m.LastPage <= 0 {
+		return -1
+	}
+
+	if m.CurrentPage >= m.LastPage {
+		return 0
+	}
+
+	return m.LastPage - m.CurrentPage
+}
+
+// getMany returns the records associated with each of the pending IDs from
+// the list at the provided endpoint, keyed by ID, and removes the IDs it
+// finds from pending. getMany scans the first page of the list and then takes
+// whichever route needs fewer requests for the IDs still pending: following
+// the rest of the pages, or calling the provided get function once per ID.
+// A list that does not report its number of pages is followed to the end.
+// IDs that get reports as not found are left pending. Records that cannot be
+// decoded are skipped and returned as RecordErrors.
+func getMany[T any](c *Client, end endpoint, pending map[int]bool, get func(id int) (*T, error)) (map[int]*T, RecordErrors, error) {
+	objs := make(map[int]*T, len(pending))
+	if len(pending) == 0 {
+		return objs, nil, nil
+	}
+
+	var errs RecordErrors
+	var index int
+	var byID bool
+	err := c.pages(end, func(page []json.RawMessage, left int) bool {
+		for _, raw := range page {
+			i := index
+			index++
+
+			var head struct {
+				ID int `json:"id"`
+			}
+			if err := json.Unmarshal(raw, &head); err != nil {
+				errs = append(errs, &RecordError{Index: i, Err: err})
+				continue
+			}
+
+			if !pending[head.ID] {
+				continue
+			}
+			delete(pending, head.ID)
+
+			obj := new(T)
+			if err := unmarshal(raw, obj, c.strict); err != nil {
+				errs = append(errs, &RecordError{Index: i, Err: err})
+				continue
+			}
+			objs[head.ID] = obj
+		}
+
+		if len(pending) > 0 && len(pending) < left {
+			byID = true
+			return false
+		}
+
+		return len(pending) > 0
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !byID {
+		return objs, errs, nil
+	}
+
+	ids := make([]int, 0, len(pending))
+	for id := range pending {
+		ids = append(ids, id)
+	}
+	sort.Ints(ids)
+
+	for _, id := range ids {
+		obj, err := get(id)
+		if isNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+
+		delete(pending, id)
+		objs[id] = obj
+	}
+
+	return objs, errs, nil
+}
+
+// entityIndexAll returns every record of every page of the list of the
+// provided sub-resource, such as the attributes, of the entity associated
+// with entID in the Campaign associated with campID. The provided kind, such
+// as "Attribute", names the records in error messages.
+// If a non-nil time is provided, only the records that have been changed
+// since that time are returned.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the records that were decoded.
+func entityIndexAll[T any](c *Client, campID int, entID int, sub endpoint, kind string, sync *time.Time) ([]*T, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(endpointEntity)
+
+	if end, err = end.id(entID); err != nil {
+		return nil, fmt.Errorf("invalid Entity ID: %w", err)
+	}
+	end = end.concat(sub)
+
+	if sync != nil {
+		end = end.sync(*sync)
+	}
+
+	raws, err := c.indexAll(end)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get every page of %s Index for Entity (ID: %d) from Campaign (ID: %d): %w", kind, entID, campID, err)
+	}
+
+	var list []*T
+	if err = decodeList(raws, &list, c.strict); err != nil {
+		return list, fmt.Errorf("cannot decode %s Index for Entity (ID: %d) from Campaign (ID: %d): %w", kind, entID, campID, err)
+	}
+
+	return list, nil
+}
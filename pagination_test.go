@@ -0,0 +1,231 @@
+package kanka
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testCount string = "test_data/count.json"
+
+func TestClient_count(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		want    int
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response",
+			status:  http.StatusOK,
+			file:    testCount,
+			want:    128,
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, missing meta",
+			status:  http.StatusOK,
+			file:    testCharacterIndex,
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name:    "StatusOK, empty response",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			want:    0,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			want:    0,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, test.file)
+			defer ts.Close()
+
+			got, err := c.count("campaigns/5272/characters")
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if !strings.Contains(rec.url, "page=1") {
+				t.Errorf("got url: <%s>, want url with the first page", rec.url)
+			}
+			if got != test.want {
+				t.Errorf("got: <%d>, want: <%d>", got, test.want)
+			}
+		})
+	}
+}
+
+func TestService_Count(t *testing.T) {
+	c, ts, rec := testRecordClient(t, http.StatusOK, testCount)
+	defer ts.Close()
+
+	counts := map[string]func(int) (int, error){
+		"abilities":     c.Abilities.Count,
+		"characters":    c.Characters.Count,
+		"conversations": c.Conversations.Count,
+		"entities":      c.Entities.Count,
+		"events":        c.Events.Count,
+		"families":      c.Families.Count,
+		"items":         c.Items.Count,
+		"journals":      c.Journals.Count,
+		"locations":     c.Locations.Count,
+		"notes":         c.Notes.Count,
+		"organisations": c.Organizations.Count,
+		"quests":        c.Quests.Count,
+		"races":         c.Races.Count,
+		"tags":          c.Tags.Count,
+	}
+	for name, count := range counts {
+		t.Run(name, func(t *testing.T) {
+			got, err := count(5272)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != 128 {
+				t.Errorf("got: <%d>, want: <%d>", got, 128)
+			}
+			if !strings.HasPrefix(rec.url, "/campaigns/5272/"+name+"?") {
+				t.Errorf("got url: <%s>, want url of the %s endpoint", rec.url, name)
+			}
+
+			if _, err := count(-123); err == nil {
+				t.Errorf("got err?: <false>, want err?: <true>")
+			}
+		})
+	}
+}
+
+func TestCharacterService_IndexAll(t *testing.T) {
+	tm := time.Date(2020, time.January, 26, 3, 22, 31, 34959000, time.UTC)
+
+	tests := []struct {
+		name      string
+		sync      *time.Time
+		next      func(host string, page string) string
+		want      []string
+		wantPages []string
+		wantErr   bool
+	}{
+		{
+			name: "Every page",
+			sync: nil,
+			next: func(host string, page string) string {
+				switch page {
+				case "":
+					return `"` + host + `/campaigns/5272/characters?page=2"`
+				case "2":
+					return `"` + host + `/campaigns/5272/characters?page=3"`
+				default:
+					return "null"
+				}
+			},
+			want: []string{"Page 1", "Page 2", "Page 3"},
+			wantPages: []string{
+				"/campaigns/5272/characters?related=1",
+				"/campaigns/5272/characters?page=2&related=1",
+				"/campaigns/5272/characters?page=3&related=1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Sync kept on every page",
+			sync: &tm,
+			next: func(host string, page string) string {
+				if page == "" {
+					return `"` + host + `/campaigns/5272/characters?page=2"`
+				}
+				return "null"
+			},
+			want: []string{"Page 1", "Page 2"},
+			wantPages: []string{
+				"/campaigns/5272/characters?lastSync=2020-01-26T03%3A22%3A31.034959Z&related=1",
+				"/campaigns/5272/characters?lastSync=2020-01-26T03%3A22%3A31.034959Z&page=2&related=1",
+			},
+			wantErr: false,
+		},
+		{
+			name: "Single page",
+			sync: nil,
+			next: func(host string, page string) string {
+				return "null"
+			},
+			want:      []string{"Page 1"},
+			wantPages: []string{"/campaigns/5272/characters?related=1"},
+			wantErr:   false,
+		},
+		{
+			name: "Link outside of API root",
+			sync: nil,
+			next: func(host string, page string) string {
+				return `"https://example.com/campaigns/5272/characters?page=2"`
+			},
+			want:      nil,
+			wantPages: []string{"/campaigns/5272/characters?related=1"},
+			wantErr:   true,
+		},
+		{
+			name: "Repeated link",
+			sync: nil,
+			next: func(host string, page string) string {
+				return `"` + host + `/campaigns/5272/characters?page=2"`
+			},
+			want: nil,
+			wantPages: []string{
+				"/campaigns/5272/characters?related=1",
+				"/campaigns/5272/characters?page=2&related=1",
+			},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var pages []string
+			var ts *httptest.Server
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pages = append(pages, r.URL.String())
+
+				page := r.URL.Query().Get(paramPage)
+				name := "Page 1"
+				if page != "" {
+					name = "Page " + page
+				}
+				fmt.Fprintf(w, `{"data":[{"name":"%s"}],"links":{"next":%s}}`, name, test.next(ts.URL, page))
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			chars, err := c.Characters.IndexAll(5272, test.sync)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			var got []string
+			for _, ch := range chars {
+				got = append(got, ch.Name)
+			}
+
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if diff := cmp.Diff(pages, test.wantPages); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
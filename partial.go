@@ -0,0 +1,126 @@
+package kanka
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// nullID returns the provided ID for use in a partial update body. An ID of 0
+// is returned as nil so that it is sent as JSON null, clearing the field.
+func nullID(id int) interface{} {
+	if id == 0 {
+		return nil
+	}
+
+	return id
+}
+
+// parentFields returns the partial update body that sets the provided parent
+// field to the provided parent ID. A parent ID of 0 clears the field.
+func parentFields(field string, parentID int) (map[string]interface{}, error) {
+	if parentID < 0 {
+		return nil, fmt.Errorf("provided parent ID (%d) cannot be negative", parentID)
+	}
+
+	return map[string]interface{}{field: nullID(parentID)}, nil
+}
+
+// clearFields returns the partial update body that clears each of the provided
+// fields by sending it as JSON null. The required name field cannot be
+// cleared.
+func clearFields(fields []string) (map[string]interface{}, error) {
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("at least one field must be provided")
+	}
+
+	body := make(map[string]interface{}, len(fields))
+	for _, f := range fields {
+		switch f {
+		case "":
+			return nil, fmt.Errorf("provided field cannot be empty")
+		case "name":
+			return nil, fmt.Errorf("required field '%s' cannot be cleared", f)
+		}
+		body[f] = nil
+	}
+
+	return body, nil
+}
+
+// optionalIDFields returns the partial update body that sets each provided
+// field to its provided ID. A nil ID leaves the field out of the body and an
+// ID of 0 clears the field.
+func optionalIDFields(ids map[string]*int) (map[string]interface{}, error) {
+	body := make(map[string]interface{}, len(ids))
+	for field, id := range ids {
+		if id == nil {
+			continue
+		}
+		if *id < 0 {
+			return nil, fmt.Errorf("provided %s (%d) cannot be negative", field, *id)
+		}
+		body[field] = nullID(*id)
+	}
+
+	if len(body) == 0 {
+		return nil, fmt.Errorf("at least one ID must be provided")
+	}
+
+	return body, nil
+}
+
+// serverFields are the fields of an object that are managed by Kanka and
+// rejected or misread when sent back in an update, such as the path of the
+// current image, which Kanka expects to be an uploaded file instead.
+var serverFields = []string{
+	"id",
+	"entity_id",
+	"created_at",
+	"created_by",
+	"updated_at",
+	"updated_by",
+	"image",
+	"image_full",
+	"image_thumb",
+	"has_custom_image",
+	"header_full",
+	"has_custom_header",
+}
+
+// updateBody returns the JSON-encoded update body of the provided simple data
+// without any of the serverFields, so that only writable fields are sent even
+// if the data was copied from an object returned by Kanka.
+func updateBody(data interface{}) ([]byte, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(b, &body); err != nil {
+		return nil, err
+	}
+
+	for _, f := range serverFields {
+		delete(body, f)
+	}
+
+	return json.Marshal(body)
+}
+
+// createBody returns the JSON-encoded create body of the provided simple data.
+// If private is true, the body marks the new object as private.
+func createBody(data interface{}, private bool) ([]byte, error) {
+	b, err := json.Marshal(data)
+	if err != nil || !private {
+		return b, err
+	}
+
+	var body map[string]json.RawMessage
+	if err := json.Unmarshal(b, &body); err != nil {
+		return nil, err
+	}
+	body["is_private"] = json.RawMessage("true")
+
+	return json.Marshal(body)
+}
@@ -22,9 +22,7 @@ type ProfileService service
 
 // Get returns the Profile of the current user.
 func (ps *ProfileService) Get() (*Profile, error) {
-	var wrap struct {
-		Data *Profile `json:"data"`
-	}
+	var wrap response[*Profile]
 
 	err := ps.client.get(ps.end, &wrap)
 	if err != nil {
@@ -1,29 +1,28 @@
 package kanka
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // Quest contains information about a specific quest.
 // For more information, visit: https://kanka.io/en-US/docs/1.0/quests
 type Quest struct {
 	SimpleQuest
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
-	Characters     int       `json:"characters"`
-	Locations      int       `json:"locations"`
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
+	Characters      int       `json:"characters"`
+	Locations       int       `json:"locations"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -36,161 +35,211 @@ type Quest struct {
 // SimpleQuest contains only the simple information about a quest.
 // SimpleQuest is primarily used to create new quests for posting to Kanka.
 type SimpleQuest struct {
-	Name        string `json:"name"`
-	Entry       string `json:"entry,omitempty"`
-	Type        string `json:"type,omitempty"`
-	QuestID     int    `json:"quest_id,omitempty"`
-	CharacterID int    `json:"character_id,omitempty"`
-	Tags        []int  `json:"tags,omitempty"`
-	IsPrivate   bool   `json:"is_private,omitempty"`
-	IsCompleted bool   `json:"is_completed,omitempty"`
-	Image       string `json:"image,omitempty"`
-	ImageURL    string `json:"image_url,omitempty"`
+	Name           string   `json:"name"`
+	Entry          string   `json:"entry,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	QuestID        int      `json:"quest_id,omitempty"`
+	CharacterID    int      `json:"character_id,omitempty"`
+	Tags           []int    `json:"tags,omitempty"`
+	IsPrivate      bool     `json:"is_private,omitempty"`
+	IsTemplate     *bool    `json:"is_template,omitempty"`
+	IsCompleted    bool     `json:"is_completed,omitempty"`
+	Image          string   `json:"image,omitempty"`
+	ImageURL       string   `json:"image_url,omitempty"`
+	ImageUUID      string   `json:"image_uuid,omitempty"`
+	FocusX         *float64 `json:"focus_x,omitempty"`
+	FocusY         *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL string   `json:"header_image_url,omitempty"`
+	HeaderUUID     string   `json:"entity_header_uuid,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleQuest into its JSON-encoded form if it
 // has the required populated fields.
 func (sq SimpleQuest) MarshalJSON() ([]byte, error) {
-	if blank.Is(sq.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleQuest into JSON with a missing Name")
+	if err := requireField("SimpleQuest", "Name", sq.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleQuest
 	return json.Marshal(alias(sq))
 }
 
+// Simple returns a copy of the SimpleQuest data of the Quest, ready to be
+// modified and passed to Update. The path of the current image is left out and
+// the slices of the copy do not share memory with the Quest.
+func (q *Quest) Simple() SimpleQuest {
+	s := q.SimpleQuest
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+
+	return s
+}
+
 // QuestService handles communication with the Quest endpoint.
 type QuestService service
 
+// base returns the baseService implementing the common operations of the
+// QuestService.
+func (qs *QuestService) base() baseService[Quest, SimpleQuest] {
+	return baseService[Quest, SimpleQuest]{
+		service: (*service)(qs),
+		kind:    "Quest",
+		entity:  "quest",
+		label:   func(qst SimpleQuest) string { return qst.Name },
+	}
+}
+
 // Index returns the list of all Quests in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Quests that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Quests that were decoded.
 func (qs *QuestService) Index(campID int, sync *time.Time) ([]*Quest, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(qs.end)
-
-	if sync != nil {
-		end = end.sync(*sync)
-	}
-
-	var wrap struct {
-		Data []*Quest `json:"data"`
-	}
+	return qs.base().Index(campID, sync)
+}
 
-	err = qs.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Quest Index from Campaign (ID: %d): %w", campID, err)
-	}
+// IndexAll returns the list of all Quests in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Quests that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Quests that were decoded.
+func (qs *QuestService) IndexAll(campID int, sync *time.Time) ([]*Quest, error) {
+	return qs.base().IndexAll(campID, sync)
+}
 
-	return wrap.Data, nil
+// GetMany returns the Quests associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Quests, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Quests that were
+// found.
+func (qs *QuestService) GetMany(campID int, ids []int) (map[int]*Quest, error) {
+	return qs.base().GetMany(campID, ids)
 }
 
-// Get returns the Quest associated with qstID from the Campaign
-// associated with campID.
-func (qs *QuestService) Get(campID int, qstID int) (*Quest, error) {
+// Count returns the number of Quests in the Campaign associated with campID
+// without retrieving them.
+func (qs *QuestService) Count(campID int) (int, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(qs.end)
 
-	end, err = end.id(qstID)
+	n, err := qs.client.count(end)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Quest ID: %w", err)
+		return 0, fmt.Errorf("cannot count Quests in Campaign (ID: %d): %w", campID, err)
 	}
 
-	var wrap struct {
-		Data *Quest `json:"data"`
-	}
+	return n, nil
+}
 
-	err = qs.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Quest (ID: %d) from Campaign (ID: %d): %w", qstID, campID, err)
-	}
+// Get returns the Quest associated with qstID from the Campaign
+// associated with campID.
+func (qs *QuestService) Get(campID int, qstID int) (*Quest, error) {
+	return qs.base().Get(campID, qstID)
+}
 
-	return wrap.Data, nil
+// GetByName returns the Quest with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Quest has the name and ErrAmbiguous if more than one does.
+func (qs *QuestService) GetByName(campID int, name string) (*Quest, error) {
+	return qs.base().GetByName(campID, name)
 }
 
 // Create creates a new Quest in the Campaign associated with campID using
 // the provided SimpleQuest data.
 // Create returns the newly created Quest.
-func (qs *QuestService) Create(campID int, qst SimpleQuest) (*Quest, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(qs.end)
-
-	b, err := json.Marshal(qst)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleQuest (Name: %s): %w", qst.Name, err)
-	}
-
-	var wrap struct {
-		Data *Quest `json:"data"`
-	}
-
-	err = qs.client.post(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot create Quest (Name: %s) for Campaign (ID: %d): %w", qst.Name, campID, err)
-	}
+// The provided CreateOptions, such as Public, apply to this call only.
+func (qs *QuestService) Create(campID int, qst SimpleQuest, opts ...CreateOption) (*Quest, error) {
+	return qs.base().Create(campID, qst, opts...)
+}
 
-	return wrap.Data, nil
+// CreateWithResult creates a new Quest in the Campaign associated with campID
+// using the provided SimpleQuest data.
+// CreateWithResult returns the newly created Quest along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (qs *QuestService) CreateWithResult(campID int, qst SimpleQuest, opts ...CreateOption) (*Quest, *WriteResult, error) {
+	return qs.base().CreateWithResult(campID, qst, opts...)
 }
 
 // Update updates an existing Quest associated with qstID from the
 // Campaign associated with campID using the provided SimpleQuest data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Quest returned by Kanka, provide its SimpleQuest.
 // Update returns the newly updated Quest.
 func (qs *QuestService) Update(campID int, qstID int, qst SimpleQuest) (*Quest, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(qs.end)
-
-	end, err = end.id(qstID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Quest ID: %w", err)
-	}
+	return qs.base().Update(campID, qstID, qst)
+}
 
-	b, err := json.Marshal(qst)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleQuest (Name: %s): %w", qst.Name, err)
-	}
+// UpdateWithResult updates an existing Quest associated with qstID from the
+// Campaign associated with campID using the provided SimpleQuest data, as
+// Update does.
+// UpdateWithResult returns the newly updated Quest along with the metadata of
+// Kanka's response, such as its status code.
+func (qs *QuestService) UpdateWithResult(campID int, qstID int, qst SimpleQuest) (*Quest, *WriteResult, error) {
+	return qs.base().UpdateWithResult(campID, qstID, qst)
+}
 
-	var wrap struct {
-		Data *Quest `json:"data"`
-	}
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Quest associated with qstID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Quest is left unchanged.
+// Clear returns the newly updated Quest.
+func (qs *QuestService) Clear(campID int, qstID int, fields ...string) (*Quest, error) {
+	return qs.base().Clear(campID, qstID, fields...)
+}
 
-	err = qs.client.put(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot update Quest (Name: %s) for Campaign (ID: %d): '%w'", qst.Name, campID, err)
-	}
+// SetHeader sets the header image of an existing Quest associated with qstID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Quest on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Quest.
+func (qs *QuestService) SetHeader(campID int, qstID int, uuid string) (*Quest, error) {
+	return qs.base().SetHeader(campID, qstID, uuid)
+}
 
-	return wrap.Data, nil
+// SetHeaderURL sets the header image of an existing Quest associated with
+// qstID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Quest.
+func (qs *QuestService) SetHeaderURL(campID int, qstID int, url string) (*Quest, error) {
+	return qs.base().SetHeaderURL(campID, qstID, url)
 }
 
 // Delete deletes an existing Quest associated with qstID from the
 // Campaign associated with campID.
 func (qs *QuestService) Delete(campID int, qstID int) error {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(qs.end)
+	return qs.base().Delete(campID, qstID)
+}
 
-	end, err = end.id(qstID)
+// Path returns the chain of Quests from the root of the quest tree to the
+// Quest associated with qstID in the Campaign associated with campID, such as
+// the breadcrumbs "Main Quest > Side Quest". The chain ends with the Quest itself.
+func (qs *QuestService) Path(campID int, qstID int) ([]*Quest, error) {
+	paths, err := qs.Paths(campID, []int{qstID})
 	if err != nil {
-		return fmt.Errorf("invalid Quest ID: %w", err)
+		return nil, err
 	}
 
-	err = qs.client.delete(end)
-	if err != nil {
-		return fmt.Errorf("cannot delete Quest (ID: %d) for Campaign (ID: %d): %w", qstID, campID, err)
-	}
+	return paths[qstID], nil
+}
 
-	return nil
+// Paths returns the Path of each Quest associated with the provided qstIDs
+// in the Campaign associated with campID, keyed by Quest ID. Each Quest is
+// fetched at most once, even if it is an ancestor shared by several Quests.
+func (qs *QuestService) Paths(campID int, qstIDs []int) (map[int][]*Quest, error) {
+	return qs.base().paths(campID, qstIDs, func(x *Quest) int { return x.QuestID })
 }
@@ -35,6 +35,8 @@ type QuestCharacterService service
 // qstID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return QuestCharacters that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the QuestCharacters that were decoded.
 func (qs *QuestCharacterService) Index(campID int, qstID int, sync *time.Time) ([]*QuestCharacter, error) {
 	var err error
 	end := EndpointCampaign
@@ -53,15 +55,18 @@ func (qs *QuestCharacterService) Index(campID int, qstID int, sync *time.Time) (
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*QuestCharacter `json:"data"`
-	}
+	var wrap response[[]json.RawMessage]
 
 	if err = qs.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get QuestCharacter Index from Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	var list []*QuestCharacter
+	if err = decodeList(wrap.Data, &list, qs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode QuestCharacter Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
 }
 
 // Get returns the QuestCharacter associated with qchID for the quest associated
@@ -84,9 +89,7 @@ func (qs *QuestCharacterService) Get(campID int, qstID int, qchID int) (*QuestCh
 		return nil, fmt.Errorf("invalid QuestCharacter ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *QuestCharacter `json:"data"`
-	}
+	var wrap response[*QuestCharacter]
 
 	if err = qs.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get QuestCharacter (ID: %d) from Campaign (ID: %d): %w", qchID, campID, err)
@@ -117,9 +120,7 @@ func (qs *QuestCharacterService) Create(campID int, qstID int, qch SimpleQuestCh
 		return nil, fmt.Errorf("cannot marshal SimpleQuestCharacter: %w", err)
 	}
 
-	var wrap struct {
-		Data *QuestCharacter `json:"data"`
-	}
+	var wrap response[*QuestCharacter]
 
 	if err = qs.client.post(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot create QuestCharacter for Campaign (ID: %d): %w", campID, err)
@@ -155,9 +156,7 @@ func (qs *QuestCharacterService) Update(campID int, qstID int, qchID int, qch Si
 		return nil, fmt.Errorf("cannot marshal SimpleQuestCharacter: %w", err)
 	}
 
-	var wrap struct {
-		Data *QuestCharacter `json:"data"`
-	}
+	var wrap response[*QuestCharacter]
 
 	if err = qs.client.put(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot update QuestCharacter for Campaign (ID: %d): '%w'", campID, err)
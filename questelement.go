@@ -0,0 +1,211 @@
+package kanka
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// QuestElement contains information about a specific quest element.
+// QuestElement represents an entity of any type, such as a character or a
+// location, taking part in the parent quest. Quest elements replace the
+// separate quest characters, locations, items, and organisations.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/quests#quest-elements
+type QuestElement struct {
+	SimpleQuestElement
+	ID        int       `json:"id"`
+	QuestID   int       `json:"quest_id"`
+	CreatedAt time.Time `json:"created_at"`
+	CreatedBy int       `json:"created_by"`
+	UpdatedAt time.Time `json:"updated_at"`
+	UpdatedBy int       `json:"updated_by"`
+}
+
+// SimpleQuestElement contains only the simple information about a quest element.
+// SimpleQuestElement is primarily used to create new quest elements for posting to Kanka.
+type SimpleQuestElement struct {
+	EntityID    int        `json:"entity_id,omitempty"`
+	Name        string     `json:"name,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Role        string     `json:"role,omitempty"`
+	Colour      string     `json:"colour,omitempty"`
+	Visibility  Visibility `json:"visibility,omitempty"`
+}
+
+// MarshalJSON marshals the SimpleQuestElement into its JSON-encoded form if it
+// has either an entity or a name.
+func (se SimpleQuestElement) MarshalJSON() ([]byte, error) {
+	if se.EntityID <= 0 {
+		if err := requireField("SimpleQuestElement", "EntityID or Name", se.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	type alias SimpleQuestElement
+	return json.Marshal(alias(se))
+}
+
+// QuestElementService handles communication with the QuestElement endpoint.
+type QuestElementService service
+
+// Index returns the list of all QuestElements for the quest associated with
+// qstID in the Campaign associated with campID.
+// If a non-nil time is provided, Index will only return QuestElements that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the QuestElements that were decoded.
+func (qs *QuestElementService) Index(campID int, qstID int, sync *time.Time) ([]*QuestElement, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(EndpointQuest)
+
+	if end, err = end.id(qstID); err != nil {
+		return nil, fmt.Errorf("invalid Quest ID: %w", err)
+	}
+	end = end.concat(qs.end)
+
+	if sync != nil {
+		end = end.sync(*sync)
+	}
+
+	var wrap response[[]json.RawMessage]
+
+	if err = qs.client.get(end, &wrap); err != nil {
+		return nil, fmt.Errorf("cannot get QuestElement Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	var list []*QuestElement
+	if err = decodeList(wrap.Data, &list, qs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode QuestElement Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// Get returns the QuestElement associated with qelID for the quest associated
+// with qstID from the Campaign associated with campID.
+func (qs *QuestElementService) Get(campID int, qstID int, qelID int) (*QuestElement, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(EndpointQuest)
+
+	if end, err = end.id(qstID); err != nil {
+		return nil, fmt.Errorf("invalid Quest ID: %w", err)
+	}
+	end = end.concat(qs.end)
+
+	if end, err = end.id(qelID); err != nil {
+		return nil, fmt.Errorf("invalid QuestElement ID: %w", err)
+	}
+
+	var wrap response[*QuestElement]
+
+	if err = qs.client.get(end, &wrap); err != nil {
+		return nil, fmt.Errorf("cannot get QuestElement (ID: %d) from Campaign (ID: %d): %w", qelID, campID, err)
+	}
+
+	return wrap.Data, nil
+}
+
+// Create creates a new QuestElement for the quest associated with qstID in the
+// Campaign associated with campID using the provided SimpleQuestElement data.
+// Create returns the newly created QuestElement.
+func (qs *QuestElementService) Create(campID int, qstID int, qel SimpleQuestElement) (*QuestElement, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(EndpointQuest)
+
+	if end, err = end.id(qstID); err != nil {
+		return nil, fmt.Errorf("invalid Quest ID: %w", err)
+	}
+	end = end.concat(qs.end)
+
+	b, err := json.Marshal(qel)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal SimpleQuestElement: %w", err)
+	}
+
+	var wrap response[*QuestElement]
+
+	if err = qs.client.post(end, bytes.NewReader(b), &wrap); err != nil {
+		return nil, fmt.Errorf("cannot create QuestElement for Campaign (ID: %d): %w", campID, err)
+	}
+
+	return wrap.Data, nil
+}
+
+// Update updates an existing QuestElement associated with qelID for the quest
+// associated with qstID from the Campaign associated with campID using the
+// provided SimpleQuestElement data.
+// Update returns the newly updated QuestElement.
+func (qs *QuestElementService) Update(campID int, qstID int, qelID int, qel SimpleQuestElement) (*QuestElement, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(EndpointQuest)
+
+	if end, err = end.id(qstID); err != nil {
+		return nil, fmt.Errorf("invalid Quest ID: %w", err)
+	}
+	end = end.concat(qs.end)
+
+	if end, err = end.id(qelID); err != nil {
+		return nil, fmt.Errorf("invalid QuestElement ID: %w", err)
+	}
+
+	b, err := json.Marshal(qel)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal SimpleQuestElement: %w", err)
+	}
+
+	var wrap response[*QuestElement]
+
+	if err = qs.client.put(end, bytes.NewReader(b), &wrap); err != nil {
+		return nil, fmt.Errorf("cannot update QuestElement for Campaign (ID: %d): '%w'", campID, err)
+	}
+
+	return wrap.Data, nil
+}
+
+// Delete deletes an existing QuestElement associated with qelID from the
+// Campaign associated with campID.
+func (qs *QuestElementService) Delete(campID int, qstID int, qelID int) error {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(EndpointQuest)
+
+	if end, err = end.id(qstID); err != nil {
+		return fmt.Errorf("invalid Quest ID: %w", err)
+	}
+	end = end.concat(qs.end)
+
+	if end, err = end.id(qelID); err != nil {
+		return fmt.Errorf("invalid QuestElement ID: %w", err)
+	}
+
+	if err = qs.client.delete(end); err != nil {
+		return fmt.Errorf("cannot delete QuestElement (ID: %d) for Campaign (ID: %d): %w", qelID, campID, err)
+	}
+
+	return nil
+}
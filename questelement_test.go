@@ -0,0 +1,606 @@
+package kanka
+
+import (
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const (
+	testQuestElementIndex  string = "test_data/questelement_index.json"
+	testQuestElementGet    string = "test_data/questelement_get.json"
+	testQuestElementCreate string = "test_data/questelement_create.json"
+	testQuestElementUpdate string = "test_data/questelement_update.json"
+)
+
+func TestQuestElementService_Index(t *testing.T) {
+	qels := []*QuestElement{
+		{
+			SimpleQuestElement: SimpleQuestElement{
+				EntityID: 222,
+				Role:     "Hero",
+			},
+			QuestID: 111,
+		},
+		{
+			SimpleQuestElement: SimpleQuestElement{
+				EntityID: 444,
+				Role:     "Goddess",
+			},
+			QuestID: 333,
+		},
+		{
+			SimpleQuestElement: SimpleQuestElement{
+				EntityID: 666,
+				Role:     "Father",
+			},
+			QuestID: 555,
+		},
+	}
+	n := time.Now()
+	now := &n
+
+	type args struct {
+		campID int
+		qstID  int
+		sync   *time.Time
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*QuestElement
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testQuestElementIndex,
+			args:    args{campID: 5272, qstID: 10394, sync: now},
+			want:    qels,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testQuestElementIndex,
+			args:    args{campID: -123, qstID: 10394, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid qstID",
+			status:  http.StatusOK,
+			file:    testQuestElementIndex,
+			args:    args{campID: 5272, qstID: -123, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testQuestElementIndex,
+			args:    args{campID: -123, qstID: -123, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, qstID: -123, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, sync: now},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.QuestElements.Index(test.args.campID, test.args.qstID, test.args.sync)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQuestElementService_Get(t *testing.T) {
+	qel := &QuestElement{
+		SimpleQuestElement: SimpleQuestElement{
+			EntityID:    24326,
+			Description: "\n<p>The princess trapped in the tower</p>\n",
+			Role:        "Princess",
+		},
+		ID:        6849,
+		CreatedBy: 0,
+		UpdatedBy: 0,
+	}
+
+	type args struct {
+		campID int
+		qstID  int
+		qelID  int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *QuestElement
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testQuestElementGet,
+			args:    args{campID: 5272, qstID: 10394, qelID: 6849},
+			want:    qel,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testQuestElementGet,
+			args:    args{campID: -123, qstID: 10394, qelID: 6849},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid qstID",
+			status:  http.StatusOK,
+			file:    testQuestElementGet,
+			args:    args{campID: 5272, qstID: -123, qelID: 6849},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid qelID",
+			status:  http.StatusOK,
+			file:    testQuestElementGet,
+			args:    args{campID: 5272, qstID: 10394, qelID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testQuestElementGet,
+			args:    args{campID: -123, qstID: -123, qelID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, qelID: 6849},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, qstID: 10394, qelID: -123},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, qelID: 6849},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, qelID: 6849},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, qelID: 6849},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.QuestElements.Get(test.args.campID, test.args.qstID, test.args.qelID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQuestElementService_Create(t *testing.T) {
+	qel := SimpleQuestElement{
+		EntityID: 888,
+		Role:     "Threshold Guardian",
+	}
+	type args struct {
+		campID int
+		qstID  int
+		qel    SimpleQuestElement
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *QuestElement
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testQuestElementCreate,
+			args:    args{campID: 5272, qstID: 10394, qel: qel},
+			want:    &QuestElement{SimpleQuestElement: qel, QuestID: 777},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testQuestElementCreate,
+			args:    args{campID: -123, qstID: 10394, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid qstID",
+			status:  http.StatusOK,
+			file:    testQuestElementCreate,
+			args:    args{campID: 5272, qstID: -123, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, missing entity and name",
+			status:  http.StatusOK,
+			file:    testQuestElementCreate,
+			args:    args{campID: 5272, qstID: 10394, qel: SimpleQuestElement{Role: "Mentor"}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testQuestElementCreate,
+			args:    args{campID: -123, qstID: -123, qel: SimpleQuestElement{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, qstID: -123, qel: SimpleQuestElement{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.QuestElements.Create(test.args.campID, test.args.qstID, test.args.qel)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQuestElementService_Update(t *testing.T) {
+	qel := SimpleQuestElement{
+		EntityID: 101010,
+		Role:     "Temptress",
+	}
+	type args struct {
+		campID int
+		qstID  int
+		qelID  int
+		qel    SimpleQuestElement
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    *QuestElement
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testQuestElementUpdate,
+			args:    args{campID: 5272, qstID: 10394, qelID: 111, qel: qel},
+			want:    &QuestElement{SimpleQuestElement: qel, ID: 111, QuestID: 999},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testQuestElementUpdate,
+			args:    args{campID: -123, qstID: 10394, qelID: 111, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid qstID",
+			status:  http.StatusOK,
+			file:    testQuestElementUpdate,
+			args:    args{campID: 5272, qstID: -123, qelID: 111, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid qelID",
+			status:  http.StatusOK,
+			file:    testQuestElementUpdate,
+			args:    args{campID: 5272, qstID: 10394, qelID: -123, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, missing entity and name",
+			status:  http.StatusOK,
+			file:    testQuestElementUpdate,
+			args:    args{campID: 5272, qstID: 10394, qelID: 111, qel: SimpleQuestElement{Role: "Mentor"}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid args",
+			status:  http.StatusOK,
+			file:    testQuestElementUpdate,
+			args:    args{campID: -123, qstID: -123, qelID: -123, qel: SimpleQuestElement{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, qelID: 111, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, invalid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: -123, qstID: -123, qelID: -123, qel: SimpleQuestElement{}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, qelID: 111, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, qelID: 111, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, qstID: 10394, qelID: 111, qel: qel},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.QuestElements.Update(test.args.campID, test.args.qstID, test.args.qelID, test.args.qel)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestQuestElementService_Delete(t *testing.T) {
+	type args struct {
+		campID int
+		qstID  int
+		qelID  int
+	}
+	tests := []struct {
+		name    string
+		status  int
+		args    args
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid args",
+			status:  http.StatusOK,
+			args:    args{campID: 5272, qstID: 10394, qelID: 111},
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, invalid campID",
+			status:  http.StatusOK,
+			args:    args{campID: -123, qstID: 10394, qelID: 111},
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, invalid qstID",
+			status:  http.StatusOK,
+			args:    args{campID: 5272, qstID: -123, qelID: 111},
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, invalid qelID",
+			status:  http.StatusOK,
+			args:    args{campID: 5272, qstID: 10394, qelID: -123},
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, invalid args",
+			status:  http.StatusOK,
+			args:    args{campID: -123, qstID: -123, qelID: -123},
+			wantErr: true,
+		},
+		{
+			name:    "StatusUnauthorized, valid args",
+			status:  http.StatusUnauthorized,
+			args:    args{campID: 5272, qstID: 10394, qelID: 111},
+			wantErr: true,
+		},
+		{
+			name:    "StatusForbidden, valid args",
+			status:  http.StatusForbidden,
+			args:    args{campID: 5272, qstID: 10394, qelID: 111},
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			args:    args{campID: 5272, qstID: 10394, qelID: 111},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(testFileEmpty)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			err = c.QuestElements.Delete(test.args.campID, test.args.qstID, test.args.qelID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+		})
+	}
+}
@@ -35,6 +35,8 @@ type QuestItemService service
 // qstID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return QuestItems that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the QuestItems that were decoded.
 func (qs *QuestItemService) Index(campID int, qstID int, sync *time.Time) ([]*QuestItem, error) {
 	var err error
 	end := EndpointCampaign
@@ -53,15 +55,18 @@ func (qs *QuestItemService) Index(campID int, qstID int, sync *time.Time) ([]*Qu
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*QuestItem `json:"data"`
-	}
+	var wrap response[[]json.RawMessage]
 
 	if err = qs.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get QuestItem Index from Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	var list []*QuestItem
+	if err = decodeList(wrap.Data, &list, qs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode QuestItem Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
 }
 
 // Get returns the QuestItem associated with itemID for the quest associated
@@ -84,9 +89,7 @@ func (qs *QuestItemService) Get(campID int, qstID int, itemID int) (*QuestItem,
 		return nil, fmt.Errorf("invalid QuestItem ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *QuestItem `json:"data"`
-	}
+	var wrap response[*QuestItem]
 
 	if err = qs.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get QuestItem (ID: %d) from Campaign (ID: %d): %w", itemID, campID, err)
@@ -117,9 +120,7 @@ func (qs *QuestItemService) Create(campID int, qstID int, item SimpleQuestItem)
 		return nil, fmt.Errorf("cannot marshal SimpleQuestItem: %w", err)
 	}
 
-	var wrap struct {
-		Data *QuestItem `json:"data"`
-	}
+	var wrap response[*QuestItem]
 
 	if err = qs.client.post(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot create QuestItem for Campaign (ID: %d): %w", campID, err)
@@ -155,9 +156,7 @@ func (qs *QuestItemService) Update(campID int, qstID int, itemID int, item Simpl
 		return nil, fmt.Errorf("cannot marshal SimpleQuestItem: %w", err)
 	}
 
-	var wrap struct {
-		Data *QuestItem `json:"data"`
-	}
+	var wrap response[*QuestItem]
 
 	if err = qs.client.put(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot update QuestItem for Campaign (ID: %d): '%w'", campID, err)
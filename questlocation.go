@@ -35,6 +35,8 @@ type QuestLocationService service
 // qstID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return QuestLocations that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the QuestLocations that were decoded.
 func (qs *QuestLocationService) Index(campID int, qstID int, sync *time.Time) ([]*QuestLocation, error) {
 	var err error
 	end := EndpointCampaign
@@ -53,15 +55,18 @@ func (qs *QuestLocationService) Index(campID int, qstID int, sync *time.Time) ([
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*QuestLocation `json:"data"`
-	}
+	var wrap response[[]json.RawMessage]
 
 	if err = qs.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get QuestLocation Index from Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	var list []*QuestLocation
+	if err = decodeList(wrap.Data, &list, qs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode QuestLocation Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
 }
 
 // Get returns the QuestLocation associated with qlocID for the quest associated
@@ -84,9 +89,7 @@ func (qs *QuestLocationService) Get(campID int, qstID int, qlocID int) (*QuestLo
 		return nil, fmt.Errorf("invalid QuestLocation ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *QuestLocation `json:"data"`
-	}
+	var wrap response[*QuestLocation]
 
 	if err = qs.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get QuestLocation (ID: %d) from Campaign (ID: %d): %w", qlocID, campID, err)
@@ -117,9 +120,7 @@ func (qs *QuestLocationService) Create(campID int, qstID int, qloc SimpleQuestLo
 		return nil, fmt.Errorf("cannot marshal SimpleQuestLocation: %w", err)
 	}
 
-	var wrap struct {
-		Data *QuestLocation `json:"data"`
-	}
+	var wrap response[*QuestLocation]
 
 	if err = qs.client.post(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot create QuestLocation for Campaign (ID: %d): %w", campID, err)
@@ -155,9 +156,7 @@ func (qs *QuestLocationService) Update(campID int, qstID int, qlocID int, qloc S
 		return nil, fmt.Errorf("cannot marshal SimpleQuestLocation: %w", err)
 	}
 
-	var wrap struct {
-		Data *QuestLocation `json:"data"`
-	}
+	var wrap response[*QuestLocation]
 
 	if err = qs.client.put(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot update QuestLocation for Campaign (ID: %d): '%w'", campID, err)
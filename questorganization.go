@@ -35,6 +35,8 @@ type QuestOrganizationService service
 // qstID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return QuestOrganizations that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the QuestOrganizations that were decoded.
 func (qs *QuestOrganizationService) Index(campID int, qstID int, sync *time.Time) ([]*QuestOrganization, error) {
 	var err error
 	end := EndpointCampaign
@@ -53,15 +55,18 @@ func (qs *QuestOrganizationService) Index(campID int, qstID int, sync *time.Time
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*QuestOrganization `json:"data"`
-	}
+	var wrap response[[]json.RawMessage]
 
 	if err = qs.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get QuestOrganization Index from Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	var list []*QuestOrganization
+	if err = decodeList(wrap.Data, &list, qs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode QuestOrganization Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
 }
 
 // Get returns the QuestOrganization associated with orgID for the quest associated
@@ -84,9 +89,7 @@ func (qs *QuestOrganizationService) Get(campID int, qstID int, orgID int) (*Ques
 		return nil, fmt.Errorf("invalid QuestOrganization ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *QuestOrganization `json:"data"`
-	}
+	var wrap response[*QuestOrganization]
 
 	if err = qs.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get QuestOrganization (ID: %d) from Campaign (ID: %d): %w", orgID, campID, err)
@@ -122,9 +125,7 @@ func (qs *QuestOrganizationService) Update(campID int, qstID int, orgID int, org
 		return nil, fmt.Errorf("cannot marshal SimpleQuestOrganization: %w", err)
 	}
 
-	var wrap struct {
-		Data *QuestOrganization `json:"data"`
-	}
+	var wrap response[*QuestOrganization]
 
 	if err = qs.client.put(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot update QuestOrganization for Campaign (ID: %d): '%w'", campID, err)
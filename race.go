@@ -5,23 +5,23 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // Race contains information about a specific race.
 // For more information, visit: https://kanka.io/en-US/docs/1.0/races
 type Race struct {
 	SimpleRace
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -34,113 +34,194 @@ type Race struct {
 // SimpleRace contains only the simple information about a race.
 // SimpleRace is primarily used to create new races for posting to Kanka.
 type SimpleRace struct {
-	Name      string `json:"name"`
-	Entry     string `json:"entry,omitempty"`
-	Type      string `json:"type,omitempty"`
-	RaceID    int    `json:"race_id,omitempty"`
-	Tags      []int  `json:"tags,omitempty"`
-	IsPrivate bool   `json:"is_private,omitempty"`
-	Image     string `json:"image,omitempty"`
-	ImageURL  string `json:"image_url,omitempty"`
+	Name           string   `json:"name"`
+	Entry          string   `json:"entry,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	RaceID         int      `json:"race_id,omitempty"`
+	Tags           []int    `json:"tags,omitempty"`
+	IsPrivate      bool     `json:"is_private,omitempty"`
+	IsTemplate     *bool    `json:"is_template,omitempty"`
+	Image          string   `json:"image,omitempty"`
+	ImageURL       string   `json:"image_url,omitempty"`
+	ImageUUID      string   `json:"image_uuid,omitempty"`
+	FocusX         *float64 `json:"focus_x,omitempty"`
+	FocusY         *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL string   `json:"header_image_url,omitempty"`
+	HeaderUUID     string   `json:"entity_header_uuid,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleRace into its JSON-encoded form if it
 // has the required populated fields.
 func (sr SimpleRace) MarshalJSON() ([]byte, error) {
-	if blank.Is(sr.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleRace into JSON with a missing Name")
+	if err := requireField("SimpleRace", "Name", sr.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleRace
 	return json.Marshal(alias(sr))
 }
 
+// Simple returns a copy of the SimpleRace data of the Race, ready to be
+// modified and passed to Update. The path of the current image is left out and
+// the slices of the copy do not share memory with the Race.
+func (r *Race) Simple() SimpleRace {
+	s := r.SimpleRace
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+
+	return s
+}
+
 // RaceService handles communication with the Race endpoint.
 type RaceService service
 
+// base returns the baseService implementing the common operations of the
+// RaceService.
+func (rs *RaceService) base() baseService[Race, SimpleRace] {
+	return baseService[Race, SimpleRace]{
+		service: (*service)(rs),
+		kind:    "Race",
+		entity:  "race",
+		label:   func(race SimpleRace) string { return race.Name },
+	}
+}
+
 // Index returns the list of all Races in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Races that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Races that were decoded.
 func (rs *RaceService) Index(campID int, sync *time.Time) ([]*Race, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(rs.end)
-
-	if sync != nil {
-		end = end.sync(*sync)
-	}
-
-	var wrap struct {
-		Data []*Race `json:"data"`
-	}
+	return rs.base().Index(campID, sync)
+}
 
-	err = rs.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Race Index from Campaign (ID: %d): %w", campID, err)
-	}
+// IndexAll returns the list of all Races in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Races that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Races that were decoded.
+func (rs *RaceService) IndexAll(campID int, sync *time.Time) ([]*Race, error) {
+	return rs.base().IndexAll(campID, sync)
+}
 
-	return wrap.Data, nil
+// GetMany returns the Races associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Races, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Races that were
+// found.
+func (rs *RaceService) GetMany(campID int, ids []int) (map[int]*Race, error) {
+	return rs.base().GetMany(campID, ids)
 }
 
-// Get returns the Race associated with raceID from the Campaign
-// associated with campID.
-func (rs *RaceService) Get(campID int, raceID int) (*Race, error) {
+// Count returns the number of Races in the Campaign associated with campID
+// without retrieving them.
+func (rs *RaceService) Count(campID int) (int, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(rs.end)
 
-	end, err = end.id(raceID)
+	n, err := rs.client.count(end)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Race ID: %w", err)
+		return 0, fmt.Errorf("cannot count Races in Campaign (ID: %d): %w", campID, err)
 	}
 
-	var wrap struct {
-		Data *Race `json:"data"`
-	}
+	return n, nil
+}
 
-	err = rs.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Race (ID: %d) from Campaign (ID: %d): %w", raceID, campID, err)
-	}
+// Get returns the Race associated with raceID from the Campaign
+// associated with campID.
+func (rs *RaceService) Get(campID int, raceID int) (*Race, error) {
+	return rs.base().Get(campID, raceID)
+}
 
-	return wrap.Data, nil
+// GetByName returns the Race with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Race has the name and ErrAmbiguous if more than one does.
+func (rs *RaceService) GetByName(campID int, name string) (*Race, error) {
+	return rs.base().GetByName(campID, name)
 }
 
 // Create creates a new Race in the Campaign associated with campID using
 // the provided SimpleRace data.
 // Create returns the newly created Race.
-func (rs *RaceService) Create(campID int, race SimpleRace) (*Race, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(rs.end)
-
-	b, err := json.Marshal(race)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleRace (Name: %s): %w", race.Name, err)
-	}
-
-	var wrap struct {
-		Data *Race `json:"data"`
-	}
-
-	err = rs.client.post(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot create Race (Name: %s) for Campaign (ID: %d): %w", race.Name, campID, err)
-	}
+// The provided CreateOptions, such as Public, apply to this call only.
+func (rs *RaceService) Create(campID int, race SimpleRace, opts ...CreateOption) (*Race, error) {
+	return rs.base().Create(campID, race, opts...)
+}
 
-	return wrap.Data, nil
+// CreateWithResult creates a new Race in the Campaign associated with campID
+// using the provided SimpleRace data.
+// CreateWithResult returns the newly created Race along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (rs *RaceService) CreateWithResult(campID int, race SimpleRace, opts ...CreateOption) (*Race, *WriteResult, error) {
+	return rs.base().CreateWithResult(campID, race, opts...)
 }
 
 // Update updates an existing Race associated with raceID from the
 // Campaign associated with campID using the provided SimpleRace data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Race returned by Kanka, provide its SimpleRace.
 // Update returns the newly updated Race.
 func (rs *RaceService) Update(campID int, raceID int, race SimpleRace) (*Race, error) {
+	return rs.base().Update(campID, raceID, race)
+}
+
+// UpdateWithResult updates an existing Race associated with raceID from the
+// Campaign associated with campID using the provided SimpleRace data, as
+// Update does.
+// UpdateWithResult returns the newly updated Race along with the metadata of
+// Kanka's response, such as its status code.
+func (rs *RaceService) UpdateWithResult(campID int, raceID int, race SimpleRace) (*Race, *WriteResult, error) {
+	return rs.base().UpdateWithResult(campID, raceID, race)
+}
+
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Race associated with raceID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Race is left unchanged.
+// Clear returns the newly updated Race.
+func (rs *RaceService) Clear(campID int, raceID int, fields ...string) (*Race, error) {
+	return rs.base().Clear(campID, raceID, fields...)
+}
+
+// SetHeader sets the header image of an existing Race associated with raceID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Race on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Race.
+func (rs *RaceService) SetHeader(campID int, raceID int, uuid string) (*Race, error) {
+	return rs.base().SetHeader(campID, raceID, uuid)
+}
+
+// SetHeaderURL sets the header image of an existing Race associated with
+// raceID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Race.
+func (rs *RaceService) SetHeaderURL(campID int, raceID int, url string) (*Race, error) {
+	return rs.base().SetHeaderURL(campID, raceID, url)
+}
+
+// SetParent sets the parent Race of the Race associated with raceID in the
+// Campaign associated with campID to the Race associated with parentID.
+// A parentID of 0 removes the parent Race. Only the parent is updated; every
+// other field of the Race is left unchanged.
+// SetParent returns the newly updated Race.
+func (rs *RaceService) SetParent(campID int, raceID int, parentID int) (*Race, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
@@ -152,18 +233,21 @@ func (rs *RaceService) Update(campID int, raceID int, race SimpleRace) (*Race, e
 		return nil, fmt.Errorf("invalid Race ID: %w", err)
 	}
 
-	b, err := json.Marshal(race)
+	fields, err := parentFields("race_id", parentID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleRace (Name: %s): %w", race.Name, err)
+		return nil, fmt.Errorf("invalid parent Race ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *Race `json:"data"`
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal parent Race (ID: %d): %w", parentID, err)
 	}
 
-	err = rs.client.put(end, bytes.NewReader(b), &wrap)
+	var wrap response[*Race]
+
+	err = rs.client.patch(end, bytes.NewReader(b), &wrap)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update Race (Name: %s) for Campaign (ID: %d): '%w'", race.Name, campID, err)
+		return nil, fmt.Errorf("cannot set parent of Race (ID: %d) for Campaign (ID: %d): %w", raceID, campID, err)
 	}
 
 	return wrap.Data, nil
@@ -172,21 +256,24 @@ func (rs *RaceService) Update(campID int, raceID int, race SimpleRace) (*Race, e
 // Delete deletes an existing Race associated with raceID from the
 // Campaign associated with campID.
 func (rs *RaceService) Delete(campID int, raceID int) error {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(rs.end)
+	return rs.base().Delete(campID, raceID)
+}
 
-	end, err = end.id(raceID)
+// Path returns the chain of Races from the root of the race tree to the
+// Race associated with raceID in the Campaign associated with campID, such as
+// the breadcrumbs "Elf > High Elf". The chain ends with the Race itself.
+func (rs *RaceService) Path(campID int, raceID int) ([]*Race, error) {
+	paths, err := rs.Paths(campID, []int{raceID})
 	if err != nil {
-		return fmt.Errorf("invalid Race ID: %w", err)
+		return nil, err
 	}
 
-	err = rs.client.delete(end)
-	if err != nil {
-		return fmt.Errorf("cannot delete Race (ID: %d) for Campaign (ID: %d): %w", raceID, campID, err)
-	}
+	return paths[raceID], nil
+}
 
-	return nil
+// Paths returns the Path of each Race associated with the provided raceIDs
+// in the Campaign associated with campID, keyed by Race ID. Each Race is
+// fetched at most once, even if it is an ancestor shared by several Races.
+func (rs *RaceService) Paths(campID int, raceIDs []int) (map[int][]*Race, error) {
+	return rs.base().paths(campID, raceIDs, func(x *Race) int { return x.RaceID })
 }
@@ -3,10 +3,10 @@ package kanka
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sort"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // Relation contains information about a specific relation.
@@ -31,25 +31,58 @@ type SimpleRelation struct {
 }
 
 // For more information, visit: https://kanka.io/en-US/docs/1.0/relations#create-relation
+const relationLengthMax int = 255
+
+// Range of valid Attitude values.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/relations#create-relation
+const (
+	AttitudeMin int = -100
+	AttitudeMax int = 100
+)
+
+// Available Attitude labels.
 const (
-	relationLengthMax int = 255
-	attitudeMin       int = -100
-	attitudeMax       int = 100
+	AttitudeHostile  string = "hostile"
+	AttitudeNeutral  string = "neutral"
+	AttitudeFriendly string = "friendly"
 )
 
+// Bounds of the neutral Attitude bucket.
+const (
+	attitudeNeutralMin int = -33
+	attitudeNeutralMax int = 33
+)
+
+// AttitudeLabel returns the label describing the provided Attitude value.
+// Attitudes below -33 are hostile, attitudes above 33 are friendly, and
+// the remaining attitudes are neutral. AttitudeLabel returns an empty string
+// if the provided Attitude is outside of the valid range.
+func AttitudeLabel(att int) string {
+	switch {
+	case att < AttitudeMin || att > AttitudeMax:
+		return ""
+	case att < attitudeNeutralMin:
+		return AttitudeHostile
+	case att > attitudeNeutralMax:
+		return AttitudeFriendly
+	default:
+		return AttitudeNeutral
+	}
+}
+
 // MarshalJSON marshals the SimpleRelation into its JSON-encoded form if it
 // has the required populated fields.
 func (sr SimpleRelation) MarshalJSON() ([]byte, error) {
-	if blank.Is(sr.Relation) {
-		return nil, fmt.Errorf("cannot marshal SimpleRelation into JSON with a missing Relation")
+	if err := requireField("SimpleRelation", "Relation", sr.Relation); err != nil {
+		return nil, err
 	}
 
 	if len(sr.Relation) > relationLengthMax {
 		return nil, fmt.Errorf("length of Relation string must not exceed %d characters", relationLengthMax)
 	}
 
-	if sr.Attitude < attitudeMin || sr.Attitude > attitudeMax {
-		return nil, fmt.Errorf("value of Attitude must be between %d and %d", attitudeMin, attitudeMax)
+	if sr.Attitude < AttitudeMin || sr.Attitude > AttitudeMax {
+		return nil, fmt.Errorf("value of Attitude must be between %d and %d", AttitudeMin, AttitudeMax)
 	}
 
 	type alias SimpleRelation
@@ -63,6 +96,58 @@ type Relations struct {
 	Sync time.Time  `json:"sync"`
 }
 
+// UnmarshalJSON unmarshals the JSON-encoded data into the Relations. A null value
+// or a null list is unmarshaled into an empty list rather than a nil one.
+func (r *Relations) UnmarshalJSON(b []byte) error {
+	type alias Relations
+	var al alias
+
+	if !isNull(b) {
+		if err := json.Unmarshal(b, &al); err != nil {
+			return err
+		}
+	}
+
+	if al.Data == nil {
+		al.Data = []Relation{}
+	}
+
+	*r = Relations(al)
+	return nil
+}
+
+// RelationOrder specifies the order in which relations are sorted.
+type RelationOrder int
+
+// Available orders for sorting relations.
+const (
+	// ByAttitude sorts relations from the friendliest to the most hostile.
+	ByAttitude RelationOrder = iota
+	// ByCreation sorts relations from the oldest to the newest.
+	ByCreation
+)
+
+// Sorted returns a sorted copy of the wrapped relations using the provided
+// order. Relations that are equal under the provided order keep their
+// original order.
+func (r Relations) Sorted(by RelationOrder) []Relation {
+	rels := make([]Relation, len(r.Data))
+	copy(rels, r.Data)
+
+	switch by {
+	case ByAttitude:
+		sort.SliceStable(rels, func(i, j int) bool {
+			return rels[i].Attitude > rels[j].Attitude
+		})
+	case ByCreation:
+		sort.SliceStable(rels, func(i, j int) bool {
+			return rels[i].CreatedAt.Before(rels[j].CreatedAt)
+		})
+	}
+
+	return rels
+}
+
 // RelationService handles communication with the Relation endpoint.
 type RelationService service
 
@@ -70,6 +155,8 @@ type RelationService service
 // entID in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Relations that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Relations that were decoded.
 func (rs *RelationService) Index(campID int, entID int, sync *time.Time) ([]*Relation, error) {
 	var err error
 	end := EndpointCampaign
@@ -88,15 +175,150 @@ func (rs *RelationService) Index(campID int, entID int, sync *time.Time) ([]*Rel
 		end = end.sync(*sync)
 	}
 
-	var wrap struct {
-		Data []*Relation `json:"data"`
-	}
+	var wrap response[[]json.RawMessage]
 
 	if err = rs.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get Relation Index from Campaign (ID: %d): %w", campID, err)
 	}
 
-	return wrap.Data, nil
+	var list []*Relation
+	if err = decodeList(wrap.Data, &list, rs.client.strict); err != nil {
+		return list, fmt.Errorf("cannot decode Relation Index from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
+
+// Incoming returns the list of all Relations in the Campaign associated with
+// campID that target the entity associated with entID. Unlike Index, which
+// lists the relations owned by the entity, Incoming lists the relations other
+// entities have pointing at it. Kanka cannot filter relations by target, so
+// Incoming follows every page of the Campaign's Relations, each a separate
+// request subject to the rate limit of the Client, and keeps those targeting
+// the entity.
+// If a non-nil time is provided, Incoming will only return Relations that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Relations that were decoded.
+func (rs *RelationService) Incoming(campID int, entID int, sync *time.Time) ([]*Relation, error) {
+	var err error
+	end := EndpointCampaign
+
+	if end, err = end.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(rs.end)
+
+	if entID < 0 {
+		return nil, fmt.Errorf("invalid Entity ID: provided ID (%d) cannot be negative", entID)
+	}
+
+	if sync != nil {
+		end = end.sync(*sync)
+	}
+
+	raws, err := rs.client.indexAll(end)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get every page of incoming Relations for Entity (ID: %d) from Campaign (ID: %d): %w", entID, campID, err)
+	}
+
+	var list []*Relation
+	err = decodeList(raws, &list, rs.client.strict)
+
+	var in []*Relation
+	for _, rel := range list {
+		if rel.TargetID == entID {
+			in = append(in, rel)
+		}
+	}
+
+	if err != nil {
+		return in, fmt.Errorf("cannot decode incoming Relations for Entity (ID: %d) from Campaign (ID: %d): %w", entID, campID, err)
+	}
+
+	return in, nil
+}
+
+// RelationDirection specifies whether a relation is owned by an entity or
+// targets it.
+type RelationDirection int
+
+// Available relation directions.
+const (
+	// RelationOutgoing marks a relation owned by the entity.
+	RelationOutgoing RelationDirection = iota
+	// RelationIncoming marks a relation another entity has pointing at the
+	// entity.
+	RelationIncoming
+)
+
+// DirectedRelation contains a Relation of an entity along with its direction
+// as seen from that entity.
+type DirectedRelation struct {
+	*Relation
+	Direction RelationDirection
+}
+
+// Other returns the ID of the entity at the other end of the DirectedRelation,
+// which is its target if outgoing and its owner if incoming.
+func (dr DirectedRelation) Other() int {
+	if dr.Direction == RelationIncoming {
+		return dr.OwnerID
+	}
+
+	return dr.TargetID
+}
+
+// All returns every Relation of the entity associated with entID in the
+// Campaign associated with campID in a single list, with the outgoing
+// Relations owned by the entity first and the incoming Relations listed by
+// Incoming after them, each marked with its direction. A Relation listed by
+// both, such as a Relation of the entity with itself, is included once as
+// outgoing. Unlike Index, All follows every page of both lists, each a
+// separate request subject to the rate limit of the Client.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Relations that were decoded.
+func (rs *RelationService) All(campID int, entID int) ([]DirectedRelation, error) {
+	out, err := rs.IndexAll(campID, entID, nil)
+	var recErrs RecordErrors
+	if err != nil && !errors.As(err, &recErrs) {
+		return nil, err
+	}
+
+	in, inErr := rs.Incoming(campID, entID, nil)
+	if inErr != nil && !errors.As(inErr, &recErrs) {
+		return nil, inErr
+	}
+	if err == nil {
+		err = inErr
+	}
+
+	seen := make(map[int]bool, len(out))
+	all := make([]DirectedRelation, 0, len(out)+len(in))
+	for _, rel := range out {
+		seen[rel.ID] = true
+		all = append(all, DirectedRelation{Relation: rel, Direction: RelationOutgoing})
+	}
+	for _, rel := range in {
+		if seen[rel.ID] {
+			continue
+		}
+		seen[rel.ID] = true
+		all = append(all, DirectedRelation{Relation: rel, Direction: RelationIncoming})
+	}
+
+	return all, err
+}
+
+// IndexAll returns the list of all Relations for the entity associated with
+// entID in the Campaign associated with campID from every page of the list.
+// Each page is a separate request subject to the rate limit of the Client.
+// If a non-nil time is provided, IndexAll will only return Relations that
+// have been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Relations that were decoded.
+func (rs *RelationService) IndexAll(campID int, entID int, sync *time.Time) ([]*Relation, error) {
+	return entityIndexAll[Relation](rs.client, campID, entID, rs.end, "Relation", sync)
 }
 
 // Get returns the Relation associated with relID for the entity associated
@@ -119,9 +341,7 @@ func (rs *RelationService) Get(campID int, entID int, relID int) (*Relation, err
 		return nil, fmt.Errorf("invalid Relation ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *Relation `json:"data"`
-	}
+	var wrap response[*Relation]
 
 	if err = rs.client.get(end, &wrap); err != nil {
 		return nil, fmt.Errorf("cannot get Relation (ID: %d) from Campaign (ID: %d): %w", relID, campID, err)
@@ -152,9 +372,7 @@ func (rs *RelationService) Create(campID int, entID int, rel SimpleRelation) (*R
 		return nil, fmt.Errorf("cannot marshal SimpleRelation (Relation: %s): %w", rel.Relation, err)
 	}
 
-	var wrap struct {
-		Data *Relation `json:"data"`
-	}
+	var wrap response[*Relation]
 
 	if err = rs.client.post(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot create Relation (Relation: %s) for Campaign (ID: %d): %w", rel.Relation, campID, err)
@@ -190,9 +408,7 @@ func (rs *RelationService) Update(campID int, entID int, relID int, rel SimpleRe
 		return nil, fmt.Errorf("cannot marshal SimpleRelation (Relation: %s): %w", rel.Relation, err)
 	}
 
-	var wrap struct {
-		Data *Relation `json:"data"`
-	}
+	var wrap response[*Relation]
 
 	if err = rs.client.put(end, bytes.NewReader(b), &wrap); err != nil {
 		return nil, fmt.Errorf("cannot update Relation (Relation: %s) for Campaign (ID: %d): '%w'", rel.Relation, campID, err)
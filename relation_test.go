@@ -1,7 +1,9 @@
 package kanka
 
 import (
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
@@ -10,10 +12,11 @@ import (
 )
 
 const (
-	testRelationIndex  string = "test_data/relation_index.json"
-	testRelationGet    string = "test_data/relation_get.json"
-	testRelationCreate string = "test_data/relation_create.json"
-	testRelationUpdate string = "test_data/relation_update.json"
+	testRelationIndex    string = "test_data/relation_index.json"
+	testRelationGet      string = "test_data/relation_get.json"
+	testRelationCreate   string = "test_data/relation_create.json"
+	testRelationUpdate   string = "test_data/relation_update.json"
+	testRelationIncoming string = "test_data/relation_incoming.json"
 )
 
 func TestRelationService_Index(t *testing.T) {
@@ -706,3 +709,315 @@ func TestRelationService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestRelations_Sorted(t *testing.T) {
+	t1 := time.Date(2019, time.November, 4, 11, 0, 0, 0, time.UTC)
+	t2 := t1.Add(time.Hour)
+	t3 := t2.Add(time.Hour)
+
+	friend := Relation{SimpleRelation: SimpleRelation{Relation: "Friend", Attitude: 80}, ID: 1, CreatedAt: t3}
+	rival := Relation{SimpleRelation: SimpleRelation{Relation: "Rival", Attitude: -40}, ID: 2, CreatedAt: t1}
+	ally := Relation{SimpleRelation: SimpleRelation{Relation: "Ally", Attitude: 80}, ID: 3, CreatedAt: t2}
+	rels := Relations{Data: []Relation{rival, friend, ally}}
+
+	tests := []struct {
+		name string
+		rels Relations
+		by   RelationOrder
+		want []Relation
+	}{
+		{
+			name: "By attitude",
+			rels: rels,
+			by:   ByAttitude,
+			want: []Relation{friend, ally, rival},
+		},
+		{
+			name: "By creation",
+			rels: rels,
+			by:   ByCreation,
+			want: []Relation{rival, ally, friend},
+		},
+		{
+			name: "Empty relations",
+			rels: Relations{},
+			by:   ByAttitude,
+			want: []Relation{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.rels.Sorted(test.by)
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if diff := cmp.Diff(rels.Data, []Relation{rival, friend, ally}); diff != "" {
+		t.Errorf("original relations modified (-want +got):\n%s", diff)
+	}
+}
+
+func TestAttitudeLabel(t *testing.T) {
+	tests := []struct {
+		name string
+		att  int
+		want string
+	}{
+		{name: "Minimum attitude", att: AttitudeMin, want: AttitudeHostile},
+		{name: "Hostile attitude", att: -34, want: AttitudeHostile},
+		{name: "Lower neutral attitude", att: -33, want: AttitudeNeutral},
+		{name: "Zero attitude", att: 0, want: AttitudeNeutral},
+		{name: "Upper neutral attitude", att: 33, want: AttitudeNeutral},
+		{name: "Friendly attitude", att: 34, want: AttitudeFriendly},
+		{name: "Maximum attitude", att: AttitudeMax, want: AttitudeFriendly},
+		{name: "Attitude below range", att: -101, want: ""},
+		{name: "Attitude above range", att: 101, want: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := AttitudeLabel(test.att); got != test.want {
+				t.Errorf("got: <%s>, want: <%s>", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRelationService_Incoming(t *testing.T) {
+	rels := []*Relation{
+		{
+			SimpleRelation: SimpleRelation{
+				Relation: "Mentor",
+				OwnerID:  111,
+				TargetID: 430214,
+				Attitude: 60,
+			},
+			ID: 1,
+		},
+		{
+			SimpleRelation: SimpleRelation{
+				Relation: "Rival",
+				OwnerID:  333,
+				TargetID: 430214,
+				Attitude: -40,
+			},
+			ID: 3,
+		},
+	}
+
+	type args struct {
+		campID int
+		entID  int
+		sync   *time.Time
+	}
+	tests := []struct {
+		name    string
+		status  int
+		file    string
+		args    args
+		want    []*Relation
+		wantErr bool
+	}{
+		{
+			name:    "StatusOK, valid response, valid args",
+			status:  http.StatusOK,
+			file:    testRelationIncoming,
+			args:    args{campID: 5272, entID: 430214, sync: nil},
+			want:    rels,
+			wantErr: false,
+		},
+		{
+			name:    "StatusOK, valid response, no incoming relations",
+			status:  http.StatusOK,
+			file:    testRelationIncoming,
+			args:    args{campID: 5272, entID: 999, sync: nil},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "Status OK, valid response, invalid campID",
+			status:  http.StatusOK,
+			file:    testRelationIncoming,
+			args:    args{campID: -123, entID: 430214, sync: nil},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, valid response, invalid entID",
+			status:  http.StatusOK,
+			file:    testRelationIncoming,
+			args:    args{campID: 5272, entID: -123, sync: nil},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Status OK, empty response, valid args",
+			status:  http.StatusOK,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 430214, sync: nil},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "StatusNotFound, valid args",
+			status:  http.StatusNotFound,
+			file:    testFileEmpty,
+			args:    args{campID: 5272, entID: 430214, sync: nil},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			f, err := os.Open(test.file)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer f.Close()
+
+			c, _ := testClient(test.status, f)
+
+			got, err := c.Relations.Incoming(test.args.campID, test.args.entID, test.args.sync)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestRelationService_All(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/campaigns/5272/entities/1/relations":
+			w.Write([]byte(`{"data":[
+				{"id":10,"relation":"Brother","owner_id":1,"target_id":2},
+				{"id":11,"relation":"Self","owner_id":1,"target_id":1}
+			]}`))
+		case "/campaigns/5272/relations":
+			w.Write([]byte(`{"data":[
+				{"id":10,"relation":"Brother","owner_id":1,"target_id":2},
+				{"id":11,"relation":"Self","owner_id":1,"target_id":1},
+				{"id":12,"relation":"Rival","owner_id":3,"target_id":1},
+				{"id":13,"relation":"Friend","owner_id":3,"target_id":4}
+			]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	rels, err := c.Relations.All(5272, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type summary struct {
+		ID        int
+		Direction RelationDirection
+		Other     int
+	}
+	var got []summary
+	for _, rel := range rels {
+		got = append(got, summary{ID: rel.ID, Direction: rel.Direction, Other: rel.Other()})
+	}
+
+	want := []summary{
+		{ID: 10, Direction: RelationOutgoing, Other: 2},
+		{ID: 11, Direction: RelationOutgoing, Other: 1},
+		{ID: 12, Direction: RelationIncoming, Other: 3},
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestRelationService_AllError(t *testing.T) {
+	c, ts, _ := testRecordClient(t, http.StatusUnauthorized, testFileEmpty)
+	defer ts.Close()
+
+	if _, err := c.Relations.All(5272, 1); err == nil {
+		t.Error("got nil error, want error")
+	}
+}
+
+func TestRelationService_IncomingPages(t *testing.T) {
+	var pages int
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		pages++
+
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"data":[{"id":3,"relation":"Rival","owner_id":333,"target_id":430214}],"links":{"next":null}}`))
+			return
+		}
+		fmt.Fprintf(w, `{"data":[{"id":2,"relation":"Apprentice","owner_id":430214,"target_id":111}],"links":{"next":"%s/campaigns/5272/relations?page=2"}}`, ts.URL)
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	rels, err := c.Relations.Incoming(5272, 430214, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int
+	for _, rel := range rels {
+		got = append(got, rel.ID)
+	}
+	if diff := cmp.Diff(got, []int{3}); diff != "" {
+		t.Errorf(diff)
+	}
+	if pages != 2 {
+		t.Errorf("got pages: <%d>, want pages: <2>", pages)
+	}
+}
+
+func TestRelationService_AllPages(t *testing.T) {
+	var ts *httptest.Server
+	ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page2 := r.URL.Query().Get("page") == "2"
+
+		switch r.URL.Path {
+		case "/campaigns/5272/entities/1/relations":
+			if page2 {
+				w.Write([]byte(`{"data":[{"id":11,"relation":"Sister","owner_id":1,"target_id":3}],"links":{"next":null}}`))
+				return
+			}
+			fmt.Fprintf(w, `{"data":[{"id":10,"relation":"Brother","owner_id":1,"target_id":2}],"links":{"next":"%s/campaigns/5272/entities/1/relations?page=2"}}`, ts.URL)
+		case "/campaigns/5272/relations":
+			if page2 {
+				w.Write([]byte(`{"data":[{"id":13,"relation":"Rival","owner_id":4,"target_id":1}],"links":{"next":null}}`))
+				return
+			}
+			fmt.Fprintf(w, `{"data":[{"id":12,"relation":"Friend","owner_id":3,"target_id":4}],"links":{"next":"%s/campaigns/5272/relations?page=2"}}`, ts.URL)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	rels, err := c.Relations.All(5272, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []int
+	for _, rel := range rels {
+		got = append(got, rel.ID)
+	}
+	if diff := cmp.Diff(got, []int{10, 11, 13}); diff != "" {
+		t.Errorf(diff)
+	}
+}
@@ -0,0 +1,65 @@
+package kanka
+
+import (
+	"net/http"
+	"time"
+)
+
+// response is the envelope Kanka wraps the data of every response in. The
+// type parameter is the type the data is decoded into, such as *Character or
+// []json.RawMessage. Links and Meta are only present in paginated responses.
+type response[T any] struct {
+	Data  T         `json:"data"`
+	Links *Links    `json:"links"`
+	Meta  *Meta     `json:"meta"`
+	Sync  time.Time `json:"sync"`
+
+	result WriteResult
+}
+
+// record stores the metadata of the provided response in the envelope.
+func (r *response[T]) record(resp *http.Response) {
+	r.result = WriteResult{
+		StatusCode: resp.StatusCode,
+		Location:   resp.Header.Get("Location"),
+	}
+}
+
+// recorder is implemented by results that keep the metadata of the response
+// they are decoded from.
+type recorder interface {
+	record(resp *http.Response)
+}
+
+// WriteResult contains the metadata of the response to a write request that
+// is otherwise discarded, such as whether Kanka returned 200 OK or
+// 201 Created.
+type WriteResult struct {
+	// StatusCode is the HTTP status code of the response.
+	StatusCode int
+	// Location is the canonical URL of the written object as reported by the
+	// Location header of the response. Location is empty if Kanka did not
+	// return the header.
+	Location string
+	// Warnings are the problems found with a successful write that did not
+	// prevent it, such as a likely duplicate. Kanka itself does not report
+	// warnings; they are found by the Client when asked to, such as with the
+	// CheckDuplicates CreateOption.
+	Warnings []Warning
+}
+
+// WarningDuplicateName is the Code of the Warning reported when an object of
+// the same type with the same name already existed before an object was
+// created.
+const WarningDuplicateName string = "duplicate_name"
+
+// Warning describes a problem with a successful write that did not prevent
+// the write.
+type Warning struct {
+	// Code identifies the kind of the warning, such as WarningDuplicateName.
+	Code string
+	// Message describes the warning.
+	Message string
+	// IDs are the IDs of the existing objects the warning is about, if any.
+	IDs []int
+}
@@ -0,0 +1,118 @@
+package kanka
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy reports whether a request that failed with the provided
+// response or error should be retried. The response is nil if the request
+// could not be sent, in which case the error describes why.
+type RetryPolicy func(resp *http.Response, err error) bool
+
+// DefaultRetryPolicy retries responses with the status 429 Too Many Requests
+// or any 5xx status, such as 503 Service Unavailable during maintenance.
+// Requests that could not be sent are not retried.
+func DefaultRetryPolicy(resp *http.Response, err error) bool {
+	if resp == nil {
+		return false
+	}
+
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+}
+
+// do sends the provided request, waiting for the rate limit of the Client
+// before each attempt, and retries it as configured by WithRetry. POST
+// requests are sent only once because Kanka does not support idempotent
+// creation. The response of the last attempt is returned.
+func (c *Client) do(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if c.limiter != nil {
+			if err := c.limiter.Wait(req.Context()); err != nil {
+				return nil, fmt.Errorf("cannot wait for rate limit to send request with method '%s' to url '%s': %w", req.Method, req.URL.String(), err)
+			}
+		}
+
+		resp, err := c.http.Do(req)
+		if !c.retry(req, attempt, resp, err) {
+			if err != nil {
+				return nil, fmt.Errorf("http client cannot send request with method '%s' to url '%s': %w", req.Method, req.URL.String(), err)
+			}
+			return resp, nil
+		}
+
+		wait := backoff(c.retryBase, attempt, resp)
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		t := time.NewTimer(wait)
+		select {
+		case <-t.C:
+		case <-req.Context().Done():
+			t.Stop()
+			return nil, fmt.Errorf("cannot retry request with method '%s' to url '%s': %w", req.Method, req.URL.String(), req.Context().Err())
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, fmt.Errorf("cannot reset body to retry request with method '%s' to url '%s': %w", req.Method, req.URL.String(), err)
+			}
+			req.Body = body
+		}
+	}
+}
+
+// retry reports whether the provided attempt at sending the provided request,
+// which ended with the provided response or error, should be retried.
+func (c *Client) retry(req *http.Request, attempt int, resp *http.Response, err error) bool {
+	if attempt >= c.retries || req.Method == http.MethodPost {
+		return false
+	}
+	if resp != nil && resp.StatusCode < 400 {
+		return false
+	}
+	if req.Body != nil && req.GetBody == nil {
+		return false
+	}
+
+	policy := c.retryPolicy
+	if policy == nil {
+		policy = DefaultRetryPolicy
+	}
+
+	return policy(resp, err)
+}
+
+// backoff returns how long to wait before retrying after the provided
+// attempt, counted from 0. The wait doubles with each attempt starting from
+// the provided base and is randomized to between half and all of that so that
+// many clients failing at once do not retry in lockstep. A longer wait asked
+// for by the Retry-After header of the provided response, if any, is used
+// instead.
+func backoff(base time.Duration, attempt int, resp *http.Response) time.Duration {
+	d := base << uint(attempt)
+	if d <= 0 {
+		d = base
+	}
+	if half := int64(d / 2); half > 0 {
+		d = time.Duration(half + rand.Int63n(half+1))
+	}
+
+	if resp != nil {
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+			if after := time.Duration(secs) * time.Second; after > d {
+				d = after
+			}
+		}
+	}
+
+	return d
+}
@@ -0,0 +1,179 @@
+package kanka
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestClient_Retry(t *testing.T) {
+	tests := []struct {
+		name      string
+		method    string
+		opts      []Option
+		statuses  []int
+		wantCalls int
+		wantErr   bool
+	}{
+		{
+			name:      "No retries",
+			method:    http.MethodGet,
+			opts:      nil,
+			statuses:  []int{http.StatusTooManyRequests, http.StatusOK},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "Retried until success",
+			method:    http.MethodGet,
+			opts:      []Option{WithRetry(3, time.Millisecond)},
+			statuses:  []int{http.StatusTooManyRequests, http.StatusBadGateway, http.StatusOK},
+			wantCalls: 3,
+			wantErr:   false,
+		},
+		{
+			name:      "Retries exhausted",
+			method:    http.MethodGet,
+			opts:      []Option{WithRetry(2, time.Millisecond)},
+			statuses:  []int{http.StatusInternalServerError},
+			wantCalls: 3,
+			wantErr:   true,
+		},
+		{
+			name:      "Not retryable",
+			method:    http.MethodGet,
+			opts:      []Option{WithRetry(3, time.Millisecond)},
+			statuses:  []int{http.StatusNotFound, http.StatusOK},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "POST never retried",
+			method:    http.MethodPost,
+			opts:      []Option{WithRetry(3, time.Millisecond)},
+			statuses:  []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+		{
+			name:      "PUT retried",
+			method:    http.MethodPut,
+			opts:      []Option{WithRetry(3, time.Millisecond)},
+			statuses:  []int{http.StatusServiceUnavailable, http.StatusOK},
+			wantCalls: 2,
+			wantErr:   false,
+		},
+		{
+			name:   "Custom policy",
+			method: http.MethodGet,
+			opts: []Option{
+				WithRetry(3, time.Millisecond),
+				WithRetryPolicy(func(resp *http.Response, err error) bool {
+					return resp != nil && resp.StatusCode == http.StatusNotFound
+				}),
+			},
+			statuses:  []int{http.StatusNotFound, http.StatusOK},
+			wantCalls: 2,
+			wantErr:   false,
+		},
+		{
+			name:   "Custom policy refusing 5xx",
+			method: http.MethodGet,
+			opts: []Option{
+				WithRetry(3, time.Millisecond),
+				WithRetryPolicy(func(resp *http.Response, err error) bool { return false }),
+			},
+			statuses:  []int{http.StatusInternalServerError, http.StatusOK},
+			wantCalls: 1,
+			wantErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var calls int
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				b, err := ioutil.ReadAll(r.Body)
+				if err != nil {
+					t.Error(err)
+				}
+				if r.Method == http.MethodPut && string(b) != `{"name":"Jon Snow"}` {
+					t.Errorf("got body: <%s>, want body: <%s>", b, `{"name":"Jon Snow"}`)
+				}
+
+				status := test.statuses[len(test.statuses)-1]
+				if calls < len(test.statuses) {
+					status = test.statuses[calls]
+				}
+				calls++
+
+				w.WriteHeader(status)
+				w.Write([]byte(`{"data":{"id":111,"name":"Jon Snow"}}`))
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client(), test.opts...)
+			c.rootURL = ts.URL + "/"
+
+			var err error
+			switch test.method {
+			case http.MethodGet:
+				_, err = c.Characters.Get(5272, 111)
+			case http.MethodPost:
+				_, err = c.Characters.Create(5272, SimpleCharacter{Name: "Jon Snow"})
+			case http.MethodPut:
+				_, err = c.Characters.Update(5272, 111, SimpleCharacter{Name: "Jon Snow"})
+			}
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if calls != test.wantCalls {
+				t.Errorf("got calls: <%d>, want calls: <%d>", calls, test.wantCalls)
+			}
+		})
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	base := 8 * time.Millisecond
+
+	for attempt := 0; attempt < 5; attempt++ {
+		max := base << uint(attempt)
+		for i := 0; i < 50; i++ {
+			d := backoff(base, attempt, nil)
+			if d < max/2 || d > max {
+				t.Fatalf("got backoff: <%v> for attempt %d, want between <%v> and <%v>", d, attempt, max/2, max)
+			}
+		}
+	}
+
+	resp := &http.Response{Header: http.Header{"Retry-After": []string{"2"}}}
+	if d := backoff(base, 0, resp); d != 2*time.Second {
+		t.Errorf("got backoff: <%v>, want Retry-After: <%v>", d, 2*time.Second)
+	}
+}
+
+func TestDefaultRetryPolicy(t *testing.T) {
+	tests := []struct {
+		status int
+		want   bool
+	}{
+		{status: http.StatusTooManyRequests, want: true},
+		{status: http.StatusInternalServerError, want: true},
+		{status: http.StatusServiceUnavailable, want: true},
+		{status: http.StatusNotFound, want: false},
+		{status: http.StatusUnprocessableEntity, want: false},
+	}
+	for _, test := range tests {
+		got := DefaultRetryPolicy(&http.Response{StatusCode: test.status, Body: ioutil.NopCloser(bytes.NewReader(nil))}, nil)
+		if got != test.want {
+			t.Errorf("got retry?: <%t> for status %d, want retry?: <%t>", got, test.status, test.want)
+		}
+	}
+
+	if DefaultRetryPolicy(nil, http.ErrHandlerTimeout) {
+		t.Error("got retry?: <true> for unsent request, want retry?: <false>")
+	}
+}
@@ -1,12 +1,23 @@
 package kanka
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"time"
 
 	"github.com/Henry-Sarabia/blank"
 )
 
+// ErrNotFound is matched by errors returned when no object matches a lookup
+// by name.
+var ErrNotFound = errors.New("no matching object found")
+
+// ErrAmbiguous is matched by errors returned when more than one object
+// matches a lookup by name.
+var ErrAmbiguous = errors.New("more than one matching object found")
+
 // Result contains the response to a search query.
 // For more information, visit: https://kanka.io/en-US/docs/1.0/search
 type Result struct {
@@ -34,7 +45,28 @@ type Results struct {
 	Sync time.Time `json:"sync"`
 }
 
+// UnmarshalJSON unmarshals the JSON-encoded data into the Results. A null value
+// or a null list is unmarshaled into an empty list rather than a nil one.
+func (rs *Results) UnmarshalJSON(b []byte) error {
+	type alias Results
+	var al alias
+
+	if !isNull(b) {
+		if err := json.Unmarshal(b, &al); err != nil {
+			return err
+		}
+	}
+
+	if al.Data == nil {
+		al.Data = []*Result{}
+	}
+
+	*rs = Results(al)
+	return nil
+}
+
 // Search searches the Campaign associated with campID for the provided query.
+// The query is escaped, so it may contain any character, such as ? or %.
 func (c *Client) Search(campID int, qry string, sync *time.Time) ([]*Result, error) {
 	if blank.Is(qry) {
 		return nil, fmt.Errorf("invalid search query")
@@ -45,7 +77,7 @@ func (c *Client) Search(campID int, qry string, sync *time.Time) ([]*Result, err
 		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(EndpointSearch)
-	end = end.append("/" + qry)
+	end = end.append("/" + url.PathEscape(qry))
 
 	if sync != nil {
 		end = end.sync(*sync)
@@ -59,3 +91,38 @@ func (c *Client) Search(campID int, qry string, sync *time.Time) ([]*Result, err
 
 	return wrap.Data, nil
 }
+
+// SearchAll searches the Campaign associated with campID for the provided
+// query and returns the results from every page, following the link to the
+// next page returned by Kanka until the last page is reached. Each page is a
+// separate request subject to the rate limit and timeout of the Client.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Results that were decoded.
+func (c *Client) SearchAll(campID int, qry string, sync *time.Time) ([]*Result, error) {
+	if blank.Is(qry) {
+		return nil, fmt.Errorf("invalid search query")
+	}
+
+	end, err := EndpointCampaign.id(campID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+	end = end.concat(EndpointSearch)
+	end = end.append("/" + url.PathEscape(qry))
+
+	if sync != nil {
+		end = end.sync(*sync)
+	}
+
+	raws, err := c.indexAll(end)
+	if err != nil {
+		return nil, fmt.Errorf("cannot get every page of Search results from Campaign (ID: %d): %w", campID, err)
+	}
+
+	var list []*Result
+	if err = decodeList(raws, &list, c.strict); err != nil {
+		return list, fmt.Errorf("cannot decode Search results from Campaign (ID: %d): %w", campID, err)
+	}
+
+	return list, nil
+}
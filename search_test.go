@@ -1,7 +1,9 @@
 package kanka
 
 import (
+	"fmt"
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
@@ -168,3 +170,69 @@ func TestClient_Search(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_SearchAll(t *testing.T) {
+	tests := []struct {
+		name      string
+		qry       string
+		want      []string
+		wantPages []string
+		wantErr   bool
+	}{
+		{
+			name: "Every page",
+			qry:  "Penny",
+			want: []string{"Page 1", "Page 2"},
+			wantPages: []string{
+				"/campaigns/5272/search/Penny?related=1",
+				"/campaigns/5272/search/Penny?page=2&related=1",
+			},
+			wantErr: false,
+		},
+		{
+			name:      "Blank query",
+			qry:       " ",
+			want:      nil,
+			wantPages: nil,
+			wantErr:   true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var pages []string
+			var ts *httptest.Server
+			ts = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				pages = append(pages, r.URL.String())
+
+				next := `"` + ts.URL + `/campaigns/5272/search/Penny?page=2"`
+				name := "Page 1"
+				if page := r.URL.Query().Get(paramPage); page != "" {
+					next = "null"
+					name = "Page " + page
+				}
+				fmt.Fprintf(w, `{"data":[{"name":"%s"}],"links":{"next":%s}}`, name, next)
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			res, err := c.SearchAll(5272, test.qry, nil)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			var got []string
+			for _, r := range res {
+				got = append(got, r.Name)
+			}
+
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+			if diff := cmp.Diff(pages, test.wantPages); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
@@ -1,27 +1,182 @@
 package kanka
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 )
 
+// ErrFeatureUnavailable is matched by errors returned when a feature requires
+// a boosted Campaign and the Campaign is not boosted. Use errors.Is to check
+// for it and skip the feature instead of treating it as a hard failure.
+var ErrFeatureUnavailable = errors.New("feature requires a boosted campaign")
+
+// ErrMaintenance is matched by the MaintenanceError returned when Kanka is
+// down for maintenance. Use errors.Is to check for it.
+var ErrMaintenance = errors.New("kanka is down for maintenance")
+
+// MaintenanceError is returned when Kanka responds with 503 Service
+// Unavailable while it is down for maintenance. The body of such a response
+// is an HTML page, so it is never decoded.
+type MaintenanceError struct {
+	// RetryAfter is the time Kanka asked to wait before retrying, as
+	// reported by the Retry-After header of the response. RetryAfter is 0 if
+	// Kanka did not send the header.
+	RetryAfter time.Duration
+}
+
+// newMaintenanceError returns a MaintenanceError describing the provided
+// 503 Service Unavailable response.
+func newMaintenanceError(resp *http.Response) *MaintenanceError {
+	var wait time.Duration
+	if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && secs > 0 {
+		wait = time.Duration(secs) * time.Second
+	}
+
+	return &MaintenanceError{RetryAfter: wait}
+}
+
+// Error returns the message of the error.
+func (e *MaintenanceError) Error() string {
+	if e.RetryAfter > 0 {
+		return fmt.Sprintf("%s, retry after %v", ErrMaintenance, e.RetryAfter)
+	}
+
+	return ErrMaintenance.Error()
+}
+
+// Is returns true if the target is ErrMaintenance.
+func (e *MaintenanceError) Is(target error) bool {
+	return target == ErrMaintenance
+}
+
+// Temporary returns true because maintenance is always temporary.
+func (e *MaintenanceError) Temporary() bool {
+	return true
+}
+
+// APIError is returned when an operation on the objects of a Campaign, such
+// as getting a Character, fails. APIError keeps the operation and the IDs it
+// was about as fields so that failures can be aggregated without parsing the
+// message of the error. Use errors.As to retrieve an APIError and errors.Is
+// or errors.As to inspect the underlying error, such as ErrNotFound.
+type APIError struct {
+	// Op is the failed operation, such as "index", "get", "create",
+	// "update", "clear", "header", "children", "search", or "delete".
+	Op string
+	// Kind is the type of the object the operation was about, such as
+	// "Character".
+	Kind string
+	// CampaignID is the ID of the Campaign the operation was about.
+	CampaignID int
+	// ID is the ID of the object the operation was about, or 0 if the
+	// operation was not about a single existing object, such as "index" or
+	// "create".
+	ID int
+	// Err is the underlying error.
+	Err error
+
+	msg string
+}
+
+// Error returns the message of the error followed by the underlying error.
+func (e *APIError) Error() string {
+	if e.Err == nil {
+		return e.msg
+	}
+
+	return e.msg + ": " + e.Err.Error()
+}
+
+// Unwrap returns the underlying error.
+func (e *APIError) Unwrap() error {
+	return e.Err
+}
+
+// StatusCode returns the HTTP status code of the unsuccessful response Kanka
+// sent, or 0 if the error was not caused by an unsuccessful response.
+func (e *APIError) StatusCode() int {
+	var se *serverError
+	if errors.As(e.Err, &se) {
+		return se.code
+	}
+
+	var me *MaintenanceError
+	if errors.As(e.Err, &me) {
+		return http.StatusServiceUnavailable
+	}
+
+	return 0
+}
+
+// Temporary returns true if the underlying error is temporary.
+func (e *APIError) Temporary() bool {
+	var t interface{ Temporary() bool }
+	return errors.As(e.Err, &t) && t.Temporary()
+}
+
+// maxErrorBody is the maximum number of bytes read from an error response.
+const maxErrorBody = 1 << 16
+
 // serverError represents an error originating from another server.
 type serverError struct {
-	code      int
-	status    string
-	temporary bool
+	code        int
+	status      string
+	message     string
+	temporary   bool
+	unavailable bool
+}
+
+// newServerError returns a serverError describing the provided unsuccessful
+// response. The response body is read for the message Kanka sends along with
+// the error, if any.
+func newServerError(resp *http.Response) *serverError {
+	var body struct {
+		Message string `json:"message"`
+	}
+	json.NewDecoder(io.LimitReader(resp.Body, maxErrorBody)).Decode(&body)
+
+	return &serverError{
+		code:        resp.StatusCode,
+		status:      resp.Status,
+		message:     body.Message,
+		temporary:   isTemporary(resp.StatusCode),
+		unavailable: isUnavailable(resp.StatusCode, body.Message),
+	}
 }
 
 // Error returns the status message of an error.
 func (e *serverError) Error() string {
+	if e.message != "" {
+		return fmt.Sprintf("server responded with status '%s': %s", e.status, e.message)
+	}
+
 	return fmt.Sprintf("server responded with status '%s'", e.status)
 }
 
+// Is returns true if the target is ErrFeatureUnavailable and the error was
+// caused by a feature that requires a boosted Campaign.
+func (e *serverError) Is(target error) bool {
+	return target == ErrFeatureUnavailable && e.unavailable
+}
+
 // Temporary returns true if the error is temporary.
 func (e *serverError) Temporary() bool {
 	return e.temporary
 }
 
+// isNotFound returns true if the provided error was caused by a 404 Not Found
+// response from Kanka.
+func isNotFound(err error) bool {
+	var se *serverError
+	return errors.As(err, &se) && se.code == http.StatusNotFound
+}
+
 // isSuccess returns true if the provided status code is of the 200 type.
 func isSuccess(code int) bool {
 	if code >= 200 && code < 300 {
@@ -44,3 +199,13 @@ func isTemporary(code int) bool {
 		return false
 	}
 }
+
+// isUnavailable returns true if the provided status code and message
+// represent a feature that is unavailable because the Campaign is not boosted.
+func isUnavailable(code int, msg string) bool {
+	if code != http.StatusForbidden && code != http.StatusPaymentRequired {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(msg), "boost")
+}
@@ -0,0 +1,225 @@
+package kanka
+
+import (
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewServerError(t *testing.T) {
+	tests := []struct {
+		name            string
+		status          int
+		body            string
+		wantMsg         string
+		wantTemporary   bool
+		wantUnavailable bool
+	}{
+		{
+			name:            "Forbidden, boosted feature",
+			status:          http.StatusForbidden,
+			body:            `{"message":"This feature requires the campaign to be boosted."}`,
+			wantMsg:         "server responded with status '403 Forbidden': This feature requires the campaign to be boosted.",
+			wantTemporary:   false,
+			wantUnavailable: true,
+		},
+		{
+			name:            "Forbidden, missing permission",
+			status:          http.StatusForbidden,
+			body:            `{"message":"This action is unauthorized."}`,
+			wantMsg:         "server responded with status '403 Forbidden': This action is unauthorized.",
+			wantTemporary:   false,
+			wantUnavailable: false,
+		},
+		{
+			name:            "Not Found, boost in message",
+			status:          http.StatusNotFound,
+			body:            `{"message":"Boosted campaign not found."}`,
+			wantMsg:         "server responded with status '404 Not Found': Boosted campaign not found.",
+			wantTemporary:   false,
+			wantUnavailable: false,
+		},
+		{
+			name:            "Too Many Requests, empty body",
+			status:          http.StatusTooManyRequests,
+			body:            "",
+			wantMsg:         "server responded with status '429 Too Many Requests'",
+			wantTemporary:   true,
+			wantUnavailable: false,
+		},
+		{
+			name:            "Internal Server Error, non-JSON body",
+			status:          http.StatusInternalServerError,
+			body:            "<html>error</html>",
+			wantMsg:         "server responded with status '500 Internal Server Error'",
+			wantTemporary:   false,
+			wantUnavailable: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			resp := &http.Response{
+				StatusCode: test.status,
+				Status:     fmt.Sprintf("%d %s", test.status, http.StatusText(test.status)),
+				Body:       ioutil.NopCloser(strings.NewReader(test.body)),
+			}
+
+			err := newServerError(resp)
+			if err.Error() != test.wantMsg {
+				t.Errorf("got message: <%s>, want message: <%s>", err.Error(), test.wantMsg)
+			}
+			if err.Temporary() != test.wantTemporary {
+				t.Errorf("got temporary: <%t>, want temporary: <%t>", err.Temporary(), test.wantTemporary)
+			}
+
+			wrapped := fmt.Errorf("cannot get Map: %w", err)
+			if got := errors.Is(wrapped, ErrFeatureUnavailable); got != test.wantUnavailable {
+				t.Errorf("got unavailable: <%t>, want unavailable: <%t>", got, test.wantUnavailable)
+			}
+		})
+	}
+}
+
+func TestClient_maintenance(t *testing.T) {
+	tests := []struct {
+		name           string
+		retryAfter     string
+		wantRetryAfter time.Duration
+	}{
+		{
+			name:           "Retry-After header",
+			retryAfter:     "120",
+			wantRetryAfter: 2 * time.Minute,
+		},
+		{
+			name:           "No Retry-After header",
+			retryAfter:     "",
+			wantRetryAfter: 0,
+		},
+		{
+			name:           "Invalid Retry-After header",
+			retryAfter:     "soon",
+			wantRetryAfter: 0,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if test.retryAfter != "" {
+					w.Header().Set("Retry-After", test.retryAfter)
+				}
+				w.Header().Set("Content-Type", "text/html")
+				w.WriteHeader(http.StatusServiceUnavailable)
+				w.Write([]byte("<html><body>Down for maintenance</body></html>"))
+			}))
+			defer ts.Close()
+
+			c := NewClient(testToken, ts.Client())
+			c.rootURL = ts.URL + "/"
+
+			_, err := c.Profiles.Get()
+			if !errors.Is(err, ErrMaintenance) {
+				t.Fatalf("got err: <%v>, want err: <%v>", err, ErrMaintenance)
+			}
+
+			var me *MaintenanceError
+			if !errors.As(err, &me) {
+				t.Fatalf("got err: <%T>, want err: <%T>", err, me)
+			}
+			if me.RetryAfter != test.wantRetryAfter {
+				t.Errorf("got RetryAfter: <%v>, want RetryAfter: <%v>", me.RetryAfter, test.wantRetryAfter)
+			}
+			if !me.Temporary() {
+				t.Errorf("got Temporary: <false>, want Temporary: <true>")
+			}
+		})
+	}
+}
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		call          func(c *Client) error
+		wantOp        string
+		wantID        int
+		wantStatus    int
+		wantTemporary bool
+		wantMsg       string
+	}{
+		{
+			name:   "Get not found",
+			status: http.StatusNotFound,
+			call: func(c *Client) error {
+				_, err := c.Characters.Get(5272, 111)
+				return err
+			},
+			wantOp:        "get",
+			wantID:        111,
+			wantStatus:    http.StatusNotFound,
+			wantTemporary: false,
+			wantMsg:       "cannot get Character (ID: 111) from Campaign (ID: 5272): ",
+		},
+		{
+			name:   "Index rate limited",
+			status: http.StatusTooManyRequests,
+			call: func(c *Client) error {
+				_, err := c.Locations.Index(5272, nil)
+				return err
+			},
+			wantOp:        "index",
+			wantID:        0,
+			wantStatus:    http.StatusTooManyRequests,
+			wantTemporary: true,
+			wantMsg:       "cannot get Location Index from Campaign (ID: 5272): ",
+		},
+		{
+			name:   "Delete during maintenance",
+			status: http.StatusServiceUnavailable,
+			call: func(c *Client) error {
+				return c.Items.Delete(5272, 222)
+			},
+			wantOp:        "delete",
+			wantID:        222,
+			wantStatus:    http.StatusServiceUnavailable,
+			wantTemporary: true,
+			wantMsg:       "cannot delete Item (ID: 222) for Campaign (ID: 5272): ",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, _ := testRecordClient(t, test.status, "")
+			defer ts.Close()
+
+			err := test.call(c)
+
+			var ae *APIError
+			if !errors.As(err, &ae) {
+				t.Fatalf("got err: <%v>, want APIError", err)
+			}
+			if ae.Op != test.wantOp {
+				t.Errorf("got op: <%s>, want op: <%s>", ae.Op, test.wantOp)
+			}
+			if ae.CampaignID != 5272 {
+				t.Errorf("got Campaign ID: <%d>, want Campaign ID: <%d>", ae.CampaignID, 5272)
+			}
+			if ae.ID != test.wantID {
+				t.Errorf("got ID: <%d>, want ID: <%d>", ae.ID, test.wantID)
+			}
+			if ae.StatusCode() != test.wantStatus {
+				t.Errorf("got status: <%d>, want status: <%d>", ae.StatusCode(), test.wantStatus)
+			}
+			if ae.Temporary() != test.wantTemporary {
+				t.Errorf("got temporary: <%t>, want temporary: <%t>", ae.Temporary(), test.wantTemporary)
+			}
+			if !strings.HasPrefix(err.Error(), test.wantMsg) {
+				t.Errorf("got message: <%s>, want prefix: <%s>", err.Error(), test.wantMsg)
+			}
+		})
+	}
+}
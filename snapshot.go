@@ -0,0 +1,157 @@
+package kanka
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// indexer retrieves every object of a single type from the Campaign
+// associated with campID, such as a []*Character.
+type indexer func(c *Client, campID int, sync *time.Time) (interface{}, error)
+
+// indexers maps each Entity type to the indexer for its concrete objects.
+var indexers = map[string]indexer{
+	"character": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Characters.IndexAll(campID, sync)
+	},
+	"location": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Locations.IndexAll(campID, sync)
+	},
+	"family": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Families.IndexAll(campID, sync)
+	},
+	"organisation": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Organizations.IndexAll(campID, sync)
+	},
+	"item": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Items.IndexAll(campID, sync)
+	},
+	"note": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Notes.IndexAll(campID, sync)
+	},
+	"event": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Events.IndexAll(campID, sync)
+	},
+	"race": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Races.IndexAll(campID, sync)
+	},
+	"quest": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Quests.IndexAll(campID, sync)
+	},
+	"journal": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Journals.IndexAll(campID, sync)
+	},
+	"tag": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Tags.IndexAll(campID, sync)
+	},
+	"ability": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Abilities.IndexAll(campID, sync)
+	},
+	"conversation": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Conversations.IndexAll(campID, sync)
+	},
+	"calendar": func(c *Client, campID int, sync *time.Time) (interface{}, error) {
+		return c.Calendars.IndexAll(campID, sync)
+	},
+}
+
+// TypeErrors collects the error encountered for each type of a snapshot or
+// each kind of EntityDetails, keyed by the type. TypeErrors can be retrieved
+// from a returned error using errors.As.
+type TypeErrors map[string]error
+
+// Error returns every type error joined into a single message, ordered by
+// type.
+func (e TypeErrors) Error() string {
+	types := make([]string, 0, len(e))
+	for typ := range e {
+		types = append(types, typ)
+	}
+	sort.Strings(types)
+
+	msgs := make([]string, len(types))
+	for i, typ := range types {
+		msgs[i] = fmt.Sprintf("%s: %v", typ, e[typ])
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// SnapshotTypes retrieves every object of each of the provided Entity types,
+// such as "character" or "location", from the Campaign associated with campID.
+// The types are retrieved concurrently, sharing the rate limit of the Client.
+// The returned map holds the list of objects of each type, such as a
+// []*Character for "character".
+// If a non-nil time is provided, SnapshotTypes will only return objects that
+// have been changed since that time.
+// Types that cannot be retrieved, including unsupported types, are reported
+// in an error wrapping TypeErrors alongside the types that were retrieved. A
+// type whose records could only partly be decoded is kept in the map with the
+// records that were decoded.
+func (c *Client) SnapshotTypes(campID int, types []string, sync *time.Time) (map[string]interface{}, error) {
+	if _, err := EndpointCampaign.id(campID); err != nil {
+		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+	}
+
+	snap, errs := snapshot(types, func(idx indexer) (interface{}, error) {
+		return idx(c, campID, sync)
+	})
+
+	if len(errs) > 0 {
+		return snap, fmt.Errorf("cannot snapshot every type of Campaign (ID: %d): %w", campID, errs)
+	}
+
+	return snap, nil
+}
+
+// snapshot calls the provided fetch function concurrently with the indexer of
+// each of the provided types, retrieving duplicate types only once. The
+// fetched lists and the errors encountered are returned keyed by type.
+func snapshot(types []string, fetch func(idx indexer) (interface{}, error)) (map[string]interface{}, TypeErrors) {
+	var (
+		mu   sync.Mutex
+		wg   sync.WaitGroup
+		seen = make(map[string]bool)
+		snap = make(map[string]interface{})
+		errs = make(TypeErrors)
+	)
+
+	for _, typ := range types {
+		if seen[typ] {
+			continue
+		}
+		seen[typ] = true
+
+		idx, ok := indexers[typ]
+		if !ok {
+			mu.Lock()
+			errs[typ] = fmt.Errorf("unsupported type '%s'", typ)
+			mu.Unlock()
+			continue
+		}
+
+		wg.Add(1)
+		go func(typ string) {
+			defer wg.Done()
+
+			list, err := fetch(idx)
+			var recErrs RecordErrors
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs[typ] = err
+			}
+			if err == nil || errors.As(err, &recErrs) {
+				snap[typ] = list
+			}
+		}(typ)
+	}
+	wg.Wait()
+
+	return snap, errs
+}
@@ -0,0 +1,93 @@
+package kanka
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestClient_SnapshotTypes(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/characters"):
+			fmt.Fprint(w, `{"data":[{"id":1,"name":"Arya"},{"id":2,"name":"Bran"}],"links":{"next":null}}`)
+		case strings.HasSuffix(r.URL.Path, "/calendars"):
+			fmt.Fprint(w, `{"data":[{"id":4,"name":"Harptos"}],"links":{"next":null}}`)
+		case strings.HasSuffix(r.URL.Path, "/locations"):
+			fmt.Fprint(w, `{"data":[{"id":3,"name":"Winterfell"},{"id":"bad"}],"links":{"next":null}}`)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer ts.Close()
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	snap, err := c.SnapshotTypes(5272, []string{"character", "location", "tag", "character", "calendar", "dragon"}, nil)
+
+	var errs TypeErrors
+	if !errors.As(err, &errs) {
+		t.Fatalf("got err: <%v>, want TypeErrors", err)
+	}
+
+	var failed []string
+	for typ := range errs {
+		failed = append(failed, typ)
+	}
+	sort.Strings(failed)
+	if diff := cmp.Diff(failed, []string{"dragon", "location", "tag"}); diff != "" {
+		t.Errorf(diff)
+	}
+
+	var recErrs RecordErrors
+	if !errors.As(errs["location"], &recErrs) {
+		t.Errorf("got location err: <%v>, want RecordErrors", errs["location"])
+	}
+
+	chars, ok := snap["character"].([]*Character)
+	if !ok || len(chars) != 2 || chars[0].Name != "Arya" || chars[1].Name != "Bran" {
+		t.Errorf("got characters: <%#v>, want Arya and Bran", snap["character"])
+	}
+
+	locs, ok := snap["location"].([]*Location)
+	if !ok || len(locs) != 1 || locs[0].Name != "Winterfell" {
+		t.Errorf("got locations: <%#v>, want Winterfell", snap["location"])
+	}
+
+	cals, ok := snap["calendar"].([]*Calendar)
+	if !ok || len(cals) != 1 || cals[0].Name != "Harptos" {
+		t.Errorf("got calendars: <%#v>, want Harptos", snap["calendar"])
+	}
+
+	if _, ok := snap["tag"]; ok {
+		t.Errorf("got tags: <%#v>, want no tags", snap["tag"])
+	}
+
+	sort.Strings(paths)
+	want := []string{"/campaigns/5272/calendars", "/campaigns/5272/characters", "/campaigns/5272/locations", "/campaigns/5272/tags"}
+	if diff := cmp.Diff(paths, want); diff != "" {
+		t.Errorf(diff)
+	}
+}
+
+func TestClient_SnapshotTypes_invalidCampaign(t *testing.T) {
+	c := NewClient(testToken, nil)
+
+	if _, err := c.SnapshotTypes(-1, []string{"character"}, nil); err == nil {
+		t.Errorf("got err?: <false>, want err?: <true>")
+	}
+}
@@ -0,0 +1,33 @@
+package kanka
+
+import "time"
+
+// SyncToken records the point from which an incremental sync continues.
+// Store a SyncToken after each sync and replay it on the next one so that
+// only the records changed in between are returned.
+type SyncToken struct {
+	// Time is the time from which the next sync continues, such as the time
+	// the last successful sync was requested. Kanka has no sync cursors, so
+	// the time is all a SyncToken needs to record.
+	Time time.Time `json:"time"`
+}
+
+// Since returns the time to pass as the sync argument of an Index function.
+// A nil SyncToken or one without a time returns nil, requesting the full
+// dataset.
+func (st *SyncToken) Since() *time.Time {
+	if st == nil || st.Time.IsZero() {
+		return nil
+	}
+
+	t := st.Time
+	return &t
+}
+
+// Advance moves the SyncToken forward to the provided sync time. Times before
+// the current one are ignored so that a late response cannot rewind the sync.
+func (st *SyncToken) Advance(t time.Time) {
+	if t.After(st.Time) {
+		st.Time = t
+	}
+}
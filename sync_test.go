@@ -0,0 +1,101 @@
+package kanka
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSyncToken_Since(t *testing.T) {
+	tm := time.Date(2020, time.January, 26, 3, 22, 31, 34959000, time.UTC)
+
+	tests := []struct {
+		name  string
+		token *SyncToken
+		want  *time.Time
+	}{
+		{
+			name:  "Nil token",
+			token: nil,
+			want:  nil,
+		},
+		{
+			name:  "Zero token",
+			token: &SyncToken{},
+			want:  nil,
+		},
+		{
+			name:  "Token with time",
+			token: &SyncToken{Time: tm},
+			want:  &tm,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := test.token.Since()
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSyncToken_Advance(t *testing.T) {
+	early := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	late := time.Date(2020, time.February, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name  string
+		token SyncToken
+		t     time.Time
+		want  time.Time
+	}{
+		{
+			name:  "Zero token",
+			token: SyncToken{},
+			t:     early,
+			want:  early,
+		},
+		{
+			name:  "Later time",
+			token: SyncToken{Time: early},
+			t:     late,
+			want:  late,
+		},
+		{
+			name:  "Earlier time",
+			token: SyncToken{Time: late},
+			t:     early,
+			want:  late,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.token.Advance(test.t)
+			if !test.token.Time.Equal(test.want) {
+				t.Errorf("got: <%v>, want: <%v>", test.token.Time, test.want)
+			}
+		})
+	}
+}
+
+func TestSyncToken_roundTrip(t *testing.T) {
+	want := SyncToken{
+		Time: time.Date(2020, time.January, 26, 3, 22, 31, 34959000, time.UTC),
+	}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got SyncToken
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(got, want); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,209 @@
+package kanka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// SyncKey identifies the objects of a single type in a single Campaign whose
+// changes are tracked by a SyncManager.
+type SyncKey struct {
+	CampaignID int
+	// Type is the type of object tracked, such as "characters". Any value
+	// is allowed as long as it is used consistently.
+	Type string
+}
+
+// SyncStore persists the SyncToken of each SyncKey between runs.
+// Load returns a zero SyncToken and a nil error for a key without a token.
+type SyncStore interface {
+	Load(key SyncKey) (SyncToken, error)
+	Save(key SyncKey, tok SyncToken) error
+}
+
+// MemoryStore is a SyncStore that keeps the SyncTokens in memory. MemoryStore
+// is safe for concurrent use. The zero value is ready to use.
+type MemoryStore struct {
+	mu   sync.Mutex
+	toks map[SyncKey]SyncToken
+}
+
+// Load returns the SyncToken saved for the provided key.
+func (ms *MemoryStore) Load(key SyncKey) (SyncToken, error) {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	return ms.toks[key], nil
+}
+
+// Save saves the provided SyncToken for the provided key.
+func (ms *MemoryStore) Save(key SyncKey, tok SyncToken) error {
+	ms.mu.Lock()
+	defer ms.mu.Unlock()
+
+	if ms.toks == nil {
+		ms.toks = make(map[SyncKey]SyncToken)
+	}
+	ms.toks[key] = tok
+
+	return nil
+}
+
+// SyncManager keeps track of the last successful sync of each SyncKey in a
+// SyncStore so that each run only retrieves the objects changed since the
+// previous one. Use Changes to retrieve the changes.
+type SyncManager struct {
+	store SyncStore
+	now   func() time.Time
+}
+
+// NewSyncManager returns a SyncManager persisting its SyncTokens to the
+// provided SyncStore.
+func NewSyncManager(store SyncStore) *SyncManager {
+	return &SyncManager{store: store, now: time.Now}
+}
+
+// Changes returns the objects listed by the provided index function, such as
+// Client.Characters.IndexAll, for the Campaign associated with campID that
+// have changed since the last successful call with the same Campaign and type.
+// The first call returns every object.
+// The SyncToken is only advanced if every object was retrieved and decoded,
+// so the index function must follow every page of the list. Passing a single
+// page function such as Client.Characters.Index permanently skips the objects
+// beyond its first page.
+// It is advanced to the time the request was made less syncMargin, so objects
+// changed while the request was in flight, or within syncMargin of it, are
+// returned again on the next call rather than missed. The margin covers a
+// local clock running ahead of Kanka's, which applies the sync filter.
+func Changes[T any](m *SyncManager, campID int, typ string, index func(campID int, sync *time.Time) ([]*T, error)) ([]*T, error) {
+	return changes(m, campID, typ, index, nil)
+}
+
+// changes returns the objects changed since the last successful call with the
+// same Campaign and type, as described by Changes. If the provided handle
+// function is not nil, it is called with the objects before the SyncToken is
+// advanced, and the SyncToken is only advanced if handle succeeds.
+func changes[T any](m *SyncManager, campID int, typ string, index func(campID int, sync *time.Time) ([]*T, error), handle func([]*T) error) ([]*T, error) {
+	key := SyncKey{CampaignID: campID, Type: typ}
+
+	tok, err := m.store.Load(key)
+	if err != nil {
+		return nil, fmt.Errorf("cannot load sync token for %s in Campaign (ID: %d): %w", typ, campID, err)
+	}
+
+	start := m.now()
+
+	list, err := index(campID, tok.Since())
+	if err != nil {
+		return list, err
+	}
+
+	if handle != nil {
+		if err = handle(list); err != nil {
+			return list, err
+		}
+	}
+
+	tok.Advance(start.Add(-syncMargin))
+	if err = m.store.Save(key, tok); err != nil {
+		return list, fmt.Errorf("cannot save sync token for %s in Campaign (ID: %d): %w", typ, campID, err)
+	}
+
+	return list, nil
+}
+
+// syncMargin is how far behind the local time of a request a SyncToken is
+// advanced. Kanka compares the sync time against its own clock, so a local
+// clock running ahead of Kanka's by less than syncMargin cannot skip changes.
+const syncMargin = 5 * time.Minute
+
+// maxWatchDoublings is the number of times Watch doubles its wait after
+// consecutive failures, waiting at most 32 intervals.
+const maxWatchDoublings = 5
+
+// Watch retrieves the changes listed by the provided index function, such as
+// Client.Characters.IndexAll, for the Campaign associated with campID right away
+// and then every interval until the provided context is done, calling the
+// provided handle function with each changed object in order. The changes are
+// tracked by the SyncManager exactly as with Changes, except that the
+// SyncToken is only advanced once every changed object has been handled.
+// Failures to retrieve the changes are retried with an exponential backoff of
+// up to 32 intervals, or after the wait requested by Kanka while it is down
+// for maintenance, if longer. Watch stops and returns the error if Kanka
+// refuses the request outright, such as for an invalid token, if a record
+// cannot be decoded, since retrying returns the same record, or if handle
+// returns an error, in which case the same changes are handled again by the
+// next Watch. Otherwise, Watch returns the error of the context once it is
+// done. As with Changes, the index function must follow every page of the
+// list.
+func Watch[T any](ctx context.Context, m *SyncManager, campID int, typ string, interval time.Duration, index func(campID int, sync *time.Time) ([]*T, error), handle func(obj *T) error) error {
+	if interval <= 0 {
+		return fmt.Errorf("invalid watch interval: provided interval (%v) must be positive", interval)
+	}
+
+	var handleErr error
+	each := func(list []*T) error {
+		for _, obj := range list {
+			if err := handle(obj); err != nil {
+				handleErr = err
+				return err
+			}
+		}
+		return nil
+	}
+
+	var failures uint
+	for {
+		wait := interval
+
+		_, err := changes(m, campID, typ, index, each)
+		switch {
+		case handleErr != nil:
+			return fmt.Errorf("cannot handle changed %s in Campaign (ID: %d): %w", typ, campID, handleErr)
+		case err != nil && !retryable(err):
+			return err
+		case err != nil:
+			if failures < maxWatchDoublings {
+				failures++
+			}
+			wait = interval << failures
+
+			var me *MaintenanceError
+			if errors.As(err, &me) && me.RetryAfter > wait {
+				wait = me.RetryAfter
+			}
+		default:
+			failures = 0
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// retryable returns true if the provided error might not happen again if the
+// request is retried later. Every error is retryable except an unsuccessful
+// response from Kanka that is neither temporary nor a server failure and a
+// record that cannot be decoded.
+func retryable(err error) bool {
+	var recErrs RecordErrors
+	if errors.As(err, &recErrs) {
+		return false
+	}
+
+	var se *serverError
+	if errors.As(err, &se) {
+		return se.Temporary() || se.code >= http.StatusInternalServerError
+	}
+
+	return true
+}
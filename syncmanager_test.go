@@ -0,0 +1,285 @@
+package kanka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type testFailStore struct{}
+
+func (testFailStore) Load(key SyncKey) (SyncToken, error) {
+	return SyncToken{}, errors.New("store unavailable")
+}
+
+func (testFailStore) Save(key SyncKey, tok SyncToken) error {
+	return errors.New("store unavailable")
+}
+
+func TestChanges(t *testing.T) {
+	first := time.Date(2020, time.January, 26, 3, 22, 31, 0, time.UTC)
+	firstSync := first.Add(-syncMargin)
+	secondSync := firstSync.Add(time.Hour)
+
+	tests := []struct {
+		name      string
+		errs      []error
+		wantSyncs []*time.Time
+		wantErrs  []bool
+	}{
+		{
+			name:      "Successful runs",
+			errs:      []error{nil, nil, nil},
+			wantSyncs: []*time.Time{nil, &firstSync, &secondSync},
+			wantErrs:  []bool{false, false, false},
+		},
+		{
+			name:      "Failed run does not advance",
+			errs:      []error{nil, errors.New("timeout"), nil},
+			wantSyncs: []*time.Time{nil, &firstSync, &firstSync},
+			wantErrs:  []bool{false, true, false},
+		},
+		{
+			name:      "Failed first run",
+			errs:      []error{errors.New("timeout"), nil},
+			wantSyncs: []*time.Time{nil, nil},
+			wantErrs:  []bool{true, false},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m := NewSyncManager(&MemoryStore{})
+			now := first
+			m.now = func() time.Time { return now }
+
+			for i, wantErr := range test.errs {
+				var got *time.Time
+				index := func(campID int, sync *time.Time) ([]*Character, error) {
+					got = sync
+					return []*Character{}, test.errs[i]
+				}
+
+				_, err := Changes(m, 5272, "characters", index)
+				if (err != nil) != test.wantErrs[i] {
+					t.Fatalf("run %d: got err?: <%t>, want err?: <%t>\nerror: <%v>", i, (err != nil), test.wantErrs[i], err)
+				}
+				if !errors.Is(err, wantErr) {
+					t.Errorf("run %d: got err: <%v>, want err: <%v>", i, err, wantErr)
+				}
+				if diff := cmp.Diff(got, test.wantSyncs[i]); diff != "" {
+					t.Errorf("run %d: %s", i, diff)
+				}
+
+				now = now.Add(time.Hour)
+			}
+		})
+	}
+}
+
+func TestChanges_keys(t *testing.T) {
+	tm := time.Date(2020, time.January, 26, 3, 22, 31, 0, time.UTC)
+	store := &MemoryStore{}
+	m := NewSyncManager(store)
+	m.now = func() time.Time { return tm }
+
+	index := func(campID int, sync *time.Time) ([]*Character, error) {
+		return nil, nil
+	}
+
+	if _, err := Changes(m, 5272, "characters", index); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		key  SyncKey
+		want SyncToken
+	}{
+		{
+			name: "Synced key",
+			key:  SyncKey{CampaignID: 5272, Type: "characters"},
+			want: SyncToken{Time: tm.Add(-syncMargin)},
+		},
+		{
+			name: "Other type",
+			key:  SyncKey{CampaignID: 5272, Type: "locations"},
+			want: SyncToken{},
+		},
+		{
+			name: "Other campaign",
+			key:  SyncKey{CampaignID: 1234, Type: "characters"},
+			want: SyncToken{},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := store.Load(test.key)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}
+
+func TestChanges_storeError(t *testing.T) {
+	m := NewSyncManager(testFailStore{})
+
+	called := false
+	index := func(campID int, sync *time.Time) ([]*Character, error) {
+		called = true
+		return nil, nil
+	}
+
+	if _, err := Changes(m, 5272, "characters", index); err == nil {
+		t.Errorf("got err: <nil>, want err: <store unavailable>")
+	}
+	if called {
+		t.Errorf("got index called: <true>, want: <false>")
+	}
+}
+
+func TestWatch(t *testing.T) {
+	first := time.Date(2020, time.January, 26, 3, 22, 31, 0, time.UTC)
+	firstSync := first.Add(-syncMargin)
+
+	type result struct {
+		list []*Character
+		err  error
+	}
+
+	tests := []struct {
+		name        string
+		results     []result
+		failHandle  string
+		wantHandled []string
+		wantSyncs   []*time.Time
+		wantErr     error
+	}{
+		{
+			name: "Retries failure then handles changes",
+			results: []result{
+				{list: nil, err: errors.New("connection reset")},
+				{list: []*Character{{SimpleCharacter: SimpleCharacter{Name: "Arya"}}, {SimpleCharacter: SimpleCharacter{Name: "Bran"}}}, err: nil},
+				{list: []*Character{{SimpleCharacter: SimpleCharacter{Name: "Sansa"}}}, err: nil},
+			},
+			failHandle:  "",
+			wantHandled: []string{"Arya", "Bran", "Sansa"},
+			wantSyncs:   []*time.Time{nil, nil, &firstSync},
+			wantErr:     context.Canceled,
+		},
+		{
+			name: "Stops on refused request",
+			results: []result{
+				{list: nil, err: &serverError{code: http.StatusUnauthorized, status: "401 Unauthorized"}},
+			},
+			failHandle:  "",
+			wantHandled: nil,
+			wantSyncs:   []*time.Time{nil},
+			wantErr:     &serverError{},
+		},
+		{
+			name: "Stops on undecodable record without advancing",
+			results: []result{
+				{list: []*Character{{SimpleCharacter: SimpleCharacter{Name: "Arya"}}}, err: fmt.Errorf("cannot decode Character Index: %w", RecordErrors{{Index: 1, Err: errors.New("bad record")}})},
+			},
+			failHandle:  "",
+			wantHandled: nil,
+			wantSyncs:   []*time.Time{nil},
+			wantErr:     RecordErrors{},
+		},
+		{
+			name: "Stops on handler failure without advancing",
+			results: []result{
+				{list: []*Character{{SimpleCharacter: SimpleCharacter{Name: "Arya"}}, {SimpleCharacter: SimpleCharacter{Name: "Bran"}}}, err: nil},
+			},
+			failHandle:  "Bran",
+			wantHandled: []string{"Arya", "Bran"},
+			wantSyncs:   []*time.Time{nil},
+			wantErr:     errHandle,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			store := &MemoryStore{}
+			m := NewSyncManager(store)
+			m.now = func() time.Time { return first }
+
+			var syncs []*time.Time
+			index := func(campID int, sync *time.Time) ([]*Character, error) {
+				syncs = append(syncs, sync)
+				res := test.results[len(syncs)-1]
+				if len(syncs) == len(test.results) && res.err == nil && test.failHandle == "" {
+					defer cancel()
+				}
+				return res.list, res.err
+			}
+
+			var handled []string
+			handle := func(ch *Character) error {
+				handled = append(handled, ch.Name)
+				if ch.Name == test.failHandle {
+					return errHandle
+				}
+				return nil
+			}
+
+			err := Watch(ctx, m, 5272, "characters", time.Millisecond, index, handle)
+			switch want := test.wantErr.(type) {
+			case *serverError:
+				var se *serverError
+				if !errors.As(err, &se) {
+					t.Errorf("got err: <%v>, want serverError", err)
+				}
+			case RecordErrors:
+				var recErrs RecordErrors
+				if !errors.As(err, &recErrs) {
+					t.Errorf("got err: <%v>, want RecordErrors", err)
+				}
+			default:
+				if !errors.Is(err, want) {
+					t.Errorf("got err: <%v>, want err: <%v>", err, want)
+				}
+			}
+
+			if diff := cmp.Diff(handled, test.wantHandled); diff != "" {
+				t.Errorf(diff)
+			}
+			if diff := cmp.Diff(syncs, test.wantSyncs); diff != "" {
+				t.Errorf(diff)
+			}
+
+			if test.failHandle != "" {
+				tok, _ := store.Load(SyncKey{CampaignID: 5272, Type: "characters"})
+				if tok.Since() != nil {
+					t.Errorf("got sync token: <%v>, want no sync token", tok.Time)
+				}
+			}
+		})
+	}
+}
+
+func TestWatch_invalidInterval(t *testing.T) {
+	m := NewSyncManager(&MemoryStore{})
+	index := func(campID int, sync *time.Time) ([]*Character, error) {
+		t.Error("index called with invalid interval")
+		return nil, nil
+	}
+
+	if err := Watch(context.Background(), m, 5272, "characters", 0, index, func(*Character) error { return nil }); err == nil {
+		t.Errorf("got err?: <false>, want err?: <true>")
+	}
+}
+
+var errHandle = errors.New("cannot handle character")
@@ -5,24 +5,24 @@ import (
 	"encoding/json"
 	"fmt"
 	"time"
-
-	"github.com/Henry-Sarabia/blank"
 )
 
 // Tag contains information about a specific tag.
 // For more information, visit: https://kanka.io/en-US/docs/1.0/tags
 type Tag struct {
 	SimpleTag
-	ID             int       `json:"id"`
-	ImageFull      string    `json:"image_full"`
-	ImageThumb     string    `json:"image_thumb"`
-	HasCustomImage bool      `json:"has_custom_image"`
-	EntityID       int       `json:"entity_id"`
-	Entities       []int     `json:"entities"`
-	CreatedAt      time.Time `json:"created_at"`
-	CreatedBy      int       `json:"created_by"`
-	UpdatedAt      time.Time `json:"updated_at"`
-	UpdatedBy      int       `json:"updated_by"`
+	ID              int       `json:"id"`
+	ImageFull       string    `json:"image_full"`
+	ImageThumb      string    `json:"image_thumb"`
+	HasCustomImage  bool      `json:"has_custom_image"`
+	HeaderFull      string    `json:"header_full"`
+	HasCustomHeader bool      `json:"has_custom_header"`
+	EntityID        int       `json:"entity_id"`
+	Entities        []int     `json:"entities"`
+	CreatedAt       time.Time `json:"created_at"`
+	CreatedBy       int       `json:"created_by"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	UpdatedBy       int       `json:"updated_by"`
 
 	Attributes   Attributes   `json:"attributes"`
 	EntityEvents EntityEvents `json:"entity_events"`
@@ -35,114 +35,195 @@ type Tag struct {
 // SimpleTag contains only the simple information about a tag.
 // SimpleTag is primarily used to create new tags for posting to Kanka.
 type SimpleTag struct {
-	Name      string `json:"name"`
-	Entry     string `json:"entry,omitempty"`
-	Type      string `json:"type,omitempty"`
-	TagID     int    `json:"tag_id,omitempty"`
-	Color     string `json:"colour,omitempty"`
-	Tags      []int  `json:"tags,omitempty"`
-	IsPrivate bool   `json:"is_private,omitempty"`
-	Image     string `json:"image,omitempty"`
-	ImageURL  string `json:"image_url,omitempty"`
+	Name           string   `json:"name"`
+	Entry          string   `json:"entry,omitempty"`
+	Type           string   `json:"type,omitempty"`
+	TagID          int      `json:"tag_id,omitempty"`
+	Color          string   `json:"colour,omitempty"`
+	Tags           []int    `json:"tags,omitempty"`
+	IsPrivate      bool     `json:"is_private,omitempty"`
+	IsTemplate     *bool    `json:"is_template,omitempty"`
+	Image          string   `json:"image,omitempty"`
+	ImageURL       string   `json:"image_url,omitempty"`
+	ImageUUID      string   `json:"image_uuid,omitempty"`
+	FocusX         *float64 `json:"focus_x,omitempty"`
+	FocusY         *float64 `json:"focus_y,omitempty"`
+	HeaderImageURL string   `json:"header_image_url,omitempty"`
+	HeaderUUID     string   `json:"entity_header_uuid,omitempty"`
+
+	// IsAttributesPrivate hides the attributes of the entity from non-admins.
+	IsAttributesPrivate *bool `json:"is_attributes_private,omitempty"`
 }
 
 // MarshalJSON marshals the SimpleTag into its JSON-encoded form if it
 // has the required populated fields.
 func (st SimpleTag) MarshalJSON() ([]byte, error) {
-	if blank.Is(st.Name) {
-		return nil, fmt.Errorf("cannot marshal SimpleTag into JSON with a missing Name")
+	if err := requireField("SimpleTag", "Name", st.Name); err != nil {
+		return nil, err
 	}
 
 	type alias SimpleTag
 	return json.Marshal(alias(st))
 }
 
+// Simple returns a copy of the SimpleTag data of the Tag, ready to be modified
+// and passed to Update. The path of the current image is left out and the
+// slices of the copy do not share memory with the Tag.
+func (t *Tag) Simple() SimpleTag {
+	s := t.SimpleTag
+	s.Image = ""
+	s.Tags = append([]int(nil), s.Tags...)
+
+	return s
+}
+
 // TagService handles communication with the Tag endpoint.
 type TagService service
 
+// base returns the baseService implementing the common operations of the
+// TagService.
+func (ts *TagService) base() baseService[Tag, SimpleTag] {
+	return baseService[Tag, SimpleTag]{
+		service: (*service)(ts),
+		kind:    "Tag",
+		entity:  "tag",
+		label:   func(tag SimpleTag) string { return tag.Name },
+	}
+}
+
 // Index returns the list of all Tags in the Campaign associated with campID.
 // If a non-nil time is provided, Index will only return Tags that have
 // been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Tags that were decoded.
 func (ts *TagService) Index(campID int, sync *time.Time) ([]*Tag, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(ts.end)
-
-	if sync != nil {
-		end = end.sync(*sync)
-	}
-
-	var wrap struct {
-		Data []*Tag `json:"data"`
-	}
+	return ts.base().Index(campID, sync)
+}
 
-	err = ts.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Tag Index from Campaign (ID: %d): %w", campID, err)
-	}
+// IndexAll returns the list of all Tags in the Campaign associated with
+// campID from every page of the list, following the link to the next page
+// returned by Kanka until the last page is reached. The rate limit of the
+// Client, if any, applies to every page.
+// If a non-nil time is provided, IndexAll will only return Tags that have
+// been changed since that time.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors alongside the Tags that were decoded.
+func (ts *TagService) IndexAll(campID int, sync *time.Time) ([]*Tag, error) {
+	return ts.base().IndexAll(campID, sync)
+}
 
-	return wrap.Data, nil
+// GetMany returns the Tags associated with each of the provided IDs from the
+// Campaign associated with campID, keyed by ID, along with their related data.
+// GetMany reads the first page of the list of Tags, then either requests the
+// remaining IDs with Get or pages through the rest of the list, whichever
+// takes fewer requests. It never takes more than one request more than
+// calling Get for each ID.
+// Records that cannot be decoded are skipped and reported in an error
+// wrapping RecordErrors. Otherwise, IDs that are not in the Campaign are
+// reported in an error matching ErrNotFound alongside the Tags that were
+// found.
+func (ts *TagService) GetMany(campID int, ids []int) (map[int]*Tag, error) {
+	return ts.base().GetMany(campID, ids)
 }
 
-// Get returns the Tag associated with tagID from the Campaign
-// associated with campID.
-func (ts *TagService) Get(campID int, tagID int) (*Tag, error) {
+// Count returns the number of Tags in the Campaign associated with campID
+// without retrieving them.
+func (ts *TagService) Count(campID int) (int, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
+		return 0, fmt.Errorf("invalid Campaign ID: %w", err)
 	}
 	end = end.concat(ts.end)
 
-	end, err = end.id(tagID)
+	n, err := ts.client.count(end)
 	if err != nil {
-		return nil, fmt.Errorf("invalid Tag ID: %w", err)
+		return 0, fmt.Errorf("cannot count Tags in Campaign (ID: %d): %w", campID, err)
 	}
 
-	var wrap struct {
-		Data *Tag `json:"data"`
-	}
+	return n, nil
+}
 
-	err = ts.client.get(end, &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot get Tag (ID: %d) from Campaign (ID: %d): %w", tagID, campID, err)
-	}
+// Get returns the Tag associated with tagID from the Campaign
+// associated with campID.
+func (ts *TagService) Get(campID int, tagID int) (*Tag, error) {
+	return ts.base().Get(campID, tagID)
+}
 
-	return wrap.Data, nil
+// GetByName returns the Tag with the provided name from the Campaign
+// associated with campID. Names are matched exactly or, failing that,
+// regardless of case. GetByName returns an error matching ErrNotFound if no
+// Tag has the name and ErrAmbiguous if more than one does.
+func (ts *TagService) GetByName(campID int, name string) (*Tag, error) {
+	return ts.base().GetByName(campID, name)
 }
 
 // Create creates a new Tag in the Campaign associated with campID using
 // the provided SimpleTag data.
 // Create returns the newly created Tag.
-func (ts *TagService) Create(campID int, tag SimpleTag) (*Tag, error) {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
-	}
-	end = end.concat(ts.end)
-
-	b, err := json.Marshal(tag)
-	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleTag (Name: %s): %w", tag.Name, err)
-	}
-
-	var wrap struct {
-		Data *Tag `json:"data"`
-	}
-
-	err = ts.client.post(end, bytes.NewReader(b), &wrap)
-	if err != nil {
-		return nil, fmt.Errorf("cannot create Tag (Name: %s) for Campaign (ID: %d): %w", tag.Name, campID, err)
-	}
+// The provided CreateOptions, such as Public, apply to this call only.
+func (ts *TagService) Create(campID int, tag SimpleTag, opts ...CreateOption) (*Tag, error) {
+	return ts.base().Create(campID, tag, opts...)
+}
 
-	return wrap.Data, nil
+// CreateWithResult creates a new Tag in the Campaign associated with campID
+// using the provided SimpleTag data.
+// CreateWithResult returns the newly created Tag along with the metadata of
+// Kanka's response, such as its status code and Location header.
+// The provided CreateOptions, such as Public, apply to this call only.
+func (ts *TagService) CreateWithResult(campID int, tag SimpleTag, opts ...CreateOption) (*Tag, *WriteResult, error) {
+	return ts.base().CreateWithResult(campID, tag, opts...)
 }
 
 // Update updates an existing Tag associated with tagID from the
 // Campaign associated with campID using the provided SimpleTag data.
+// Fields managed by Kanka, such as the path of the current image, are never
+// sent. To update a Tag returned by Kanka, provide its SimpleTag.
 // Update returns the newly updated Tag.
 func (ts *TagService) Update(campID int, tagID int, tag SimpleTag) (*Tag, error) {
+	return ts.base().Update(campID, tagID, tag)
+}
+
+// UpdateWithResult updates an existing Tag associated with tagID from the
+// Campaign associated with campID using the provided SimpleTag data, as
+// Update does.
+// UpdateWithResult returns the newly updated Tag along with the metadata of
+// Kanka's response, such as its status code.
+func (ts *TagService) UpdateWithResult(campID int, tagID int, tag SimpleTag) (*Tag, *WriteResult, error) {
+	return ts.base().UpdateWithResult(campID, tagID, tag)
+}
+
+// Clear clears each of the provided fields, such as "entry", of an existing
+// Tag associated with tagID from the Campaign associated with campID. Unlike
+// Update, which omits empty fields, Clear blanks the fields; every other field
+// of the Tag is left unchanged.
+// Clear returns the newly updated Tag.
+func (ts *TagService) Clear(campID int, tagID int, fields ...string) (*Tag, error) {
+	return ts.base().Clear(campID, tagID, fields...)
+}
+
+// SetHeader sets the header image of an existing Tag associated with tagID
+// from the Campaign associated with campID to the GalleryImage associated with
+// uuid. The header image is shown above the Tag on its page, separately
+// from its main image. Only the header image is updated.
+// SetHeader returns the newly updated Tag.
+func (ts *TagService) SetHeader(campID int, tagID int, uuid string) (*Tag, error) {
+	return ts.base().SetHeader(campID, tagID, uuid)
+}
+
+// SetHeaderURL sets the header image of an existing Tag associated with
+// tagID from the Campaign associated with campID to the image found at the
+// provided URL, which Kanka downloads. Only the header image is updated.
+// SetHeaderURL returns the newly updated Tag.
+func (ts *TagService) SetHeaderURL(campID int, tagID int, url string) (*Tag, error) {
+	return ts.base().SetHeaderURL(campID, tagID, url)
+}
+
+// SetParent sets the parent Tag of the Tag associated with tagID in the
+// Campaign associated with campID to the Tag associated with parentID.
+// A parentID of 0 removes the parent Tag. Only the parent is updated; every
+// other field of the Tag is left unchanged.
+// SetParent returns the newly updated Tag.
+func (ts *TagService) SetParent(campID int, tagID int, parentID int) (*Tag, error) {
 	end, err := EndpointCampaign.id(campID)
 	if err != nil {
 		return nil, fmt.Errorf("invalid Campaign ID: %w", err)
@@ -154,18 +235,21 @@ func (ts *TagService) Update(campID int, tagID int, tag SimpleTag) (*Tag, error)
 		return nil, fmt.Errorf("invalid Tag ID: %w", err)
 	}
 
-	b, err := json.Marshal(tag)
+	fields, err := parentFields("tag_id", parentID)
 	if err != nil {
-		return nil, fmt.Errorf("cannot marshal SimpleTag (Name: %s): %w", tag.Name, err)
+		return nil, fmt.Errorf("invalid parent Tag ID: %w", err)
 	}
 
-	var wrap struct {
-		Data *Tag `json:"data"`
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, fmt.Errorf("cannot marshal parent Tag (ID: %d): %w", parentID, err)
 	}
 
-	err = ts.client.put(end, bytes.NewReader(b), &wrap)
+	var wrap response[*Tag]
+
+	err = ts.client.patch(end, bytes.NewReader(b), &wrap)
 	if err != nil {
-		return nil, fmt.Errorf("cannot update Tag (Name: %s) for Campaign (ID: %d): '%w'", tag.Name, campID, err)
+		return nil, fmt.Errorf("cannot set parent of Tag (ID: %d) for Campaign (ID: %d): %w", tagID, campID, err)
 	}
 
 	return wrap.Data, nil
@@ -174,21 +258,78 @@ func (ts *TagService) Update(campID int, tagID int, tag SimpleTag) (*Tag, error)
 // Delete deletes an existing Tag associated with tagID from the
 // Campaign associated with campID.
 func (ts *TagService) Delete(campID int, tagID int) error {
-	end, err := EndpointCampaign.id(campID)
-	if err != nil {
-		return fmt.Errorf("invalid Campaign ID: %w", err)
+	return ts.base().Delete(campID, tagID)
+}
+
+// TagPath returns the chain of Tags from the root of the tag tree to the Tag
+// associated with tagID in the Campaign associated with campID, such as the
+// breadcrumbs of the Tag. The chain ends with the Tag itself.
+func (ts *TagService) TagPath(campID int, tagID int) ([]*Tag, error) {
+	return ts.path(tagID, func(id int) (*Tag, error) {
+		return ts.Get(campID, id)
+	})
+}
+
+// TagPaths returns the TagPath of each Tag associated with the provided
+// tagIDs in the Campaign associated with campID, keyed by Tag ID. Each Tag is
+// fetched at most once, even if it is an ancestor shared by several Tags.
+func (ts *TagService) TagPaths(campID int, tagIDs []int) (map[int][]*Tag, error) {
+	cache := make(map[int]*Tag)
+	get := func(id int) (*Tag, error) {
+		if tag, ok := cache[id]; ok {
+			return tag, nil
+		}
+
+		tag, err := ts.Get(campID, id)
+		if err != nil {
+			return nil, err
+		}
+		cache[id] = tag
+
+		return tag, nil
 	}
-	end = end.concat(ts.end)
 
-	end, err = end.id(tagID)
-	if err != nil {
-		return fmt.Errorf("invalid Tag ID: %w", err)
+	paths := make(map[int][]*Tag, len(tagIDs))
+	for _, id := range tagIDs {
+		if _, ok := paths[id]; ok {
+			continue
+		}
+
+		p, err := ts.path(id, get)
+		if err != nil {
+			return nil, err
+		}
+		paths[id] = p
 	}
 
-	err = ts.client.delete(end)
-	if err != nil {
-		return fmt.Errorf("cannot delete Tag (ID: %d) for Campaign (ID: %d): %w", tagID, campID, err)
+	return paths, nil
+}
+
+// path returns the chain of Tags from the root of the tag tree to the Tag
+// associated with tagID using the provided function to fetch each Tag.
+// Returns an error if the chain contains a cycle.
+func (ts *TagService) path(tagID int, get func(int) (*Tag, error)) ([]*Tag, error) {
+	var chain []*Tag
+	seen := make(map[int]bool)
+
+	for id := tagID; id != 0; {
+		if seen[id] {
+			return nil, fmt.Errorf("cannot resolve path of Tag (ID: %d): cycle at Tag (ID: %d)", tagID, id)
+		}
+		seen[id] = true
+
+		tag, err := get(id)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resolve path of Tag (ID: %d): %w", tagID, err)
+		}
+
+		chain = append(chain, tag)
+		id = tag.TagID
+	}
+
+	for i, j := 0, len(chain)-1; i < j; i, j = i+1, j-1 {
+		chain[i], chain[j] = chain[j], chain[i]
 	}
 
-	return nil
+	return chain, nil
 }
@@ -2,6 +2,7 @@ package kanka
 
 import (
 	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 	"time"
@@ -559,3 +560,206 @@ func TestTagService_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestTagService_SetParent(t *testing.T) {
+	type args struct {
+		campID   int
+		tagID    int
+		parentID int
+	}
+	tests := []struct {
+		name     string
+		status   int
+		file     string
+		args     args
+		wantBody string
+		wantErr  bool
+	}{
+		{
+			name:     "StatusOK, valid response, valid args",
+			status:   http.StatusOK,
+			file:     testTagUpdate,
+			args:     args{campID: 5272, tagID: 111, parentID: 222},
+			wantBody: `{"tag_id":222}`,
+			wantErr:  false,
+		},
+		{
+			name:     "StatusOK, valid response, cleared parent",
+			status:   http.StatusOK,
+			file:     testTagUpdate,
+			args:     args{campID: 5272, tagID: 111, parentID: 0},
+			wantBody: `{"tag_id":null}`,
+			wantErr:  false,
+		},
+		{
+			name:     "Status OK, valid response, invalid campID",
+			status:   http.StatusOK,
+			file:     testTagUpdate,
+			args:     args{campID: -123, tagID: 111, parentID: 222},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Status OK, valid response, invalid tagID",
+			status:   http.StatusOK,
+			file:     testTagUpdate,
+			args:     args{campID: 5272, tagID: -123, parentID: 222},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Status OK, valid response, invalid parentID",
+			status:   http.StatusOK,
+			file:     testTagUpdate,
+			args:     args{campID: 5272, tagID: 111, parentID: -123},
+			wantBody: "",
+			wantErr:  true,
+		},
+		{
+			name:     "StatusNotFound, valid args",
+			status:   http.StatusNotFound,
+			file:     testFileEmpty,
+			args:     args{campID: 5272, tagID: 111, parentID: 222},
+			wantBody: `{"tag_id":222}`,
+			wantErr:  true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, rec := testRecordClient(t, test.status, test.file)
+			defer ts.Close()
+
+			got, err := c.Tags.SetParent(test.args.campID, test.args.tagID, test.args.parentID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if rec.body != test.wantBody {
+				t.Errorf("got body: <%s>, want body: <%s>", rec.body, test.wantBody)
+			}
+			if test.wantErr {
+				return
+			}
+			if rec.method != "PATCH" {
+				t.Errorf("got method: <%s>, want method: <%s>", rec.method, "PATCH")
+			}
+			if got == nil || got.ID != 111 {
+				t.Errorf("got: <%v>, want Tag with ID 111", got)
+			}
+		})
+	}
+}
+
+func testTagTreeClient(t *testing.T) (*Client, *httptest.Server, map[string]int) {
+	bodies := map[string]string{
+		"/campaigns/5272/tags/1": `{"data":{"id":1,"name":"Stark","tag_id":2}}`,
+		"/campaigns/5272/tags/2": `{"data":{"id":2,"name":"Great Houses","tag_id":3}}`,
+		"/campaigns/5272/tags/3": `{"data":{"id":3,"name":"Westeros"}}`,
+		"/campaigns/5272/tags/4": `{"data":{"id":4,"name":"Lannister","tag_id":2}}`,
+		"/campaigns/5272/tags/5": `{"data":{"id":5,"name":"Ouroboros","tag_id":6}}`,
+		"/campaigns/5272/tags/6": `{"data":{"id":6,"name":"Serpent","tag_id":5}}`,
+		"/campaigns/5272/tags/7": `{"data":{"id":7,"name":"Orphan","tag_id":8}}`,
+	}
+
+	counts := make(map[string]int)
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		counts[r.URL.Path]++
+
+		body, ok := bodies[r.URL.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+
+	c := NewClient(testToken, ts.Client())
+	c.rootURL = ts.URL + "/"
+
+	return c, ts, counts
+}
+
+func TestTagService_TagPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		tagID   int
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "Leaf tag",
+			tagID:   1,
+			want:    []string{"Westeros", "Great Houses", "Stark"},
+			wantErr: false,
+		},
+		{
+			name:    "Root tag",
+			tagID:   3,
+			want:    []string{"Westeros"},
+			wantErr: false,
+		},
+		{
+			name:    "Cyclic tag tree",
+			tagID:   5,
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Missing parent",
+			tagID:   7,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c, ts, _ := testTagTreeClient(t)
+			defer ts.Close()
+
+			got, err := c.Tags.TagPath(5272, test.tagID)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			var names []string
+			for _, tag := range got {
+				names = append(names, tag.Name)
+			}
+			if diff := cmp.Diff(names, test.want); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestTagService_TagPaths(t *testing.T) {
+	c, ts, counts := testTagTreeClient(t)
+	defer ts.Close()
+
+	got, err := c.Tags.TagPaths(5272, []int{1, 4, 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	names := make(map[int][]string)
+	for id, path := range got {
+		for _, tag := range path {
+			names[id] = append(names[id], tag.Name)
+		}
+	}
+	want := map[int][]string{
+		1: {"Westeros", "Great Houses", "Stark"},
+		4: {"Westeros", "Great Houses", "Lannister"},
+	}
+	if diff := cmp.Diff(names, want); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+
+	for path, n := range counts {
+		if n != 1 {
+			t.Errorf("got %d requests to <%s>, want 1", n, path)
+		}
+	}
+	if len(counts) != 4 {
+		t.Errorf("got requests to %d tags, want 4", len(counts))
+	}
+}
@@ -0,0 +1,61 @@
+package kanka
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// timestampLayouts are the layouts Kanka is known to use for timestamps, in
+// the order they are tried when unmarshaling.
+var timestampLayouts = []string{
+	SyncFormat,
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05",
+}
+
+// Timestamp is a time that is marshaled in the format Kanka expects for
+// timestamps in write payloads rather than Go's default RFC 3339 format.
+// Timestamp is always marshaled in UTC using the SyncFormat layout and the
+// zero Timestamp is marshaled as null. Timestamp unmarshals each of the
+// formats Kanka uses in its responses, keeping reads and writes symmetric.
+type Timestamp struct {
+	time.Time
+}
+
+// MarshalJSON marshals the Timestamp into its JSON-encoded form.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+
+	return json.Marshal(t.UTC().Format(SyncFormat))
+}
+
+// UnmarshalJSON unmarshals the JSON-encoded data into the Timestamp. A null
+// value or an empty string is unmarshaled into the zero Timestamp.
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	if isNull(b) {
+		*t = Timestamp{}
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("cannot unmarshal timestamp: %w", err)
+	}
+
+	if s == "" {
+		*t = Timestamp{}
+		return nil
+	}
+
+	for _, layout := range timestampLayouts {
+		if tm, err := time.Parse(layout, s); err == nil {
+			*t = Timestamp{tm}
+			return nil
+		}
+	}
+
+	return fmt.Errorf("cannot parse timestamp '%s' with any known layout", s)
+}
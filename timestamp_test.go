@@ -0,0 +1,124 @@
+package kanka
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestamp_MarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		ts   Timestamp
+		want string
+	}{
+		{
+			name: "UTC time",
+			ts:   Timestamp{time.Date(2020, time.January, 26, 3, 22, 31, 34959000, time.UTC)},
+			want: `"2020-01-26T03:22:31.034959Z"`,
+		},
+		{
+			name: "Time with offset",
+			ts:   Timestamp{time.Date(2020, time.January, 26, 5, 22, 31, 0, time.FixedZone("EET", 2*60*60))},
+			want: `"2020-01-26T03:22:31.000000Z"`,
+		},
+		{
+			name: "Zero time",
+			ts:   Timestamp{},
+			want: `null`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, err := json.Marshal(test.ts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != test.want {
+				t.Errorf("got: <%s>, want: <%s>", got, test.want)
+			}
+		})
+	}
+}
+
+func TestTimestamp_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    time.Time
+		wantErr bool
+	}{
+		{
+			name:    "Sync format",
+			data:    `"2020-01-26T03:22:31.034959Z"`,
+			want:    time.Date(2020, time.January, 26, 3, 22, 31, 34959000, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "RFC 3339",
+			data:    `"2020-01-26T03:22:31Z"`,
+			want:    time.Date(2020, time.January, 26, 3, 22, 31, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "Date and time without zone",
+			data:    `"2020-01-26 03:22:31"`,
+			want:    time.Date(2020, time.January, 26, 3, 22, 31, 0, time.UTC),
+			wantErr: false,
+		},
+		{
+			name:    "Null",
+			data:    `null`,
+			want:    time.Time{},
+			wantErr: false,
+		},
+		{
+			name:    "Empty string",
+			data:    `""`,
+			want:    time.Time{},
+			wantErr: false,
+		},
+		{
+			name:    "Unknown layout",
+			data:    `"26/01/2020"`,
+			want:    time.Time{},
+			wantErr: true,
+		},
+		{
+			name:    "Not a string",
+			data:    `1580008951`,
+			want:    time.Time{},
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var got Timestamp
+			err := json.Unmarshal([]byte(test.data), &got)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+			if !got.Equal(test.want) {
+				t.Errorf("got: <%v>, want: <%v>", got.Time, test.want)
+			}
+		})
+	}
+}
+
+func TestTimestamp_roundTrip(t *testing.T) {
+	want := Timestamp{time.Date(2020, time.January, 26, 3, 22, 31, 34959000, time.UTC)}
+
+	b, err := json.Marshal(want)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got Timestamp
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if !got.Equal(want.Time) {
+		t.Errorf("got: <%v>, want: <%v>", got.Time, want.Time)
+	}
+}
@@ -0,0 +1,18 @@
+package kanka
+
+import (
+	"fmt"
+
+	"github.com/Henry-Sarabia/blank"
+)
+
+// requireField returns an error if the provided value of the named field is
+// blank. The typ argument is the name of the simple type being marshaled and
+// is used to keep the error messages uniform across every simple type.
+func requireField(typ string, field string, val string) error {
+	if blank.Is(val) {
+		return fmt.Errorf("cannot marshal %s into JSON with a missing %s", typ, field)
+	}
+
+	return nil
+}
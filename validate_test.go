@@ -0,0 +1,292 @@
+package kanka
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/Henry-Sarabia/blank"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRequireField(t *testing.T) {
+	type args struct {
+		typ   string
+		field string
+		val   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		wantErr string
+	}{
+		{
+			name:    "Populated value",
+			args:    args{typ: "SimpleCharacter", field: "Name", val: "Jon Snow"},
+			wantErr: "",
+		},
+		{
+			name:    "Empty value",
+			args:    args{typ: "SimpleCharacter", field: "Name", val: ""},
+			wantErr: "cannot marshal SimpleCharacter into JSON with a missing Name",
+		},
+		{
+			name:    "Whitespace value",
+			args:    args{typ: "SimpleMapPoint", field: "Color", val: "   "},
+			wantErr: "cannot marshal SimpleMapPoint into JSON with a missing Color",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := requireField(test.args.typ, test.args.field, test.args.val)
+			if test.wantErr == "" {
+				if err != nil {
+					t.Fatalf("got err: <%v>, want nil", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("got nil err, want: <%s>", test.wantErr)
+			}
+			if err.Error() != test.wantErr {
+				t.Errorf("got err: <%s>, want: <%s>", err.Error(), test.wantErr)
+			}
+		})
+	}
+}
+
+// testSimple is a simple type checked by the shared marshaling tests.
+type testSimple struct {
+	// valid returns a valid value of the simple type.
+	valid func() interface{}
+	// required lists the string fields that must not be blank.
+	required []string
+}
+
+// testSimples lists every simple type with a MarshalJSON guard. Each valid
+// value starts from testFill so that every field is exercised.
+var testSimples = map[string]testSimple{
+	"SimpleAbility":      {valid: func() interface{} { return testFill[SimpleAbility]() }, required: []string{"Name"}},
+	"SimpleAttribute":    {valid: func() interface{} { return testFill[SimpleAttribute]() }, required: []string{"Name"}},
+	"SimpleCharacter":    {valid: func() interface{} { return testFill[SimpleCharacter]() }, required: []string{"Name"}},
+	"SimpleConversation": {valid: func() interface{} { return testFill[SimpleConversation]() }, required: []string{"Name"}},
+	"SimpleConversationParticipant": {valid: func() interface{} {
+		s := testFill[SimpleConversationParticipant]()
+		s.UserID = 0
+		return s
+	}},
+	"SimpleEntityAsset": {valid: func() interface{} { return testFill[SimpleEntityAsset]() }, required: []string{"Name"}},
+	"SimpleEntityNote":  {valid: func() interface{} { return testFill[SimpleEntityNote]() }, required: []string{"Name"}},
+	"SimpleEntityPermission": {valid: func() interface{} {
+		s := testFill[SimpleEntityPermission]()
+		s.RoleID = 0
+		return s
+	}},
+	"SimpleEvent":        {valid: func() interface{} { return testFill[SimpleEvent]() }, required: []string{"Name"}},
+	"SimpleFamily":       {valid: func() interface{} { return testFill[SimpleFamily]() }, required: []string{"Name"}},
+	"SimpleItem":         {valid: func() interface{} { return testFill[SimpleItem]() }, required: []string{"Name"}},
+	"SimpleJournal":      {valid: func() interface{} { return testFill[SimpleJournal]() }, required: []string{"Name"}},
+	"SimpleLocation":     {valid: func() interface{} { return testFill[SimpleLocation]() }, required: []string{"Name"}},
+	"SimpleMapPoint":     {valid: func() interface{} { return testFill[SimpleMapPoint]() }, required: []string{"Color", "Icon", "Shape", "Size"}},
+	"SimpleNote":         {valid: func() interface{} { return testFill[SimpleNote]() }, required: []string{"Name"}},
+	"SimpleOrganization": {valid: func() interface{} { return testFill[SimpleOrganization]() }, required: []string{"Name"}},
+	"SimpleQuest":        {valid: func() interface{} { return testFill[SimpleQuest]() }, required: []string{"Name"}},
+	"SimpleQuestElement": {valid: func() interface{} {
+		s := testFill[SimpleQuestElement]()
+		s.EntityID = 0
+		return s
+	}, required: []string{"Name"}},
+	"SimpleRace":     {valid: func() interface{} { return testFill[SimpleRace]() }, required: []string{"Name"}},
+	"SimpleRelation": {valid: func() interface{} { return testFill[SimpleRelation]() }, required: []string{"Relation"}},
+	"SimpleTag":      {valid: func() interface{} { return testFill[SimpleTag]() }, required: []string{"Name"}},
+}
+
+// testFill returns a value of type T with every exported field populated
+// with a small non-zero value, so that no field is left out by omitempty.
+func testFill[T any]() T {
+	var v T
+	testFillValue(reflect.ValueOf(&v).Elem())
+	return v
+}
+
+// testFillValue populates the provided settable value and, recursively, its
+// fields and elements.
+func testFillValue(v reflect.Value) {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString("x")
+	case reflect.Bool:
+		v.SetBool(true)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(1)
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(0.5)
+	case reflect.Ptr:
+		v.Set(reflect.New(v.Type().Elem()))
+		testFillValue(v.Elem())
+	case reflect.Slice:
+		v.Set(reflect.MakeSlice(v.Type(), 1, 1))
+		testFillValue(v.Index(0))
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				testFillValue(v.Field(i))
+			}
+		}
+	}
+}
+
+// testJSONName returns the name of the provided struct field in JSON.
+func testJSONName(f reflect.StructField) string {
+	name := strings.Split(f.Tag.Get("json"), ",")[0]
+	if name == "" {
+		return f.Name
+	}
+
+	return name
+}
+
+func TestSimpleTypes_RoundTrip(t *testing.T) {
+	for name, st := range testSimples {
+		t.Run(name, func(t *testing.T) {
+			want := st.valid()
+
+			b, err := json.Marshal(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := reflect.New(reflect.TypeOf(want))
+			if err := json.Unmarshal(b, got.Interface()); err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := cmp.Diff(got.Elem().Interface(), want); diff != "" {
+				t.Errorf("round trip mismatch (-got +want):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSimpleTypes_RequiredFields(t *testing.T) {
+	for name, st := range testSimples {
+		for _, field := range st.required {
+			for _, val := range []string{"", "   "} {
+				t.Run(name+"/"+field+"/"+strings.ReplaceAll(val, " ", "_"), func(t *testing.T) {
+					v := reflect.New(reflect.TypeOf(st.valid())).Elem()
+					v.Set(reflect.ValueOf(st.valid()))
+					v.FieldByName(field).SetString(val)
+
+					if _, err := json.Marshal(v.Interface()); err == nil {
+						t.Errorf("got err?: <false>, want err?: <true> with blank %s", field)
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestSimpleTypes_PointerBools(t *testing.T) {
+	boolPtr := reflect.TypeOf((*bool)(nil))
+
+	for name, st := range testSimples {
+		typ := reflect.TypeOf(st.valid())
+		for i := 0; i < typ.NumField(); i++ {
+			f := typ.Field(i)
+			if f.Type != boolPtr {
+				continue
+			}
+
+			tests := []struct {
+				name    string
+				val     *bool
+				want    string
+				present bool
+			}{
+				{name: "nil", val: nil, want: "", present: false},
+				{name: "false", val: new(bool), want: "false", present: true},
+				{name: "true", val: func() *bool { b := true; return &b }(), want: "true", present: true},
+			}
+			for _, test := range tests {
+				t.Run(name+"/"+f.Name+"/"+test.name, func(t *testing.T) {
+					v := reflect.New(typ).Elem()
+					v.Set(reflect.ValueOf(st.valid()))
+					v.Field(i).Set(reflect.ValueOf(test.val))
+
+					b, err := json.Marshal(v.Interface())
+					if err != nil {
+						t.Fatal(err)
+					}
+
+					var body map[string]json.RawMessage
+					if err := json.Unmarshal(b, &body); err != nil {
+						t.Fatal(err)
+					}
+
+					got, ok := body[testJSONName(f)]
+					if ok != test.present {
+						t.Fatalf("got %s present?: <%t>, want present?: <%t>", testJSONName(f), ok, test.present)
+					}
+					if ok && string(got) != test.want {
+						t.Errorf("got %s: <%s>, want: <%s>", testJSONName(f), got, test.want)
+					}
+				})
+			}
+		}
+	}
+}
+
+func FuzzSimpleCharacter_MarshalJSON(f *testing.F) {
+	f.Add("Jon Snow", "King in the North", true, false)
+	f.Add("", "Lord Commander", false, true)
+	f.Add("   ", "", false, false)
+	f.Add("Daenerys \"Stormborn\" <Targaryen>", "Mother of Dragons", true, true)
+
+	f.Fuzz(func(t *testing.T, name string, title string, private bool, template bool) {
+		want := SimpleCharacter{Name: name, Title: title, IsPrivate: private, IsTemplate: &template}
+
+		b, err := json.Marshal(want)
+		if blank.Is(name) {
+			if err == nil {
+				t.Fatalf("got err?: <false>, want err?: <true> with blank Name <%q>", name)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var got SimpleCharacter
+		if err := json.Unmarshal(b, &got); err != nil {
+			t.Fatal(err)
+		}
+
+		// Invalid UTF-8 is replaced when marshaled, so only the validity of
+		// the strings can be compared.
+		if !utf8.ValidString(name) || !utf8.ValidString(title) {
+			return
+		}
+		if diff := cmp.Diff(got, want); diff != "" {
+			t.Errorf("round trip mismatch (-got +want):\n%s", diff)
+		}
+	})
+}
+
+func FuzzSimpleRelation_MarshalJSON(f *testing.F) {
+	f.Add("Brother", 50)
+	f.Add("Enemy", -101)
+	f.Add("", 0)
+	f.Add(strings.Repeat("a", relationLengthMax+1), 0)
+
+	f.Fuzz(func(t *testing.T, relation string, attitude int) {
+		rel := SimpleRelation{Relation: relation, OwnerID: 1, TargetID: 2, Attitude: attitude}
+
+		_, err := json.Marshal(rel)
+		wantErr := blank.Is(relation) || len(relation) > relationLengthMax || attitude < AttitudeMin || attitude > AttitudeMax
+		if (err != nil) != wantErr {
+			t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), wantErr, err)
+		}
+	})
+}
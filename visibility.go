@@ -0,0 +1,147 @@
+package kanka
+
+import "fmt"
+
+// Visibility represents the group of campaign members allowed to view an
+// object such as an entity note.
+// For more information, visit: https://kanka.io/en-US/docs/1.0/entity-notes
+type Visibility string
+
+// Available visibility levels.
+const (
+	// VisibilityAll allows anyone with access to the entity to view the object.
+	VisibilityAll Visibility = "all"
+	// VisibilityMembers allows only campaign members to view the object.
+	VisibilityMembers Visibility = "members"
+	// VisibilityAdmin allows only campaign admins to view the object.
+	VisibilityAdmin Visibility = "admin"
+	// VisibilitySelf allows only the creator of the object to view it.
+	VisibilitySelf Visibility = "self"
+)
+
+// visibleTo returns true if an object with the provided visibility can be
+// viewed by a viewer at the provided viewer level. An empty visibility is
+// treated as VisibilityAll. VisibilitySelf objects are never visible through
+// a viewer level because they depend on who created the object.
+func visibleTo(viewer Visibility, vis Visibility) bool {
+	if vis == "" {
+		vis = VisibilityAll
+	}
+
+	switch viewer {
+	case VisibilityAdmin:
+		return vis == VisibilityAll || vis == VisibilityMembers || vis == VisibilityAdmin
+	case VisibilityMembers:
+		return vis == VisibilityAll || vis == VisibilityMembers
+	case VisibilityAll:
+		return vis == VisibilityAll
+	default:
+		return false
+	}
+}
+
+// NoteVisibility is the audience of an entity note: either a named visibility
+// level or the campaign roles allowed to view the note. Use VisibleAt or
+// VisibleToRoles to construct a NoteVisibility.
+type NoteVisibility struct {
+	level  Visibility
+	roles  []int
+	byRole bool
+}
+
+// VisibleAt returns a NoteVisibility for the provided named visibility level.
+func VisibleAt(level Visibility) NoteVisibility {
+	return NoteVisibility{level: level}
+}
+
+// VisibleToRoles returns a NoteVisibility that restricts a note to the
+// campaign roles associated with the provided role IDs. Notes restricted to
+// roles are hidden from everyone else but the campaign admins.
+func VisibleToRoles(roleIDs ...int) NoteVisibility {
+	return NoteVisibility{level: VisibilityAdmin, roles: roleIDs, byRole: true}
+}
+
+// validate returns an error if the NoteVisibility has an unknown level or an
+// invalid role ID.
+func (nv NoteVisibility) validate() error {
+	switch nv.level {
+	case VisibilityAll, VisibilityMembers, VisibilityAdmin, VisibilitySelf:
+	default:
+		return fmt.Errorf("unknown visibility level '%s'", nv.level)
+	}
+
+	if nv.byRole && len(nv.roles) == 0 {
+		return fmt.Errorf("at least one role ID must be provided")
+	}
+
+	for _, id := range nv.roles {
+		if id <= 0 {
+			return fmt.Errorf("provided role ID (%d) must be positive", id)
+		}
+	}
+
+	return nil
+}
+
+// CanView returns true if members of the campaign role associated with roleID
+// can view the provided entity note, given the roles of the campaign. Admin
+// roles can view every note except those visible only to their creator.
+// Notes restricted to roles are visible to the listed roles only, private
+// notes to admins only, and the rest according to their Visibility, with
+// public roles treated as viewers without a campaign membership.
+// CanView returns false if roleID is not one of the provided roles, so that
+// an unknown role never sees more than it should.
+func CanView(roles []*Role, roleID int, note *EntityNote) bool {
+	var role *Role
+	for _, r := range roles {
+		if r != nil && r.ID == roleID {
+			role = r
+			break
+		}
+	}
+
+	if role == nil {
+		return false
+	}
+
+	viewer := VisibilityMembers
+	switch {
+	case role.IsAdmin:
+		viewer = VisibilityAdmin
+	case role.IsPublic:
+		viewer = VisibilityAll
+	}
+
+	return noteVisibleTo(viewer, roleID, note)
+}
+
+// noteVisibleTo returns true if the provided entity note can be viewed by a
+// viewer at the provided viewer level who is a member of the campaign role
+// associated with roleID. Admin viewers can view every note except those
+// visible only to their creator. Other viewers cannot view private notes,
+// can only view notes restricted to roles if roleID is one of them, and can
+// view the rest according to their Visibility.
+func noteVisibleTo(viewer Visibility, roleID int, note *EntityNote) bool {
+	if note == nil || note.Visibility == VisibilitySelf {
+		return false
+	}
+
+	if viewer == VisibilityAdmin {
+		return true
+	}
+
+	if note.IsPrivate {
+		return false
+	}
+
+	if len(note.Permissions) > 0 {
+		for _, p := range note.Permissions {
+			if p.RoleID == roleID {
+				return true
+			}
+		}
+		return false
+	}
+
+	return visibleTo(viewer, note.Visibility)
+}
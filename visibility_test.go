@@ -0,0 +1,105 @@
+package kanka
+
+import "testing"
+
+func TestCanView(t *testing.T) {
+	roles := []*Role{
+		{ID: 1, Name: "Admin", IsAdmin: true},
+		{ID: 2, Name: "Player"},
+		{ID: 3, Name: "Public", IsPublic: true},
+		{ID: 4, Name: "Scribe"},
+	}
+
+	tests := []struct {
+		name   string
+		roleID int
+		note   *EntityNote
+		want   bool
+	}{
+		{
+			name:   "Admin, admin note",
+			roleID: 1,
+			note:   &EntityNote{SimpleEntityNote: SimpleEntityNote{Visibility: VisibilityAdmin}},
+			want:   true,
+		},
+		{
+			name:   "Admin, self note",
+			roleID: 1,
+			note:   &EntityNote{SimpleEntityNote: SimpleEntityNote{Visibility: VisibilitySelf}},
+			want:   false,
+		},
+		{
+			name:   "Player, all note",
+			roleID: 2,
+			note:   &EntityNote{SimpleEntityNote: SimpleEntityNote{Visibility: VisibilityAll}},
+			want:   true,
+		},
+		{
+			name:   "Player, empty visibility",
+			roleID: 2,
+			note:   &EntityNote{},
+			want:   true,
+		},
+		{
+			name:   "Player, members note",
+			roleID: 2,
+			note:   &EntityNote{SimpleEntityNote: SimpleEntityNote{Visibility: VisibilityMembers}},
+			want:   true,
+		},
+		{
+			name:   "Player, admin note",
+			roleID: 2,
+			note:   &EntityNote{SimpleEntityNote: SimpleEntityNote{Visibility: VisibilityAdmin}},
+			want:   false,
+		},
+		{
+			name:   "Player, private note",
+			roleID: 2,
+			note:   &EntityNote{SimpleEntityNote: SimpleEntityNote{Visibility: VisibilityAll, IsPrivate: true}},
+			want:   false,
+		},
+		{
+			name:   "Public, members note",
+			roleID: 3,
+			note:   &EntityNote{SimpleEntityNote: SimpleEntityNote{Visibility: VisibilityMembers}},
+			want:   false,
+		},
+		{
+			name:   "Public, all note",
+			roleID: 3,
+			note:   &EntityNote{SimpleEntityNote: SimpleEntityNote{Visibility: VisibilityAll}},
+			want:   true,
+		},
+		{
+			name:   "Listed role, role note",
+			roleID: 4,
+			note:   &EntityNote{SimpleEntityNote: SimpleEntityNote{Visibility: VisibilityAdmin, Permissions: []NotePermission{{RoleID: 4}}}},
+			want:   true,
+		},
+		{
+			name:   "Unlisted role, role note",
+			roleID: 2,
+			note:   &EntityNote{SimpleEntityNote: SimpleEntityNote{Visibility: VisibilityAdmin, Permissions: []NotePermission{{RoleID: 4}}}},
+			want:   false,
+		},
+		{
+			name:   "Unknown role",
+			roleID: 99,
+			note:   &EntityNote{SimpleEntityNote: SimpleEntityNote{Visibility: VisibilityAll}},
+			want:   false,
+		},
+		{
+			name:   "Nil note",
+			roleID: 2,
+			note:   nil,
+			want:   false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := CanView(roles, test.roleID, test.note); got != test.want {
+				t.Errorf("got: <%t>, want: <%t>", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package kanka
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// WebhookAction is the change to an entity that triggered a webhook.
+type WebhookAction string
+
+// Available webhook actions.
+const (
+	WebhookCreated WebhookAction = "created"
+	WebhookUpdated WebhookAction = "updated"
+	WebhookDeleted WebhookAction = "deleted"
+)
+
+// maxWebhookBody is the maximum number of bytes read from a webhook request.
+const maxWebhookBody = 1 << 20
+
+// WebhookEvent represents a change to an entity reported by a Kanka webhook
+// with the JSON payload format.
+// For more information, visit: https://kanka.io/en-US/features
+type WebhookEvent struct {
+	// Action is the change made to the entity.
+	Action WebhookAction `json:"action"`
+	// Entity is the entity that was changed. Its Type and ChildID identify
+	// the concrete object, such as a Character, that was changed.
+	Entity Entity `json:"entity"`
+}
+
+// ParseWebhook decodes the payload of the provided webhook request sent by
+// Kanka into a WebhookEvent. An error is returned if the request is not a
+// POST request, the payload cannot be decoded, or the payload reports an
+// unknown action or no entity.
+// Kanka does not sign its webhook requests, so ParseWebhook cannot verify
+// their origin. Use a secret, hard to guess webhook URL to keep others from
+// sending fake events.
+func ParseWebhook(r *http.Request) (*WebhookEvent, error) {
+	if r.Method != http.MethodPost {
+		return nil, fmt.Errorf("webhook request has method '%s', want '%s'", r.Method, http.MethodPost)
+	}
+
+	var evt WebhookEvent
+	if err := json.NewDecoder(io.LimitReader(r.Body, maxWebhookBody)).Decode(&evt); err != nil {
+		return nil, fmt.Errorf("cannot decode webhook payload: %w", err)
+	}
+
+	switch evt.Action {
+	case WebhookCreated, WebhookUpdated, WebhookDeleted:
+	default:
+		return nil, fmt.Errorf("webhook payload has unknown action '%s'", evt.Action)
+	}
+
+	if evt.Entity.ID <= 0 {
+		return nil, fmt.Errorf("webhook payload is missing the entity ID")
+	}
+
+	return &evt, nil
+}
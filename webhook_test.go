@@ -0,0 +1,90 @@
+package kanka
+
+import (
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+const testWebhookUpdated string = "test_data/webhook_updated.json"
+
+func TestParseWebhook(t *testing.T) {
+	f, err := ioutil.ReadFile(testWebhookUpdated)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	evt := &WebhookEvent{
+		Action: WebhookUpdated,
+		Entity: Entity{
+			ID:         430214,
+			Name:       "Jon Snow",
+			Type:       "character",
+			ChildID:    111,
+			CampaignID: 5272,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		method  string
+		body    io.Reader
+		want    *WebhookEvent
+		wantErr bool
+	}{
+		{
+			name:    "Valid payload",
+			method:  http.MethodPost,
+			body:    strings.NewReader(string(f)),
+			want:    evt,
+			wantErr: false,
+		},
+		{
+			name:    "Invalid method",
+			method:  http.MethodGet,
+			body:    strings.NewReader(string(f)),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Invalid JSON",
+			method:  http.MethodPost,
+			body:    strings.NewReader(`{"action":`),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Unknown action",
+			method:  http.MethodPost,
+			body:    strings.NewReader(`{"action":"archived","entity":{"id":430214}}`),
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Missing entity",
+			method:  http.MethodPost,
+			body:    strings.NewReader(`{"action":"deleted"}`),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(test.method, "/webhook", test.body)
+
+			got, err := ParseWebhook(r)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("got err?: <%t>, want err?: <%t>\nerror: <%v>", (err != nil), test.wantErr, err)
+			}
+
+			if diff := cmp.Diff(got, test.want); diff != "" {
+				t.Errorf(diff)
+			}
+		})
+	}
+}